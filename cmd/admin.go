@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/sndzhng/gin-template/internal/datastore"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/usecase"
+)
+
+// description: create first super admin without setup token, refused once any admin exists
+func runBootstrap(configArgs []string, args []string) {
+	flagSet := flag.NewFlagSet("bootstrap", flag.ExitOnError)
+	username := flagSet.String("username", "", "username of first super admin")
+	password := flagSet.String("password", "", "initial password, read from stdin when omitted")
+	_ = flagSet.Parse(args)
+	if *username == "" {
+		flagSet.Usage()
+		os.Exit(2)
+	}
+
+	initialDatastore(configArgs)
+	usecase.InitialPasswordPolicy()
+	datastore.SeedPostgresql()
+
+	err := readPassword(password)
+	if err != nil {
+		log.Fatalf("Error reading password %s\n", err)
+	}
+
+	err = newAdminUsecase().Bootstrap(context.Background(), entity.Admin{Username: username, Password: password}, commandAudit("bootstrap"))
+	if err != nil {
+		log.Fatalf("Error bootstrapping %s\n", err)
+	}
+
+	log.Printf("Bootstrapped super admin %s, password must be changed at first login\n", *username)
+}
+
+// description: created admin must change password at first login, roles are seeded first so it works on fresh database
+func runCreateAdmin(configArgs []string, args []string) {
+	flagSet := flag.NewFlagSet("create-admin", flag.ExitOnError)
+	username := flagSet.String("username", "", "username of admin")
+	roleName := flagSet.String("role", "", fmt.Sprintf("role name e.g. %s or %s", entity.SuperAdminRoleName, entity.AdminRoleName))
+	password := flagSet.String("password", "", "initial password, read from stdin when omitted")
+	_ = flagSet.Parse(args)
+	if *username == "" || *roleName == "" {
+		flagSet.Usage()
+		os.Exit(2)
+	}
+
+	initialDatastore(configArgs)
+	usecase.InitialPasswordPolicy()
+	datastore.SeedPostgresql()
+
+	role, err := newRoleUsecase().Get(context.Background(), entity.Role{Name: roleName})
+	if err != nil {
+		log.Fatalf("Error getting role %s %s\n", *roleName, err)
+	}
+
+	err = readPassword(password)
+	if err != nil {
+		log.Fatalf("Error reading password %s\n", err)
+	}
+
+	admin := entity.Admin{Username: username, Password: password, RoleID: role.ID}
+	err = newAdminUsecase().Create(context.Background(), admin, commandAudit("create-admin"))
+	if err != nil {
+		log.Fatalf("Error creating admin %s\n", err)
+	}
+
+	log.Printf("Created admin %s with role %s\n", *username, *roleName)
+}
+
+// description: admin must change password at next login, tokens issued with old password are revoked and lockout from failed login is cleared too
+func runResetPassword(configArgs []string, args []string) {
+	flagSet := flag.NewFlagSet("reset-password", flag.ExitOnError)
+	username := flagSet.String("username", "", "username of admin")
+	password := flagSet.String("password", "", "new password, read from stdin when omitted")
+	_ = flagSet.Parse(args)
+	if *username == "" {
+		flagSet.Usage()
+		os.Exit(2)
+	}
+
+	initialDatastore(configArgs)
+	usecase.InitialPasswordPolicy()
+
+	ctx := context.Background()
+	adminUsecase := newAdminUsecase()
+	admin, err := adminUsecase.Get(ctx, entity.Admin{Username: username})
+	if err != nil {
+		log.Fatalf("Error getting admin %s %s\n", *username, err)
+	}
+
+	err = readPassword(password)
+	if err != nil {
+		log.Fatalf("Error reading password %s\n", err)
+	}
+
+	isResetPassword := true
+	err = adminUsecase.Update(ctx, entity.Admin{ID: admin.ID, Password: password, IsResetPassword: &isResetPassword}, commandAudit("reset-password"))
+	if err != nil {
+		log.Fatalf("Error resetting password %s\n", err)
+	}
+
+	err = newSessionUsecase().RevokeAll(ctx, entity.Session{AdminID: admin.ID})
+	if err != nil {
+		log.Fatalf("Error revoking tokens %s\n", err)
+	}
+
+	if admin.LockUntil != nil || (admin.FailedLogin != nil && *admin.FailedLogin > 0) {
+		err = adminUsecase.Unlock(ctx, entity.Admin{ID: admin.ID}, commandAudit("reset-password"))
+		if err != nil {
+			log.Fatalf("Error unlocking admin %s\n", err)
+		}
+	}
+
+	log.Printf("Reset password of admin %s\n", *username)
+}
+
+// description: audit log of command has no actor, user agent tells which command made the change, command is trusted like super admin
+func commandAudit(command string) entity.Audit {
+	userAgent := fmt.Sprintf("cli/%s", command)
+
+	return entity.Audit{
+		ActorRoles:       []entity.RoleName{entity.SuperAdminRoleName},
+		ActorPermissions: entity.AllPermissionNames(),
+		UserAgent:        &userAgent,
+	}
+}
+
+func newAdminUsecase() usecase.Admin {
+	return usecase.NewAdminUsecase(
+		repository.NewAdminRepository(datastore.Postgresql),
+		repository.NewPasswordHistoryRepository(datastore.Postgresql),
+		repository.NewRefreshTokenRepository(datastore.Postgresql),
+		repository.NewRevokedTokenRepository(datastore.Postgresql),
+		repository.NewRoleRepository(datastore.Postgresql),
+		repository.NewTransactionRepository(datastore.Postgresql),
+		repository.NewUserRepository(datastore.Postgresql),
+	)
+}
+
+func newRoleUsecase() usecase.Role {
+	return usecase.NewRoleUsecase(
+		repository.NewAdminRepository(datastore.Postgresql),
+		repository.NewPermissionRepository(datastore.Postgresql),
+		repository.NewRoleRepository(datastore.Postgresql),
+		repository.NewTransactionRepository(datastore.Postgresql),
+	)
+}
+
+func newSessionUsecase() usecase.Session {
+	return usecase.NewSessionUsecase(
+		repository.NewRefreshTokenRepository(datastore.Postgresql),
+		repository.NewRevokedTokenRepository(datastore.Postgresql),
+		repository.NewSessionRepository(datastore.Postgresql),
+		repository.NewTransactionRepository(datastore.Postgresql),
+		repository.NewUserRepository(datastore.Postgresql),
+	)
+}
+
+// description: password flag would be kept in shell history, so stdin is preferred and only read when flag is empty
+func readPassword(password *string) error {
+	if *password != "" {
+		return nil
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return err
+	}
+
+	*password = strings.TrimRight(line, "\r\n")
+	if *password == "" {
+		return errors.New("password is empty")
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -13,26 +14,127 @@ import (
 	"github.com/sndzhng/gin-template/internal/config"
 	"github.com/sndzhng/gin-template/internal/controller/route"
 	"github.com/sndzhng/gin-template/internal/datastore"
+	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/usecase"
 )
 
+const usage = `usage: main [environment] [command]
+commands:
+  serve                                         start HTTP server, default command
+  bootstrap --username [--password]             create first super admin, refused once any admin exists
+  migrate up | down [steps] | status | create   manage schema migrations
+  seed                                          seed permissions and default roles
+  create-admin --username --role [--password]   create admin, password is read from stdin when omitted
+  reset-password --username [--password]        reset admin password and unlock it
+  purge-deleted                                 purge rows deleted longer than retention once
+  config check                                  validate configuration and database connection`
+
 func main() {
 	log.SetFlags(log.Flags() &^ (log.Ldate | log.Ltime))
 
-	config.InitialConfig(os.Args)
-	config.InitialTimeZone()
+	commands := map[string]func(configArgs []string, args []string){
+		"bootstrap":      runBootstrap,
+		"config":         runConfig,
+		"create-admin":   runCreateAdmin,
+		"migrate":        runMigrate,
+		"purge-deleted":  runPurgeDeleted,
+		"reset-password": runResetPassword,
+		"seed":           runSeed,
+		"serve":          runServe,
+	}
+
+	configArgs, commandArgs := splitArgs(os.Args, commands)
+	if len(commandArgs) == 0 {
+		runServe(configArgs, commandArgs)
+		return
+	}
+
+	command, isExist := commands[commandArgs[0]]
+	if !isExist {
+		log.Fatal(usage)
+	}
+	command(configArgs, commandArgs[1:])
+}
+
+func runServe(configArgs []string, args []string) {
+	if len(args) > 0 {
+		log.Fatal(usage)
+	}
+
+	initialDatastore(configArgs)
+	middleware.InitialJWTKeys()
+	usecase.InitialPasswordPolicy()
 
 	// datastore.ConnectCloudStorage()
 	// datastore.ConnectMongodb()
 	// defer datastore.DisconnectMongodb()
-	datastore.ConnectPostgresql()
+	err := datastore.MigrateUp()
+	if err != nil {
+		log.Fatalf("Error migrating postgresql %s\n", err)
+	}
+	datastore.SeedPostgresql()
 
+	// description: parent of every request context, cancelled after shutdown so queries still running are stopped
+	baseContext, cancelBase := context.WithCancel(context.Background())
 	server := &http.Server{
-		Addr:    fmt.Sprintf(":%s", config.Server.Port),
-		Handler: route.SetupRouter(),
+		Addr:        fmt.Sprintf(":%s", config.Server.Port),
+		Handler:     route.SetupRouter(),
+		BaseContext: func(net.Listener) context.Context { return baseContext },
 	}
 
+	go startPurgeJob(baseContext, usecase.NewPurgeUsecase(repository.NewAdminRepository(datastore.Postgresql), repository.NewUserRepository(datastore.Postgresql)))
 	go startServer(server)
-	shutdownServer(server)
+	shutdownServer(server, cancelBase)
+}
+
+// description: config and postgresql initialisation shared by every command touching the database
+func initialDatastore(configArgs []string) {
+	config.InitialConfig(configArgs)
+	config.InitialTimeZone()
+	datastore.ConnectPostgresql()
+}
+
+// description: program and optional environment are passed to config, anything after is a command e.g. `main local migrate up`
+func splitArgs(args []string, commands map[string]func(configArgs []string, args []string)) ([]string, []string) {
+	if len(args) < 2 {
+		return args, []string{}
+	}
+	if _, isExist := commands[args[1]]; isExist {
+		return args[:1], args[1:]
+	}
+
+	return args[:2], args[2:]
+}
+
+// description: permanently remove rows soft deleted longer than retention on every interval, empty interval disables the job
+func startPurgeJob(ctx context.Context, purgeUsecase usecase.Purge) {
+	if config.Purge.Interval == "" {
+		return
+	}
+
+	interval, err := time.ParseDuration(config.Purge.Interval)
+	if err != nil {
+		log.Fatalf("Error parsing purge interval %s\n", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		result, err := purgeUsecase.PurgeDeleted(ctx)
+		if err != nil {
+			log.Printf("Error purging deleted rows %s\n", err)
+			continue
+		}
+
+		log.Printf("Purged %d admins and %d users deleted over %s ago\n", result.AdminAmount, result.UserAmount, config.Purge.DeletedRetention)
+	}
 }
 
 func startServer(server *http.Server) {
@@ -45,7 +147,8 @@ func startServer(server *http.Server) {
 	}
 }
 
-func shutdownServer(server *http.Server) {
+// description: requests still running when the timeout is reached are cancelled through the base context
+func shutdownServer(server *http.Server, cancelBase context.CancelFunc) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -54,6 +157,7 @@ func shutdownServer(server *http.Server) {
 	contextTimeout, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	err := server.Shutdown(contextTimeout)
+	cancelBase()
 	if err != nil {
 		log.Fatal("Server Shutdown ", err)
 	}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/datastore"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status | create <name>"
+
+// description: run migrate subcommand then exit, create only writes files so it needs neither config nor database
+func runMigrate(configArgs []string, args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	if args[0] == "create" {
+		if len(args) != 2 {
+			log.Fatal(migrateUsage)
+		}
+
+		paths, err := datastore.CreateMigration(datastore.MigrationDirectory, args[1])
+		if err != nil {
+			log.Fatalf("Error creating migration %s\n", err)
+		}
+		for _, path := range paths {
+			log.Printf("Created %s\n", path)
+		}
+		return
+	}
+
+	initialDatastore(configArgs)
+
+	switch args[0] {
+	case "up":
+		err := datastore.MigrateUp()
+		if err != nil {
+			log.Fatalf("Error migrating up %s\n", err)
+		}
+		log.Print("Migrated up")
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			parsedSteps, err := strconv.Atoi(args[1])
+			if err != nil || parsedSteps < 1 {
+				log.Fatal(migrateUsage)
+			}
+			steps = parsedSteps
+		}
+
+		err := datastore.MigrateDown(steps)
+		if err != nil {
+			log.Fatalf("Error migrating down %s\n", err)
+		}
+		log.Printf("Migrated down %d step(s)\n", steps)
+	case "status":
+		migrations, err := datastore.MigrationStatus()
+		if err != nil {
+			log.Fatalf("Error reading migration status %s\n", err)
+		}
+		for _, migration := range migrations {
+			status := "pending"
+			if migration.ApplyAt != nil {
+				status = migration.ApplyAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", migration.Version, migration.Name, status)
+		}
+	default:
+		log.Fatal(migrateUsage)
+	}
+}
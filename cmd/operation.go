@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/datastore"
+	"github.com/sndzhng/gin-template/internal/identity"
+	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/usecase"
+)
+
+// description: every initialisation of serve is run without listening, any failure exits with non zero status
+func runConfig(configArgs []string, args []string) {
+	if len(args) != 1 || args[0] != "check" {
+		log.Fatal(usage)
+	}
+
+	config.InitialConfig(configArgs)
+	errs := config.Validate()
+	for _, err := range errs {
+		log.Printf("Error invalid config %s\n", err)
+	}
+	if len(errs) > 0 {
+		log.Fatalf("Config of %s environment is invalid\n", config.Environment)
+	}
+
+	config.InitialTimeZone()
+	middleware.InitialJWTKeys()
+	usecase.InitialPasswordPolicy()
+	identity.NewProvider()
+	middleware.Timeout(config.Server.Timeout, config.Server.RouteTimeout)
+	datastore.ConnectPostgresql()
+
+	migrations, err := datastore.MigrationStatus()
+	if err != nil {
+		log.Fatalf("Error reading migration status %s\n", err)
+	}
+	for _, migration := range migrations {
+		if migration.ApplyAt == nil {
+			log.Printf("Migration %04d_%s is pending\n", migration.Version, migration.Name)
+		}
+	}
+
+	log.Printf("Config of %s environment is valid\n", config.Environment)
+}
+
+func runPurgeDeleted(configArgs []string, args []string) {
+	if len(args) > 0 {
+		log.Fatal(usage)
+	}
+
+	initialDatastore(configArgs)
+
+	purgeUsecase := usecase.NewPurgeUsecase(repository.NewAdminRepository(datastore.Postgresql), repository.NewUserRepository(datastore.Postgresql))
+	result, err := purgeUsecase.PurgeDeleted(context.Background())
+	if err != nil {
+		log.Fatalf("Error purging deleted rows %s\n", err)
+	}
+
+	log.Printf("Purged %d admins and %d users deleted over %s ago\n", result.AdminAmount, result.UserAmount, config.Purge.DeletedRetention)
+}
+
+// description: seeding is idempotent, migrations must be applied first
+func runSeed(configArgs []string, args []string) {
+	if len(args) > 0 {
+		log.Fatal(usage)
+	}
+
+	initialDatastore(configArgs)
+	datastore.SeedPostgresql()
+
+	log.Print("Seeded permissions and roles")
+}
@@ -5,6 +5,50 @@ const (
 	localEnvironment       = "local"
 	productionEnvironment  = "production"
 
+	accountLockedErrorCode             = "ACCOUNT_LOCKED"
+	adminOwnsUserErrorCode             = "ADMIN_OWNS_USER"
+	badRequestErrorCode                = "BAD_REQUEST"
+	bootstrapDoneErrorCode             = "BOOTSTRAP_DONE"
+	duplicateValueErrorCode            = "DUPLICATE_VALUE"
+	forbiddenErrorCode                 = "FORBIDDEN"
+	impersonationForbiddenErrorCode    = "IMPERSONATION_FORBIDDEN"
+	internalErrorCode                  = "INTERNAL_ERROR"
+	invalidDeletedFilterErrorCode      = "INVALID_DELETED_FILTER"
+	invalidMFACodeErrorCode            = "INVALID_MFA_CODE"
+	invalidMFATokenErrorCode           = "INVALID_MFA_TOKEN"
+	invalidOIDCStateErrorCode          = "INVALID_OIDC_STATE"
+	invalidPasswordResetTokenErrorCode = "INVALID_PASSWORD_RESET_TOKEN"
+	invalidRecoveryCodeErrorCode       = "INVALID_RECOVERY_CODE"
+	invalidRefreshTokenErrorCode       = "INVALID_REFRESH_TOKEN"
+	invalidSortOrderErrorCode          = "INVALID_SORT_ORDER"
+	invalidValueErrorCode              = "INVALID_VALUE"
+	loginFailedErrorCode               = "LOGIN_FAILED"
+	mfaAlreadyEnabledErrorCode         = "MFA_ALREADY_ENABLED"
+	mfaCodeRequiredErrorCode           = "MFA_CODE_REQUIRED"
+	mfaNotEnabledErrorCode             = "MFA_NOT_ENABLED"
+	mfaNotEnrolledErrorCode            = "MFA_NOT_ENROLLED"
+	mfaRequiredErrorCode               = "MFA_REQUIRED"
+	mfaTokenRequiredErrorCode          = "MFA_TOKEN_REQUIRED"
+	notFoundErrorCode                  = "NOT_FOUND"
+	oidcCodeRequiredErrorCode          = "OIDC_CODE_REQUIRED"
+	oidcDisabledErrorCode              = "OIDC_DISABLED"
+	oidcLoginFailedErrorCode           = "OIDC_LOGIN_FAILED"
+	oidcRoleNotMappedErrorCode         = "OIDC_ROLE_NOT_MAPPED"
+	passwordResetRequiredErrorCode     = "PASSWORD_RESET_REQUIRED"
+	preconditionRequiredErrorCode      = "PRECONDITION_REQUIRED"
+	rateLimitedErrorCode               = "RATE_LIMITED"
+	referenceNotFoundErrorCode         = "REFERENCE_NOT_FOUND"
+	refreshTokenExpiredErrorCode       = "REFRESH_TOKEN_EXPIRED"
+	refreshTokenReusedErrorCode        = "REFRESH_TOKEN_REUSED"
+	requestTimeoutErrorCode            = "REQUEST_TIMEOUT"
+	restoreConflictErrorCode           = "RESTORE_CONFLICT"
+	roleInUseErrorCode                 = "ROLE_IN_USE"
+	superAdminRoleProtectedErrorCode   = "SUPER_ADMIN_ROLE_PROTECTED"
+	unauthorizedErrorCode              = "UNAUTHORIZED"
+	usernameTakenErrorCode             = "USERNAME_TAKEN"
+	validationErrorCode                = "VALIDATION_FAILED"
+	versionMismatchErrorCode           = "VERSION_MISMATCH"
+
 	emailRegexp        = `^[a-zA-Z0-9_.+-]+@[a-zA-Z0-9-]+\.[a-zA-Z0-9-.]+$`
 	passwordRegexp     = `^[a-zA-Z0-9]{8,}([._]?[a-zA-Z0-9]+)*$`
 	phoneRegexp        = `^[0-9]{10,12}$`
@@ -0,0 +1,8 @@
+package common
+
+import _ "embed"
+
+// description: offline list of most common passwords, one per line
+//
+//go:embed common_password.txt
+var CommonPasswordList string
@@ -8,6 +8,60 @@ var (
 		Local:       localEnvironment,
 		Production:  productionEnvironment,
 	}
+	ErrorCode = struct {
+		AccountLocked, AdminOwnsUser, BadRequest, BootstrapDone, DuplicateValue, Forbidden,
+		ImpersonationForbidden, Internal, InvalidDeletedFilter, InvalidMFACode, InvalidMFAToken, InvalidOIDCState,
+		InvalidPasswordResetToken, InvalidRecoveryCode, InvalidRefreshToken, InvalidSortOrder, InvalidValue, LoginFailed,
+		MFAAlreadyEnabled, MFACodeRequired, MFANotEnabled, MFANotEnrolled, MFARequired, MFATokenRequired,
+		NotFound, OIDCCodeRequired, OIDCDisabled, OIDCLoginFailed, OIDCRoleNotMapped, PasswordResetRequired,
+		PreconditionRequired, RateLimited, ReferenceNotFound, RefreshTokenExpired, RefreshTokenReused, RequestTimeout,
+		RestoreConflict, RoleInUse, SuperAdminRoleProtected, Unauthorized, UsernameTaken, Validation,
+		VersionMismatch string
+	}{
+		AccountLocked:             accountLockedErrorCode,
+		AdminOwnsUser:             adminOwnsUserErrorCode,
+		BadRequest:                badRequestErrorCode,
+		BootstrapDone:             bootstrapDoneErrorCode,
+		DuplicateValue:            duplicateValueErrorCode,
+		Forbidden:                 forbiddenErrorCode,
+		ImpersonationForbidden:    impersonationForbiddenErrorCode,
+		Internal:                  internalErrorCode,
+		InvalidDeletedFilter:      invalidDeletedFilterErrorCode,
+		InvalidMFACode:            invalidMFACodeErrorCode,
+		InvalidMFAToken:           invalidMFATokenErrorCode,
+		InvalidOIDCState:          invalidOIDCStateErrorCode,
+		InvalidPasswordResetToken: invalidPasswordResetTokenErrorCode,
+		InvalidRecoveryCode:       invalidRecoveryCodeErrorCode,
+		InvalidRefreshToken:       invalidRefreshTokenErrorCode,
+		InvalidSortOrder:          invalidSortOrderErrorCode,
+		InvalidValue:              invalidValueErrorCode,
+		LoginFailed:               loginFailedErrorCode,
+		MFAAlreadyEnabled:         mfaAlreadyEnabledErrorCode,
+		MFACodeRequired:           mfaCodeRequiredErrorCode,
+		MFANotEnabled:             mfaNotEnabledErrorCode,
+		MFANotEnrolled:            mfaNotEnrolledErrorCode,
+		MFARequired:               mfaRequiredErrorCode,
+		MFATokenRequired:          mfaTokenRequiredErrorCode,
+		NotFound:                  notFoundErrorCode,
+		OIDCCodeRequired:          oidcCodeRequiredErrorCode,
+		OIDCDisabled:              oidcDisabledErrorCode,
+		OIDCLoginFailed:           oidcLoginFailedErrorCode,
+		OIDCRoleNotMapped:         oidcRoleNotMappedErrorCode,
+		PasswordResetRequired:     passwordResetRequiredErrorCode,
+		PreconditionRequired:      preconditionRequiredErrorCode,
+		RateLimited:               rateLimitedErrorCode,
+		ReferenceNotFound:         referenceNotFoundErrorCode,
+		RefreshTokenExpired:       refreshTokenExpiredErrorCode,
+		RefreshTokenReused:        refreshTokenReusedErrorCode,
+		RequestTimeout:            requestTimeoutErrorCode,
+		RestoreConflict:           restoreConflictErrorCode,
+		RoleInUse:                 roleInUseErrorCode,
+		SuperAdminRoleProtected:   superAdminRoleProtectedErrorCode,
+		Unauthorized:              unauthorizedErrorCode,
+		UsernameTaken:             usernameTakenErrorCode,
+		Validation:                validationErrorCode,
+		VersionMismatch:           versionMismatchErrorCode,
+	}
 	Regexp = struct {
 		Email, Password, Phone, TimeDuration, URL, Username string
 	}{
@@ -3,7 +3,10 @@ package config
 import (
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -11,13 +14,22 @@ import (
 )
 
 var (
+	Bootstrap   BootstrapConfig
 	Datastore   DatastoreConfig
 	Environment string
 	JWT         JWTConfig
+	MFA         MFAConfig
+	Notifier    NotifierConfig
+	OIDC        OIDCConfig
+	Password    PasswordConfig
+	Purge       PurgeConfig
 	Server      ServerConfig
 )
 
 type (
+	BootstrapConfig struct {
+		SetupToken string
+	}
 	DatastoreConfig struct {
 		CloudStorage CloudStorageConfig
 		Mongodb      MongodbConfig
@@ -33,10 +45,29 @@ type (
 		Database, Host, Password, Port, TimeZone, User string
 	}
 	JWTConfig struct {
-		ExpireMinute, Key string
+		Algorithm, ExpireMinute, HMACVerifyUntil, Key, KeyID, PrivateKeyFile, PublicKeyDirectory, RefreshExpireMinute string
+	}
+	MFAConfig struct {
+		Issuer string
+	}
+	NotifierConfig struct {
+		Driver, File string
+		SMTP         SMTPConfig
+	}
+	OIDCConfig struct {
+		ClientID, ClientSecret, GroupClaim, Issuer, RedirectURL, RoleMapping, Scope, UsernameClaim string
+	}
+	SMTPConfig struct {
+		From, Host, Password, Port, Username string
+	}
+	PasswordConfig struct {
+		CharacterClass, CommonListFile, HistoryAmount, MinLength, Regexp, ResetExpireMinute string
+	}
+	PurgeConfig struct {
+		DeletedRetention, Interval string
 	}
 	ServerConfig struct {
-		Context, Port string
+		Context, ErrorFormat, Port, RouteTimeout, Timeout, TrustedProxies string
 	}
 )
 
@@ -50,6 +81,9 @@ func InitialConfig(args []string) {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	Bootstrap = BootstrapConfig{
+		SetupToken: getEnv("BOOTSTRAP_SETUP_TOKEN"),
+	}
 	Datastore = DatastoreConfig{
 		CloudStorage: CloudStorageConfig{
 			BucketName: getEnv("DATASTORE_CLOUD_STORAGE_BUCKET_NAME"),
@@ -74,13 +108,112 @@ func InitialConfig(args []string) {
 	}
 	Environment = getEnv("ENVIRONMENT")
 	JWT = JWTConfig{
-		ExpireMinute: getEnv("JWT_EXPIRE_MINUTE"),
-		Key:          getEnv("JWT_KEY"),
+		Algorithm:           getEnv("JWT_ALGORITHM"),
+		ExpireMinute:        getEnv("JWT_EXPIRE_MINUTE"),
+		HMACVerifyUntil:     getEnv("JWT_HMAC_VERIFY_UNTIL"),
+		Key:                 getEnv("JWT_KEY"),
+		KeyID:               getEnv("JWT_KEY_ID"),
+		PrivateKeyFile:      getEnv("JWT_PRIVATE_KEY_FILE"),
+		PublicKeyDirectory:  getEnv("JWT_PUBLIC_KEY_DIRECTORY"),
+		RefreshExpireMinute: getEnv("JWT_REFRESH_EXPIRE_MINUTE"),
+	}
+	MFA = MFAConfig{
+		Issuer: getEnv("MFA_ISSUER"),
+	}
+	Notifier = NotifierConfig{
+		Driver: getEnv("NOTIFIER_DRIVER"),
+		File:   getEnv("NOTIFIER_FILE"),
+		SMTP: SMTPConfig{
+			From:     getEnv("NOTIFIER_SMTP_FROM"),
+			Host:     getEnv("NOTIFIER_SMTP_HOST"),
+			Password: getEnv("NOTIFIER_SMTP_PASSWORD"),
+			Port:     getEnv("NOTIFIER_SMTP_PORT"),
+			Username: getEnv("NOTIFIER_SMTP_USERNAME"),
+		},
+	}
+	OIDC = OIDCConfig{
+		ClientID:      getEnv("OIDC_CLIENT_ID"),
+		ClientSecret:  getEnv("OIDC_CLIENT_SECRET"),
+		GroupClaim:    getEnv("OIDC_GROUP_CLAIM"),
+		Issuer:        getEnv("OIDC_ISSUER"),
+		RedirectURL:   getEnv("OIDC_REDIRECT_URL"),
+		RoleMapping:   getEnv("OIDC_ROLE_MAPPING"),
+		Scope:         getEnv("OIDC_SCOPE"),
+		UsernameClaim: getEnv("OIDC_USERNAME_CLAIM"),
+	}
+	Password = PasswordConfig{
+		CharacterClass:    getEnv("PASSWORD_CHARACTER_CLASS"),
+		CommonListFile:    getEnv("PASSWORD_COMMON_LIST_FILE"),
+		HistoryAmount:     getEnv("PASSWORD_HISTORY_AMOUNT"),
+		MinLength:         getEnv("PASSWORD_MIN_LENGTH"),
+		Regexp:            getEnv("PASSWORD_REGEXP"),
+		ResetExpireMinute: getEnv("PASSWORD_RESET_EXPIRE_MINUTE"),
+	}
+	Purge = PurgeConfig{
+		DeletedRetention: getEnv("PURGE_DELETED_RETENTION"),
+		Interval:         getEnv("PURGE_INTERVAL"),
 	}
 	Server = ServerConfig{
-		Context: getEnv("SERVER_CONTEXT"),
-		Port:    getEnv("SERVER_PORT"),
+		Context:        getEnv("SERVER_CONTEXT"),
+		ErrorFormat:    getEnv("SERVER_ERROR_FORMAT"),
+		Port:           getEnv("SERVER_PORT"),
+		RouteTimeout:   getEnv("SERVER_ROUTE_TIMEOUT"),
+		Timeout:        getEnv("SERVER_TIMEOUT"),
+		TrustedProxies: getEnv("SERVER_TRUSTED_PROXIES"),
+	}
+}
+
+// description: duration values are parsed only when used, so they are checked here before the service runs with them
+func Validate() []error {
+	errs := []error{}
+	durations := map[string]string{
+		"JWT_EXPIRE_MINUTE":            JWT.ExpireMinute,
+		"JWT_REFRESH_EXPIRE_MINUTE":    JWT.RefreshExpireMinute,
+		"PASSWORD_RESET_EXPIRE_MINUTE": Password.ResetExpireMinute,
+		"PURGE_DELETED_RETENTION":      Purge.DeletedRetention,
 	}
+	if Purge.Interval != "" {
+		durations["PURGE_INTERVAL"] = Purge.Interval
+	}
+	if Server.Timeout != "" {
+		durations["SERVER_TIMEOUT"] = Server.Timeout
+	}
+	for key, value := range durations {
+		_, err := time.ParseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	for _, trustedProxy := range Server.GetTrustedProxies() {
+		_, _, err := net.ParseCIDR(trustedProxy)
+		if err != nil && net.ParseIP(trustedProxy) == nil {
+			errs = append(errs, fmt.Errorf("SERVER_TRUSTED_PROXIES: invalid ip or cidr %s", trustedProxy))
+		}
+	}
+	if JWT.HMACVerifyUntil != "" {
+		_, err := time.Parse(time.RFC3339, JWT.HMACVerifyUntil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("JWT_HMAC_VERIFY_UNTIL: %w", err))
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Error() < errs[j].Error()
+	})
+
+	return errs
+}
+
+// description: comma separated ip or cidr of proxies whose X-Forwarded-For is trusted, empty trusts none
+func (serverConfig ServerConfig) GetTrustedProxies() []string {
+	trustedProxies := []string{}
+	for _, trustedProxy := range strings.Split(serverConfig.TrustedProxies, ",") {
+		trustedProxy = strings.TrimSpace(trustedProxy)
+		if trustedProxy != "" {
+			trustedProxies = append(trustedProxies, trustedProxy)
+		}
+	}
+
+	return trustedProxies
 }
 
 func InitialTimeZone() {
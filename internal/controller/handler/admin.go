@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
 	"github.com/sndzhng/gin-template/internal/entity"
 	"github.com/sndzhng/gin-template/internal/usecase"
 	"github.com/sndzhng/gin-template/internal/util"
@@ -12,11 +13,14 @@ import (
 
 type (
 	Admin interface {
+		Bootstrap(c *gin.Context)
 		Create(c *gin.Context)
 		DeleteByID(c *gin.Context)
 		GetAll(c *gin.Context)
 		GetByID(c *gin.Context)
-		Initial(c *gin.Context)
+		PurgeByID(c *gin.Context)
+		RestoreByID(c *gin.Context)
+		UnlockByID(c *gin.Context)
 		UpdateByID(c *gin.Context)
 	}
 	adminHandler struct {
@@ -28,16 +32,34 @@ func NewAdminHandler(adminUsecase usecase.Admin) Admin {
 	return &adminHandler{adminUsecase: adminUsecase}
 }
 
+func (handler *adminHandler) Bootstrap(ginContext *gin.Context) {
+	adminBootstrap := entity.AdminBootstrap{}
+	err := ginContext.ShouldBindJSON(&adminBootstrap)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+
+	admin := entity.Admin{Username: adminBootstrap.Username, Password: adminBootstrap.Password}
+	err = handler.adminUsecase.Bootstrap(ginContext.Request.Context(), admin, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusCreated)
+}
+
 func (handler *adminHandler) Create(ginContext *gin.Context) {
 	admin := entity.Admin{}
 	err := ginContext.ShouldBindJSON(&admin)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewBindingError(err))
 		return
 	}
 	admin.PreventField()
 
-	err = handler.adminUsecase.Create(admin)
+	err = handler.adminUsecase.Create(ginContext.Request.Context(), admin, util.GetAudit(ginContext))
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
@@ -50,12 +72,18 @@ func (handler *adminHandler) DeleteByID(ginContext *gin.Context) {
 	admin := entity.Admin{}
 	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
 		return
 	}
 	admin.ID = &id
 
-	err = handler.adminUsecase.Delete(admin)
+	admin.Version, err = util.GetIfMatchVersion(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	err = handler.adminUsecase.Delete(ginContext.Request.Context(), admin, util.GetAudit(ginContext))
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
@@ -67,26 +95,30 @@ func (handler *adminHandler) DeleteByID(ginContext *gin.Context) {
 func (handler *adminHandler) GetAll(ginContext *gin.Context) {
 	adminFilter := entity.AdminFilter{}
 	_ = ginContext.ShouldBindQuery(&adminFilter)
+	if !adminFilter.DeletedFilter.Validate() {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.InvalidDeletedFilter, "invalid deleted filter"))
+		return
+	}
 
 	sortOrder := entity.InitialSortOrder()
 	err := ginContext.ShouldBindQuery(&sortOrder)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewBindingError(err))
 		return
 	}
 	if !sortOrder.Validate() {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: "invalid pagination sort order"})
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.InvalidSortOrder, "invalid pagination sort order"))
 		return
 	}
 
 	pagination := entity.Pagination{}
 	err = ginContext.ShouldBindQuery(&pagination)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewBindingError(err))
 		return
 	}
 
-	admins, err := handler.adminUsecase.GetAll(&adminFilter, &sortOrder, &pagination)
+	admins, err := handler.adminUsecase.GetAll(ginContext.Request.Context(), &adminFilter, &sortOrder, &pagination)
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
@@ -105,28 +137,74 @@ func (handler *adminHandler) GetAll(ginContext *gin.Context) {
 func (handler *adminHandler) GetByID(ginContext *gin.Context) {
 	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
 		return
 	}
 
 	admin := entity.Admin{ID: &id}
-	admin, err = handler.adminUsecase.Get(admin)
+	admin, err = handler.adminUsecase.Get(ginContext.Request.Context(), admin)
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
 	}
 
+	if util.IsNotModified(ginContext, admin.Version) {
+		ginContext.Status(http.StatusNotModified)
+		return
+	}
+
 	ginContext.JSON(http.StatusOK, admin)
 }
 
-func (handler *adminHandler) Initial(ginContext *gin.Context) {
-	err := handler.adminUsecase.Initial()
+func (handler *adminHandler) PurgeByID(ginContext *gin.Context) {
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	admin := entity.Admin{ID: &id}
+	err = handler.adminUsecase.Purge(ginContext.Request.Context(), admin, util.GetAudit(ginContext))
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
 	}
 
-	ginContext.Status(http.StatusCreated)
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *adminHandler) RestoreByID(ginContext *gin.Context) {
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	admin := entity.Admin{ID: &id}
+	err = handler.adminUsecase.Restore(ginContext.Request.Context(), admin, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *adminHandler) UnlockByID(ginContext *gin.Context) {
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	admin := entity.Admin{ID: &id}
+	err = handler.adminUsecase.Unlock(ginContext.Request.Context(), admin, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
 }
 
 func (handler *adminHandler) UpdateByID(ginContext *gin.Context) {
@@ -136,12 +214,18 @@ func (handler *adminHandler) UpdateByID(ginContext *gin.Context) {
 
 	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
 		return
 	}
 	admin.ID = &id
 
-	err = handler.adminUsecase.Update(admin)
+	admin.Version, err = util.GetIfMatchVersion(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	err = handler.adminUsecase.Update(ginContext.Request.Context(), admin, util.GetAudit(ginContext))
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
@@ -2,15 +2,18 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/common"
 	"github.com/sndzhng/gin-template/internal/controller/handler"
 	"github.com/sndzhng/gin-template/internal/entity"
 	"github.com/sndzhng/gin-template/internal/util"
@@ -31,6 +34,61 @@ func beforeTestAdmin(test *testing.T) (
 	return mockAdminUsecase, adminHandler
 }
 
+func TestAdminBootstrap(test *testing.T) {
+	mockAdminUsecase, adminHandler := beforeTestAdmin(test)
+
+	path := "/admin/{context}/admin/bootstrap"
+	username := "superadmin01"
+	password := "password"
+	adminBootstrap := entity.AdminBootstrap{Username: &username, Password: &password}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAdminUsecase.EXPECT().Bootstrap(gomock.Any(), entity.Admin{Username: &username, Password: &password}, gomock.Any()).Return(nil)
+
+		body, err := json.Marshal(adminBootstrap)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, adminHandler.Bootstrap)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusCreated, response.Code)
+	})
+
+	test.Run("Conflict", func(test *testing.T) {
+		mockAdminUsecase.EXPECT().Bootstrap(gomock.Any(), gomock.Any(), gomock.Any()).Return(util.NewError(common.ErrorCode.BootstrapDone, ""))
+
+		body, err := json.Marshal(adminBootstrap)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, adminHandler.Bootstrap)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusConflict, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		body, err := json.Marshal(entity.AdminBootstrap{Username: &username})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, adminHandler.Bootstrap)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
 func TestAdminCreate(test *testing.T) {
 	mockAdminUsecase, adminHandler := beforeTestAdmin(test)
 
@@ -45,7 +103,7 @@ func TestAdminCreate(test *testing.T) {
 	}
 
 	test.Run("Success", func(test *testing.T) {
-		mockAdminUsecase.EXPECT().Create(admin).Return(nil)
+		mockAdminUsecase.EXPECT().Create(gomock.Any(), admin, gomock.Any()).Return(nil)
 
 		body, err := json.Marshal(admin)
 		assert.NoError(test, err)
@@ -61,7 +119,7 @@ func TestAdminCreate(test *testing.T) {
 	})
 
 	test.Run("InternalError", func(test *testing.T) {
-		mockAdminUsecase.EXPECT().Create(admin).Return(util.Error{Code: http.StatusInternalServerError})
+		mockAdminUsecase.EXPECT().Create(gomock.Any(), admin, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
 
 		body, err := json.Marshal(admin)
 		assert.NoError(test, err)
@@ -99,9 +157,10 @@ func TestAdminDeleteByID(test *testing.T) {
 	admin := entity.Admin{ID: &id}
 
 	test.Run("Success", func(test *testing.T) {
-		mockAdminUsecase.EXPECT().Delete(admin).Return(nil)
+		mockAdminUsecase.EXPECT().Delete(gomock.Any(), admin, gomock.Any()).Return(nil)
 
 		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		request.Header.Set("If-Match", "*")
 		response := httptest.NewRecorder()
 		router := gin.Default()
 
@@ -112,9 +171,10 @@ func TestAdminDeleteByID(test *testing.T) {
 	})
 
 	test.Run("InternalError", func(t *testing.T) {
-		mockAdminUsecase.EXPECT().Delete(admin).Return(util.Error{Code: http.StatusInternalServerError})
+		mockAdminUsecase.EXPECT().Delete(gomock.Any(), admin, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
 
 		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		request.Header.Set("If-Match", "*")
 		response := httptest.NewRecorder()
 		router := gin.Default()
 
@@ -124,6 +184,17 @@ func TestAdminDeleteByID(test *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, response.Code)
 	})
 
+	test.Run("PreconditionRequired", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, adminHandler.DeleteByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusPreconditionRequired, response.Code)
+	})
+
 	test.Run("BadRequest", func(t *testing.T) {
 		request := httptest.NewRequest(http.MethodDelete, path, nil)
 		response := httptest.NewRecorder()
@@ -157,7 +228,7 @@ func TestAdminGetAll(test *testing.T) {
 	test.Run("Success", func(test *testing.T) {
 		admins := []entity.Admin{{ID: &id, Role: &entity.Role{}, RoleID: &id, Username: &username}}
 
-		mockAdminUsecase.EXPECT().GetAll(&adminFilter, &sortOrder, &pagination).Return(admins, nil)
+		mockAdminUsecase.EXPECT().GetAll(gomock.Any(), &adminFilter, &sortOrder, &pagination).Return(admins, nil)
 
 		request := httptest.NewRequest(http.MethodGet,
 			fmt.Sprintf(
@@ -184,8 +255,8 @@ func TestAdminGetAll(test *testing.T) {
 	})
 
 	test.Run("InternalError", func(t *testing.T) {
-		mockAdminUsecase.EXPECT().GetAll(&adminFilter, &sortOrder, &pagination).
-			Return([]entity.Admin{}, util.Error{Code: http.StatusInternalServerError})
+		mockAdminUsecase.EXPECT().GetAll(gomock.Any(), &adminFilter, &sortOrder, &pagination).
+			Return([]entity.Admin{}, util.NewError(common.ErrorCode.Internal, ""))
 
 		request := httptest.NewRequest(http.MethodGet,
 			fmt.Sprintf(
@@ -212,6 +283,17 @@ func TestAdminGetAll(test *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, response.Code)
 	})
+
+	test.Run("BadRequest/Deleted", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?deleted=all&limit=%d", path, pagination.Limit), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, adminHandler.GetAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
 }
 
 func TestAdminGetByID(test *testing.T) {
@@ -232,7 +314,7 @@ func TestAdminGetByID(test *testing.T) {
 			Password: &password,
 		}
 
-		mockAdminUsecase.EXPECT().Get(admin).Return(returnAdmin, nil)
+		mockAdminUsecase.EXPECT().Get(gomock.Any(), admin).Return(returnAdmin, nil)
 
 		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
 		response := httptest.NewRecorder()
@@ -248,8 +330,41 @@ func TestAdminGetByID(test *testing.T) {
 		assert.Equal(test, string(encodedReturnAdmin), response.Body.String())
 	})
 
+	test.Run("Success/ETag", func(test *testing.T) {
+		version := uint64(3)
+
+		mockAdminUsecase.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{ID: &id, Version: &version}, nil)
+
+		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, adminHandler.GetByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+		assert.Equal(test, `"3"`, response.Header().Get("ETag"))
+	})
+
+	test.Run("NotModified", func(test *testing.T) {
+		version := uint64(3)
+
+		mockAdminUsecase.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{ID: &id, Version: &version}, nil)
+
+		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		request.Header.Set("If-None-Match", `W/"2", "3"`)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, adminHandler.GetByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotModified, response.Code)
+		assert.Empty(test, response.Body.String())
+	})
+
 	test.Run("InternalError", func(t *testing.T) {
-		mockAdminUsecase.EXPECT().Get(admin).Return(entity.Admin{}, util.Error{Code: http.StatusInternalServerError})
+		mockAdminUsecase.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{}, util.NewError(common.ErrorCode.Internal, ""))
 
 		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
 		response := httptest.NewRecorder()
@@ -261,6 +376,22 @@ func TestAdminGetByID(test *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, response.Code)
 	})
 
+	test.Run("ServiceUnavailable", func(test *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+		defer cancel()
+
+		mockAdminUsecase.EXPECT().Get(ctx, admin).Return(entity.Admin{}, util.NewError(common.ErrorCode.Internal, ctx.Err().Error()))
+
+		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil).WithContext(ctx)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, adminHandler.GetByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusServiceUnavailable, response.Code)
+	})
+
 	test.Run("BadRequest", func(t *testing.T) {
 		request := httptest.NewRequest(http.MethodGet, path, nil)
 		response := httptest.NewRecorder()
@@ -273,35 +404,164 @@ func TestAdminGetByID(test *testing.T) {
 	})
 }
 
-func TestAdminInitial(test *testing.T) {
+func TestAdminPurgeByID(test *testing.T) {
 	mockAdminUsecase, adminHandler := beforeTestAdmin(test)
 
-	path := "/admin/{context}/admin/initial"
+	path := "/admin/{context}/admin/:id/purge"
+	id := uint64(1)
+	admin := entity.Admin{ID: &id}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAdminUsecase.EXPECT().Purge(gomock.Any(), admin, gomock.Any()).Return(nil)
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, adminHandler.PurgeByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
 
-	test.Run("Success", func(t *testing.T) {
-		mockAdminUsecase.EXPECT().Initial().Return(nil)
+	test.Run("Conflict", func(test *testing.T) {
+		mockAdminUsecase.EXPECT().Purge(gomock.Any(), admin, gomock.Any()).Return(util.NewError(common.ErrorCode.AdminOwnsUser, ""))
 
-		request := httptest.NewRequest(http.MethodPost, path, nil)
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
 		response := httptest.NewRecorder()
 		router := gin.Default()
 
-		router.POST(path, adminHandler.Initial)
+		router.DELETE(path, adminHandler.PurgeByID)
 		router.ServeHTTP(response, request)
 
-		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(test, http.StatusConflict, response.Code)
 	})
 
-	test.Run("InternalError", func(t *testing.T) {
-		mockAdminUsecase.EXPECT().Initial().Return(util.Error{Code: http.StatusInternalServerError})
+	test.Run("InternalError", func(test *testing.T) {
+		mockAdminUsecase.EXPECT().Purge(gomock.Any(), admin, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
 
-		request := httptest.NewRequest(http.MethodPost, path, nil)
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
 		response := httptest.NewRecorder()
 		router := gin.Default()
 
-		router.POST(path, adminHandler.Initial)
+		router.DELETE(path, adminHandler.PurgeByID)
 		router.ServeHTTP(response, request)
 
-		assert.Equal(t, http.StatusInternalServerError, response.Code)
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, adminHandler.PurgeByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestAdminRestoreByID(test *testing.T) {
+	mockAdminUsecase, adminHandler := beforeTestAdmin(test)
+
+	path := "/admin/{context}/admin/:id/restore"
+	id := uint64(1)
+	admin := entity.Admin{ID: &id}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAdminUsecase.EXPECT().Restore(gomock.Any(), admin, gomock.Any()).Return(nil)
+
+		request := httptest.NewRequest(http.MethodPatch, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, adminHandler.RestoreByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockAdminUsecase.EXPECT().Restore(gomock.Any(), admin, gomock.Any()).Return(util.NewError(common.ErrorCode.NotFound, ""))
+
+		request := httptest.NewRequest(http.MethodPatch, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, adminHandler.RestoreByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotFound, response.Code)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAdminUsecase.EXPECT().Restore(gomock.Any(), admin, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodPatch, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, adminHandler.RestoreByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodPatch, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, adminHandler.RestoreByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestAdminUnlockByID(test *testing.T) {
+	mockAdminUsecase, adminHandler := beforeTestAdmin(test)
+
+	path := "/admin/{context}/admin/:id/lock"
+	id := uint64(1)
+	admin := entity.Admin{ID: &id}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAdminUsecase.EXPECT().Unlock(gomock.Any(), admin, gomock.Any()).Return(nil)
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, adminHandler.UnlockByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAdminUsecase.EXPECT().Unlock(gomock.Any(), admin, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, adminHandler.UnlockByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, adminHandler.UnlockByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
 	})
 }
 
@@ -320,12 +580,34 @@ func TestAdminUpdateByID(test *testing.T) {
 	}
 
 	test.Run("Success", func(test *testing.T) {
-		mockAdminUsecase.EXPECT().Update(admin).Return(nil)
+		mockAdminUsecase.EXPECT().Update(gomock.Any(), admin, gomock.Any()).Return(nil)
+
+		body, err := json.Marshal(admin)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), bytes.NewReader(body))
+		request.Header.Set("If-Match", "*")
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PUT(path, adminHandler.UpdateByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("Success/IfMatch", func(test *testing.T) {
+		version := uint64(3)
+		versionedAdmin := admin
+		versionedAdmin.Version = &version
+
+		mockAdminUsecase.EXPECT().Update(gomock.Any(), versionedAdmin, gomock.Any()).Return(nil)
 
 		body, err := json.Marshal(admin)
 		assert.NoError(test, err)
 
 		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), bytes.NewReader(body))
+		request.Header.Set("If-Match", `"3"`)
 		response := httptest.NewRecorder()
 		router := gin.Default()
 
@@ -335,13 +617,46 @@ func TestAdminUpdateByID(test *testing.T) {
 		assert.Equal(test, http.StatusOK, response.Code)
 	})
 
+	test.Run("PreconditionFailed", func(test *testing.T) {
+		mockAdminUsecase.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(util.NewError(common.ErrorCode.VersionMismatch, ""))
+
+		body, err := json.Marshal(admin)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), bytes.NewReader(body))
+		request.Header.Set("If-Match", `"2"`)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PUT(path, adminHandler.UpdateByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusPreconditionFailed, response.Code)
+	})
+
+	test.Run("BadRequest/IfMatch", func(test *testing.T) {
+		body, err := json.Marshal(admin)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), bytes.NewReader(body))
+		request.Header.Set("If-Match", `W/"3"`)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PUT(path, adminHandler.UpdateByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+
 	test.Run("InternalError", func(test *testing.T) {
-		mockAdminUsecase.EXPECT().Update(admin).Return(util.Error{Code: http.StatusInternalServerError})
+		mockAdminUsecase.EXPECT().Update(gomock.Any(), admin, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
 
 		body, err := json.Marshal(admin)
 		assert.NoError(test, err)
 
 		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), bytes.NewReader(body))
+		request.Header.Set("If-Match", "*")
 		response := httptest.NewRecorder()
 		router := gin.Default()
 
@@ -351,6 +666,20 @@ func TestAdminUpdateByID(test *testing.T) {
 		assert.Equal(test, http.StatusInternalServerError, response.Code)
 	})
 
+	test.Run("PreconditionRequired", func(test *testing.T) {
+		body, err := json.Marshal(admin)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PUT(path, adminHandler.UpdateByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusPreconditionRequired, response.Code)
+	})
+
 	test.Run("BadRequest", func(test *testing.T) {
 		request := httptest.NewRequest(http.MethodPut, path, nil)
 		response := httptest.NewRecorder()
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+type (
+	APIKey interface {
+		Create(c *gin.Context)
+		GetAll(c *gin.Context)
+		GetByID(c *gin.Context)
+		RevokeByID(c *gin.Context)
+	}
+	apiKeyHandler struct {
+		apiKeyUsecase usecase.APIKey
+	}
+)
+
+func NewAPIKeyHandler(apiKeyUsecase usecase.APIKey) APIKey {
+	return &apiKeyHandler{apiKeyUsecase: apiKeyUsecase}
+}
+
+// description: key is owned by the admin who creates it
+func (handler *apiKeyHandler) Create(ginContext *gin.Context) {
+	apiKey := entity.APIKey{}
+	err := ginContext.ShouldBindJSON(&apiKey)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+	apiKey.PreventField()
+
+	subject, err := util.GetClaimSubject(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+	apiKey.AdminID = &subject
+
+	apiKey, err = handler.apiKeyUsecase.Create(ginContext.Request.Context(), apiKey, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusCreated, apiKey)
+}
+
+// description: only keys of the requesting admin are listed, admin id in query is ignored
+func (handler *apiKeyHandler) GetAll(ginContext *gin.Context) {
+	apiKeyFilter := entity.APIKeyFilter{}
+	_ = ginContext.ShouldBindQuery(&apiKeyFilter)
+
+	sortOrder := entity.InitialSortOrder()
+	err := ginContext.ShouldBindQuery(&sortOrder)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+	if !sortOrder.Validate("create_at", "expire_at", "last_use_at") {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.InvalidSortOrder, "invalid pagination sort order"))
+		return
+	}
+
+	pagination := entity.Pagination{}
+	err = ginContext.ShouldBindQuery(&pagination)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+
+	subject, err := util.GetClaimSubject(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+	apiKeyFilter.AdminID = &subject
+
+	apiKeys, err := handler.apiKeyUsecase.GetAll(ginContext.Request.Context(), &apiKeyFilter, &sortOrder, &pagination)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(
+		http.StatusOK,
+		entity.APIKeysWithNavigate{
+			APIKeys:    apiKeys,
+			Pagination: pagination,
+			SortOrder:  sortOrder,
+		},
+	)
+}
+
+// description: key of another admin is not found
+func (handler *apiKeyHandler) GetByID(ginContext *gin.Context) {
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	subject, err := util.GetClaimSubject(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+
+	apiKey := entity.APIKey{ID: &id, AdminID: &subject}
+	apiKey, err = handler.apiKeyUsecase.Get(ginContext.Request.Context(), apiKey)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, apiKey)
+}
+
+// description: key of another admin is not found
+func (handler *apiKeyHandler) RevokeByID(ginContext *gin.Context) {
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	subject, err := util.GetClaimSubject(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+
+	apiKey := entity.APIKey{ID: &id, AdminID: &subject}
+	err = handler.apiKeyUsecase.Revoke(ginContext.Request.Context(), apiKey, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
@@ -0,0 +1,306 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/controller/handler"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/util"
+	usecasemock "github.com/sndzhng/gin-template/mock/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func beforeTestAPIKey(test *testing.T) (
+	*usecasemock.MockAPIKey,
+	handler.APIKey,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	mockAPIKeyUsecase := usecasemock.NewMockAPIKey(controller)
+	apiKeyHandler := handler.NewAPIKeyHandler(mockAPIKeyUsecase)
+
+	return mockAPIKeyUsecase, apiKeyHandler
+}
+
+func mockAPIKeyOwnerAuthorization(id uint64) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+}
+
+func TestAPIKeyCreate(test *testing.T) {
+	mockAPIKeyUsecase, apiKeyHandler := beforeTestAPIKey(test)
+
+	path := "/admin/{context}/api-key"
+	id := uint64(1)
+	name := "batch"
+	apiKey := entity.APIKey{
+		Name:   &name,
+		Scopes: []entity.APIKeyScope{entity.ReadAPIKeyScope, entity.WriteAPIKeyScope},
+	}
+
+	mockMiddlewareAuthorization := mockAPIKeyOwnerAuthorization(id)
+
+	test.Run("Success", func(test *testing.T) {
+		key := "gtk_00000000_secret"
+		ownedAPIKey := apiKey
+		ownedAPIKey.AdminID = &id
+		createdAPIKey := ownedAPIKey
+		createdAPIKey.ID = &id
+		createdAPIKey.Key = &key
+
+		mockAPIKeyUsecase.EXPECT().Create(gomock.Any(), ownedAPIKey, gomock.Any()).Return(createdAPIKey, nil)
+
+		body, err := json.Marshal(apiKey)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, apiKeyHandler.Create)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusCreated, response.Code)
+
+		encodedAPIKey, err := json.Marshal(createdAPIKey)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedAPIKey), response.Body.String())
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		ownedAPIKey := apiKey
+		ownedAPIKey.AdminID = &id
+
+		mockAPIKeyUsecase.EXPECT().Create(gomock.Any(), ownedAPIKey, gomock.Any()).Return(entity.APIKey{}, util.NewError(common.ErrorCode.Internal, ""))
+
+		body, err := json.Marshal(apiKey)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, apiKeyHandler.Create)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("BadRequest/Scope", func(test *testing.T) {
+		body, err := json.Marshal(entity.APIKey{Name: &name, Scopes: []entity.APIKeyScope{"delete"}})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, apiKeyHandler.Create)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		body, err := json.Marshal(entity.APIKey{})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, apiKeyHandler.Create)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestAPIKeyGetAll(test *testing.T) {
+	mockAPIKeyUsecase, apiKeyHandler := beforeTestAPIKey(test)
+
+	path := "/admin/{context}/api-key"
+	id := uint64(1)
+	otherID := uint64(2)
+	isActive := true
+	apiKeyFilter := entity.APIKeyFilter{
+		APIKey:   entity.APIKey{AdminID: &id},
+		IsActive: &isActive,
+	}
+	sortOrder := entity.InitialSortOrder()
+	pagination := entity.Pagination{
+		Limit:  10,
+		Offset: 0,
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		prefix := "gtk_00000000"
+		apiKeys := []entity.APIKey{{ID: &id, AdminID: &id, Prefix: &prefix}}
+
+		mockAPIKeyUsecase.EXPECT().GetAll(gomock.Any(), &apiKeyFilter, &sortOrder, &pagination).Return(apiKeys, nil)
+
+		request := httptest.NewRequest(http.MethodGet,
+			fmt.Sprintf(
+				"%s?admin_id=%d&is_active=%t&limit=%d&offset=%d",
+				path, otherID, isActive, pagination.Limit, pagination.Offset,
+			), nil,
+		)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockAPIKeyOwnerAuthorization(id), apiKeyHandler.GetAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		apiKeysWithNavigate := entity.APIKeysWithNavigate{
+			APIKeys:    apiKeys,
+			Pagination: pagination,
+			SortOrder:  sortOrder,
+		}
+		encodedAPIKeysWithNavigate, err := json.Marshal(apiKeysWithNavigate)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedAPIKeysWithNavigate), response.Body.String())
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAPIKeyUsecase.EXPECT().GetAll(gomock.Any(), &apiKeyFilter, &sortOrder, &pagination).
+			Return([]entity.APIKey{}, util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodGet,
+			fmt.Sprintf(
+				"%s?admin_id=%d&is_active=%t&limit=%d&offset=%d",
+				path, otherID, isActive, pagination.Limit, pagination.Offset,
+			), nil,
+		)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockAPIKeyOwnerAuthorization(id), apiKeyHandler.GetAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockAPIKeyOwnerAuthorization(id), apiKeyHandler.GetAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestAPIKeyGetByID(test *testing.T) {
+	mockAPIKeyUsecase, apiKeyHandler := beforeTestAPIKey(test)
+
+	path := "/admin/{context}/api-key/:id"
+	id := uint64(1)
+	apiKey := entity.APIKey{ID: &id, AdminID: &id}
+
+	test.Run("Success", func(test *testing.T) {
+		keyHash := "keyHash"
+		returnAPIKey := entity.APIKey{ID: &id, AdminID: &id, KeyHash: &keyHash}
+
+		mockAPIKeyUsecase.EXPECT().Get(gomock.Any(), apiKey).Return(returnAPIKey, nil)
+
+		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockAPIKeyOwnerAuthorization(id), apiKeyHandler.GetByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+		assert.NotContains(test, response.Body.String(), keyHash)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockAPIKeyUsecase.EXPECT().Get(gomock.Any(), apiKey).Return(entity.APIKey{}, util.NewError(common.ErrorCode.NotFound, ""))
+
+		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockAPIKeyOwnerAuthorization(id), apiKeyHandler.GetByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotFound, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockAPIKeyOwnerAuthorization(id), apiKeyHandler.GetByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestAPIKeyRevokeByID(test *testing.T) {
+	mockAPIKeyUsecase, apiKeyHandler := beforeTestAPIKey(test)
+
+	path := "/admin/{context}/api-key/:id"
+	id := uint64(1)
+	apiKey := entity.APIKey{ID: &id, AdminID: &id}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAPIKeyUsecase.EXPECT().Revoke(gomock.Any(), apiKey, gomock.Any()).Return(nil)
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockAPIKeyOwnerAuthorization(id), apiKeyHandler.RevokeByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockAPIKeyUsecase.EXPECT().Revoke(gomock.Any(), apiKey, gomock.Any()).Return(util.NewError(common.ErrorCode.NotFound, ""))
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockAPIKeyOwnerAuthorization(id), apiKeyHandler.RevokeByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotFound, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockAPIKeyOwnerAuthorization(id), apiKeyHandler.RevokeByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+type (
+	AuditLog interface {
+		GetAll(ginContext *gin.Context)
+	}
+	auditLogHandler struct {
+		auditLogUsecase usecase.AuditLog
+	}
+)
+
+func NewAuditLogHandler(auditLogUsecase usecase.AuditLog) AuditLog {
+	return &auditLogHandler{auditLogUsecase: auditLogUsecase}
+}
+
+func (handler *auditLogHandler) GetAll(ginContext *gin.Context) {
+	auditLogFilter := entity.AuditLogFilter{}
+	_ = ginContext.ShouldBindQuery(&auditLogFilter)
+
+	sortOrder := entity.SortOrder{Sort: "id", Order: "desc"}
+	err := ginContext.ShouldBindQuery(&sortOrder)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+	if !sortOrder.Validate("create_at") {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.InvalidSortOrder, "invalid pagination sort order"))
+		return
+	}
+
+	pagination := entity.Pagination{}
+	err = ginContext.ShouldBindQuery(&pagination)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+
+	auditLogs, err := handler.auditLogUsecase.GetAll(ginContext.Request.Context(), &auditLogFilter, &sortOrder, &pagination)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(
+		http.StatusOK,
+		entity.AuditLogsWithNavigate{
+			AuditLogs:  auditLogs,
+			Pagination: pagination,
+			SortOrder:  sortOrder,
+		},
+	)
+}
@@ -0,0 +1,111 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/controller/handler"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/util"
+	usecasemock "github.com/sndzhng/gin-template/mock/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func beforeTestAuditLog(test *testing.T) (
+	*usecasemock.MockAuditLog,
+	handler.AuditLog,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	mockAuditLogUsecase := usecasemock.NewMockAuditLog(controller)
+	auditLogHandler := handler.NewAuditLogHandler(mockAuditLogUsecase)
+
+	return mockAuditLogUsecase, auditLogHandler
+}
+
+func TestAuditLogGetAll(test *testing.T) {
+	mockAuditLogUsecase, auditLogHandler := beforeTestAuditLog(test)
+
+	path := "/admin/{context}/audit"
+	id := uint64(1)
+	action := entity.UpdateAuditAction
+	entityType := entity.UserAuditEntityType
+	auditLogFilter := entity.AuditLogFilter{
+		AuditLog: entity.AuditLog{ActorID: &id, Action: &action, EntityType: &entityType, EntityID: &id},
+	}
+	sortOrder := entity.SortOrder{Sort: "id", Order: "desc"}
+	pagination := entity.Pagination{
+		Limit:  10,
+		Offset: 0,
+	}
+	url := fmt.Sprintf(
+		"%s?actor_id=%d&action=%s&entity_type=%s&entity_id=%d&limit=%d&offset=%d",
+		path, id, action, entityType, id, pagination.Limit, pagination.Offset,
+	)
+
+	test.Run("Success", func(test *testing.T) {
+		auditLogs := []entity.AuditLog{
+			{
+				ID:         &id,
+				ActorID:    &id,
+				Action:     &action,
+				EntityType: &entityType,
+				EntityID:   &id,
+				Before:     entity.AuditChange{"name": "before"},
+				After:      entity.AuditChange{"name": "after"},
+			},
+		}
+
+		mockAuditLogUsecase.EXPECT().GetAll(gomock.Any(), &auditLogFilter, &sortOrder, &pagination).Return(auditLogs, nil)
+
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, auditLogHandler.GetAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		auditLogsWithNavigate := entity.AuditLogsWithNavigate{
+			AuditLogs:  auditLogs,
+			Pagination: pagination,
+			SortOrder:  sortOrder,
+		}
+		encodedAuditLogsWithNavigate, err := json.Marshal(auditLogsWithNavigate)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedAuditLogsWithNavigate), response.Body.String())
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAuditLogUsecase.EXPECT().GetAll(gomock.Any(), &auditLogFilter, &sortOrder, &pagination).
+			Return([]entity.AuditLog{}, util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, auditLogHandler.GetAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?limit=10&sort=action", path), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, auditLogHandler.GetAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
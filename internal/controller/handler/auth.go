@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
 	"github.com/sndzhng/gin-template/internal/entity"
 	"github.com/sndzhng/gin-template/internal/usecase"
 	"github.com/sndzhng/gin-template/internal/util"
@@ -12,8 +13,19 @@ import (
 type (
 	Auth interface {
 		AdminLogin(c *gin.Context)
+		AdminOIDCCallback(c *gin.Context)
+		AdminOIDCLogin(c *gin.Context)
+		AdminRefresh(c *gin.Context)
+		AdminReset(c *gin.Context)
+		AdminVerifyMFA(c *gin.Context)
+		Logout(c *gin.Context)
+		LogoutAll(c *gin.Context)
+		UserForgot(c *gin.Context)
+		UserForgotConfirm(c *gin.Context)
 		UserLogin(c *gin.Context)
+		UserRefresh(c *gin.Context)
 		UserReset(c *gin.Context)
+		UserVerifyMFA(c *gin.Context)
 	}
 	authHandler struct {
 		authUsecase usecase.Auth
@@ -28,11 +40,12 @@ func (handler *authHandler) AdminLogin(ginContext *gin.Context) {
 	login := entity.Login{}
 	err := ginContext.ShouldBindJSON(&login)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewBindingError(err))
 		return
 	}
+	login.Device = util.GetDevice(ginContext)
 
-	accessToken, err := handler.authUsecase.AdminLogin(login)
+	accessToken, err := handler.authUsecase.AdminLogin(ginContext.Request.Context(), login)
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
@@ -41,15 +54,214 @@ func (handler *authHandler) AdminLogin(ginContext *gin.Context) {
 	ginContext.JSON(http.StatusOK, accessToken)
 }
 
+func (handler *authHandler) AdminOIDCCallback(ginContext *gin.Context) {
+	oidcCallback := entity.OIDCCallback{}
+	err := ginContext.ShouldBindQuery(&oidcCallback)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+	oidcCallback.Device = util.GetDevice(ginContext)
+
+	accessToken, err := handler.authUsecase.AdminOIDCCallback(ginContext.Request.Context(), oidcCallback)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, accessToken)
+}
+
+func (handler *authHandler) AdminOIDCLogin(ginContext *gin.Context) {
+	authorizationURL, err := handler.authUsecase.AdminOIDCLogin(ginContext.Request.Context())
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Redirect(http.StatusFound, authorizationURL)
+}
+
+func (handler *authHandler) AdminRefresh(ginContext *gin.Context) {
+	refresh := entity.Refresh{}
+	err := ginContext.ShouldBindJSON(&refresh)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+	refresh.Device = util.GetDevice(ginContext)
+
+	accessToken, err := handler.authUsecase.AdminRefresh(ginContext.Request.Context(), refresh)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, accessToken)
+}
+
+func (handler *authHandler) AdminReset(ginContext *gin.Context) {
+	reset := entity.Reset{}
+	err := ginContext.ShouldBindJSON(&reset)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+
+	subject, err := util.GetClaimSubject(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+	reset.ID = &subject
+
+	err = handler.authUsecase.AdminReset(ginContext.Request.Context(), reset, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *authHandler) AdminVerifyMFA(ginContext *gin.Context) {
+	mfa := entity.MFA{}
+	err := ginContext.ShouldBindJSON(&mfa)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+	mfa.Device = util.GetDevice(ginContext)
+	if mfa.MFAToken == nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.MFATokenRequired, "mfa token is required"))
+		return
+	}
+
+	accessToken, err := handler.authUsecase.AdminVerifyMFA(ginContext.Request.Context(), mfa, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, accessToken)
+}
+
+func (handler *authHandler) Logout(ginContext *gin.Context) {
+	logout := entity.Logout{}
+	_ = ginContext.ShouldBindJSON(&logout)
+
+	claims, err := util.GetClaims(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+	revokedToken, err := claims.RevokedToken()
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+	logout.AdminID = revokedToken.AdminID
+	logout.UserID = revokedToken.UserID
+	logout.SessionID = revokedToken.SessionID
+	logout.JTI = revokedToken.JTI
+	logout.ExpireAt = revokedToken.ExpireAt
+
+	err = handler.authUsecase.Logout(ginContext.Request.Context(), logout)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *authHandler) LogoutAll(ginContext *gin.Context) {
+	claims, err := util.GetClaims(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+	revokedToken, err := claims.RevokedToken()
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+	logout := entity.Logout{
+		AdminID: revokedToken.AdminID,
+		UserID:  revokedToken.UserID,
+	}
+
+	err = handler.authUsecase.LogoutAll(ginContext.Request.Context(), logout)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *authHandler) UserForgot(ginContext *gin.Context) {
+	forgot := entity.Forgot{}
+	err := ginContext.ShouldBindJSON(&forgot)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+
+	err = handler.authUsecase.UserForgot(ginContext.Request.Context(), forgot)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusAccepted)
+}
+
+func (handler *authHandler) UserForgotConfirm(ginContext *gin.Context) {
+	forgotConfirm := entity.ForgotConfirm{}
+	err := ginContext.ShouldBindJSON(&forgotConfirm)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+
+	err = handler.authUsecase.UserForgotConfirm(ginContext.Request.Context(), forgotConfirm, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
 func (handler *authHandler) UserLogin(ginContext *gin.Context) {
 	login := entity.Login{}
 	err := ginContext.ShouldBindJSON(&login)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+	login.Device = util.GetDevice(ginContext)
+
+	accessToken, err := handler.authUsecase.UserLogin(ginContext.Request.Context(), login)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, accessToken)
+}
+
+func (handler *authHandler) UserRefresh(ginContext *gin.Context) {
+	refresh := entity.Refresh{}
+	err := ginContext.ShouldBindJSON(&refresh)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
 		return
 	}
+	refresh.Device = util.GetDevice(ginContext)
 
-	accessToken, err := handler.authUsecase.UserLogin(login)
+	accessToken, err := handler.authUsecase.UserRefresh(ginContext.Request.Context(), refresh)
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
@@ -62,7 +274,7 @@ func (handler *authHandler) UserReset(ginContext *gin.Context) {
 	reset := entity.Reset{}
 	err := ginContext.ShouldBindJSON(&reset)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewBindingError(err))
 		return
 	}
 
@@ -73,7 +285,7 @@ func (handler *authHandler) UserReset(ginContext *gin.Context) {
 	}
 	reset.ID = &subject
 
-	err = handler.authUsecase.UserReset(reset)
+	err = handler.authUsecase.UserReset(ginContext.Request.Context(), reset, util.GetAudit(ginContext))
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
@@ -81,3 +293,25 @@ func (handler *authHandler) UserReset(ginContext *gin.Context) {
 
 	ginContext.Status(http.StatusOK)
 }
+
+func (handler *authHandler) UserVerifyMFA(ginContext *gin.Context) {
+	mfa := entity.MFA{}
+	err := ginContext.ShouldBindJSON(&mfa)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+	mfa.Device = util.GetDevice(ginContext)
+	if mfa.MFAToken == nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.MFATokenRequired, "mfa token is required"))
+		return
+	}
+
+	accessToken, err := handler.authUsecase.UserVerifyMFA(ginContext.Request.Context(), mfa, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, accessToken)
+}
@@ -7,10 +7,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
 	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/common"
 	"github.com/sndzhng/gin-template/internal/controller/handler"
 	"github.com/sndzhng/gin-template/internal/entity"
 	"github.com/sndzhng/gin-template/internal/middleware"
@@ -32,6 +34,20 @@ func beforeTestAuth(test *testing.T) (
 	return mockAuthUsecase, authHandler
 }
 
+// description: device seen by handlers for requests built with httptest
+func testDevice() entity.Device {
+	userAgent := ""
+	ipAddress := "192.0.2.1"
+
+	return entity.Device{UserAgent: &userAgent, IPAddress: &ipAddress}
+}
+
+func testAudit(actorID *uint64) entity.Audit {
+	device := testDevice()
+
+	return entity.Audit{ActorID: actorID, IPAddress: device.IPAddress, UserAgent: device.UserAgent}
+}
+
 func TestAdminLogin(test *testing.T) {
 	mockAuthUsecase, authHandler := beforeTestAuth(test)
 
@@ -41,12 +57,13 @@ func TestAdminLogin(test *testing.T) {
 	login := entity.Login{
 		Username: &username,
 		Password: &password,
+		Device:   testDevice(),
 	}
 
 	test.Run("Success", func(test *testing.T) {
 		accessToken := entity.AccessToken{AccessToken: new(string)}
 
-		mockAuthUsecase.EXPECT().AdminLogin(login).Return(accessToken, nil)
+		mockAuthUsecase.EXPECT().AdminLogin(gomock.Any(), login).Return(accessToken, nil)
 
 		body, err := json.Marshal(login)
 		assert.NoError(test, err)
@@ -66,7 +83,7 @@ func TestAdminLogin(test *testing.T) {
 	})
 
 	test.Run("InternalError", func(test *testing.T) {
-		mockAuthUsecase.EXPECT().AdminLogin(login).Return(entity.AccessToken{}, util.Error{Code: http.StatusInternalServerError})
+		mockAuthUsecase.EXPECT().AdminLogin(gomock.Any(), login).Return(entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, ""))
 
 		body, err := json.Marshal(login)
 		assert.NoError(test, err)
@@ -96,6 +113,452 @@ func TestAdminLogin(test *testing.T) {
 	})
 }
 
+func TestAdminOIDCCallback(test *testing.T) {
+	mockAuthUsecase, authHandler := beforeTestAuth(test)
+
+	path := "/admin/{context}/auth/oidc/callback"
+	code := "code"
+	state := "state"
+	oidcCallback := entity.OIDCCallback{
+		Code:   &code,
+		State:  &state,
+		Device: testDevice(),
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		accessToken := entity.AccessToken{AccessToken: new(string)}
+
+		mockAuthUsecase.EXPECT().AdminOIDCCallback(gomock.Any(), oidcCallback).Return(accessToken, nil)
+
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?code=%s&state=%s", path, code, state), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, authHandler.AdminOIDCCallback)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		encodedAccessToken, err := json.Marshal(accessToken)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedAccessToken), response.Body.String())
+	})
+
+	test.Run("Unauthorized", func(test *testing.T) {
+		providerError := "access_denied"
+
+		mockAuthUsecase.EXPECT().AdminOIDCCallback(gomock.Any(), entity.OIDCCallback{Error: &providerError, Device: testDevice()}).Return(entity.AccessToken{}, util.NewError(common.ErrorCode.Unauthorized, ""))
+
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?error=%s", path, providerError), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, authHandler.AdminOIDCCallback)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusUnauthorized, response.Code)
+	})
+}
+
+func TestAdminOIDCLogin(test *testing.T) {
+	mockAuthUsecase, authHandler := beforeTestAuth(test)
+
+	path := "/admin/{context}/auth/oidc/login"
+
+	test.Run("Success", func(test *testing.T) {
+		authorizationURL := "https://idp.example.com/authorize?state=state"
+
+		mockAuthUsecase.EXPECT().AdminOIDCLogin(gomock.Any()).Return(authorizationURL, nil)
+
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, authHandler.AdminOIDCLogin)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusFound, response.Code)
+		assert.Equal(test, authorizationURL, response.Header().Get("Location"))
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().AdminOIDCLogin(gomock.Any()).Return("", util.NewError(common.ErrorCode.NotFound, ""))
+
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, authHandler.AdminOIDCLogin)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestAdminRefresh(test *testing.T) {
+	mockAuthUsecase, authHandler := beforeTestAuth(test)
+
+	path := "/admin/{context}/auth/refresh"
+	token := "token"
+	refresh := entity.Refresh{
+		RefreshToken: &token,
+		Device:       testDevice(),
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		accessToken := entity.AccessToken{AccessToken: new(string), RefreshToken: new(string)}
+
+		mockAuthUsecase.EXPECT().AdminRefresh(gomock.Any(), refresh).Return(accessToken, nil)
+
+		body, err := json.Marshal(refresh)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.AdminRefresh)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		encodedAccessToken, err := json.Marshal(accessToken)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedAccessToken), response.Body.String())
+	})
+
+	test.Run("Unauthorized", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().AdminRefresh(gomock.Any(), refresh).Return(entity.AccessToken{}, util.NewError(common.ErrorCode.Unauthorized, ""))
+
+		body, err := json.Marshal(refresh)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.AdminRefresh)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusUnauthorized, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		body, err := json.Marshal(entity.Refresh{})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.AdminRefresh)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestAdminReset(test *testing.T) {
+	mockAuthUsecase, authHandler := beforeTestAuth(test)
+
+	path := "/admin/{context}/auth/reset"
+	id := uint64(1)
+	password := "password"
+	reset := entity.Reset{
+		ID:       &id,
+		Password: &password,
+	}
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			IsResetPassword: true,
+			Roles:           []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().AdminReset(gomock.Any(), reset, gomock.Any()).Return(nil)
+
+		body, err := json.Marshal(reset)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPatch, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, mockMiddlewareAuthorization, authHandler.AdminReset)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().AdminReset(gomock.Any(), reset, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
+
+		body, err := json.Marshal(reset)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPatch, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, mockMiddlewareAuthorization, authHandler.AdminReset)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("Forbidden/VerifyPasswordReset", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/admin/{context}/admin", nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET("/admin/{context}/admin", mockMiddlewareAuthorization, middleware.VerifyPasswordReset(), func(ginContext *gin.Context) {
+			ginContext.Status(http.StatusOK)
+		})
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusForbidden, response.Code)
+		assert.Contains(test, response.Body.String(), common.ErrorCode.PasswordResetRequired)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		body, err := json.Marshal(entity.Reset{})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPatch, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, authHandler.AdminReset)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestLogout(test *testing.T) {
+	mockAuthUsecase, authHandler := beforeTestAuth(test)
+
+	path := "/{context}/auth/logout"
+	id := uint64(1)
+	jti := "jti"
+	expireAt := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+	logout := entity.Logout{
+		UserID:   &id,
+		JTI:      &jti,
+		ExpireAt: &expireAt,
+	}
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				ExpiresAt: expireAt.Unix(),
+				Id:        jti,
+				Subject:   fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.UserRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().Logout(gomock.Any(), logout).Return(nil)
+
+		request := httptest.NewRequest(http.MethodPost, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, authHandler.Logout)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("InternalError/Logout", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().Logout(gomock.Any(), logout).Return(util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodPost, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, authHandler.Logout)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("InternalError/GetClaims", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.Logout)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+}
+
+func TestLogoutAll(test *testing.T) {
+	mockAuthUsecase, authHandler := beforeTestAuth(test)
+
+	path := "/admin/{context}/auth/logout/all"
+	id := uint64(1)
+	logout := entity.Logout{
+		AdminID: &id,
+	}
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().LogoutAll(gomock.Any(), logout).Return(nil)
+
+		request := httptest.NewRequest(http.MethodPost, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, authHandler.LogoutAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().LogoutAll(gomock.Any(), logout).Return(util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodPost, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, authHandler.LogoutAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+}
+
+func TestUserForgot(test *testing.T) {
+	mockAuthUsecase, authHandler := beforeTestAuth(test)
+
+	path := "/{context}/auth/forgot"
+	username := "username"
+	forgot := entity.Forgot{Username: &username}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().UserForgot(gomock.Any(), forgot).Return(nil)
+
+		body, err := json.Marshal(forgot)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.UserForgot)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusAccepted, response.Code)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().UserForgot(gomock.Any(), forgot).Return(util.NewError(common.ErrorCode.Internal, ""))
+
+		body, err := json.Marshal(forgot)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.UserForgot)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		body, err := json.Marshal(entity.Forgot{})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.UserForgot)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestUserForgotConfirm(test *testing.T) {
+	mockAuthUsecase, authHandler := beforeTestAuth(test)
+
+	path := "/{context}/auth/forgot/confirm"
+	token := "token"
+	password := "password"
+	forgotConfirm := entity.ForgotConfirm{Token: &token, Password: &password}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().UserForgotConfirm(gomock.Any(), forgotConfirm, gomock.Any()).Return(nil)
+
+		body, err := json.Marshal(forgotConfirm)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.UserForgotConfirm)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("Unauthorized", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().UserForgotConfirm(gomock.Any(), forgotConfirm, gomock.Any()).Return(util.NewError(common.ErrorCode.Unauthorized, ""))
+
+		body, err := json.Marshal(forgotConfirm)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.UserForgotConfirm)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusUnauthorized, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		body, err := json.Marshal(entity.ForgotConfirm{Token: &token})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.UserForgotConfirm)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
 func TestUserLogin(test *testing.T) {
 	mockAuthUsecase, authHandler := beforeTestAuth(test)
 
@@ -105,11 +568,12 @@ func TestUserLogin(test *testing.T) {
 	login := entity.Login{
 		Username: &username,
 		Password: &password,
+		Device:   testDevice(),
 	}
 
 	test.Run("Success", func(test *testing.T) {
 		accessToken := entity.AccessToken{AccessToken: new(string)}
-		mockAuthUsecase.EXPECT().UserLogin(login).Return(accessToken, nil)
+		mockAuthUsecase.EXPECT().UserLogin(gomock.Any(), login).Return(accessToken, nil)
 
 		body, err := json.Marshal(login)
 		assert.NoError(test, err)
@@ -129,7 +593,7 @@ func TestUserLogin(test *testing.T) {
 	})
 
 	test.Run("InternalError", func(test *testing.T) {
-		mockAuthUsecase.EXPECT().UserLogin(login).Return(entity.AccessToken{}, util.Error{Code: http.StatusInternalServerError})
+		mockAuthUsecase.EXPECT().UserLogin(gomock.Any(), login).Return(entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, ""))
 
 		body, err := json.Marshal(login)
 		assert.NoError(test, err)
@@ -159,6 +623,69 @@ func TestUserLogin(test *testing.T) {
 	})
 }
 
+func TestUserRefresh(test *testing.T) {
+	mockAuthUsecase, authHandler := beforeTestAuth(test)
+
+	path := "/{context}/auth/refresh"
+	token := "token"
+	refresh := entity.Refresh{
+		RefreshToken: &token,
+		Device:       testDevice(),
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		accessToken := entity.AccessToken{AccessToken: new(string), RefreshToken: new(string)}
+
+		mockAuthUsecase.EXPECT().UserRefresh(gomock.Any(), refresh).Return(accessToken, nil)
+
+		body, err := json.Marshal(refresh)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.UserRefresh)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		encodedAccessToken, err := json.Marshal(accessToken)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedAccessToken), response.Body.String())
+	})
+
+	test.Run("Unauthorized", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().UserRefresh(gomock.Any(), refresh).Return(entity.AccessToken{}, util.NewError(common.ErrorCode.Unauthorized, ""))
+
+		body, err := json.Marshal(refresh)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.UserRefresh)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusUnauthorized, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		body, err := json.Marshal(entity.Refresh{})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.UserRefresh)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
 func TestUserReset(test *testing.T) {
 	mockAuthUsecase, authHandler := beforeTestAuth(test)
 
@@ -171,7 +698,7 @@ func TestUserReset(test *testing.T) {
 	}
 
 	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
-		claims := middleware.CustomClaims{
+		claims := entity.Claims{
 			StandardClaims: jwt.StandardClaims{
 				Subject: fmt.Sprint(id),
 			},
@@ -180,7 +707,7 @@ func TestUserReset(test *testing.T) {
 	}
 
 	test.Run("Success", func(test *testing.T) {
-		mockAuthUsecase.EXPECT().UserReset(reset).Return(nil)
+		mockAuthUsecase.EXPECT().UserReset(gomock.Any(), reset, gomock.Any()).Return(nil)
 
 		body, err := json.Marshal(reset)
 		assert.NoError(test, err)
@@ -196,7 +723,7 @@ func TestUserReset(test *testing.T) {
 	})
 
 	test.Run("InternalError/UserReset", func(test *testing.T) {
-		mockAuthUsecase.EXPECT().UserReset(reset).Return(util.Error{Code: http.StatusInternalServerError})
+		mockAuthUsecase.EXPECT().UserReset(gomock.Any(), reset, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
 
 		body, err := json.Marshal(reset)
 		assert.NoError(test, err)
@@ -239,3 +766,68 @@ func TestUserReset(test *testing.T) {
 		assert.Equal(test, http.StatusBadRequest, response.Code)
 	})
 }
+
+func TestUserVerifyMFA(test *testing.T) {
+	mockAuthUsecase, authHandler := beforeTestAuth(test)
+
+	path := "/{context}/auth/mfa/verify"
+	code := "123456"
+	mfaToken := "mfaToken"
+	mfa := entity.MFA{
+		Code:     &code,
+		MFAToken: &mfaToken,
+		Device:   testDevice(),
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		accessToken := entity.AccessToken{AccessToken: new(string), RefreshToken: new(string)}
+
+		mockAuthUsecase.EXPECT().UserVerifyMFA(gomock.Any(), mfa, gomock.Any()).Return(accessToken, nil)
+
+		body, err := json.Marshal(mfa)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.UserVerifyMFA)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		encodedAccessToken, err := json.Marshal(accessToken)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedAccessToken), response.Body.String())
+	})
+
+	test.Run("Unauthorized", func(test *testing.T) {
+		mockAuthUsecase.EXPECT().UserVerifyMFA(gomock.Any(), mfa, gomock.Any()).Return(entity.AccessToken{}, util.NewError(common.ErrorCode.Unauthorized, ""))
+
+		body, err := json.Marshal(mfa)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.UserVerifyMFA)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusUnauthorized, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		body, err := json.Marshal(entity.MFA{Code: &code})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, authHandler.UserVerifyMFA)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
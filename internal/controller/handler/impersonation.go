@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+type (
+	Impersonation interface {
+		ImpersonateByUserID(ginContext *gin.Context)
+	}
+	impersonationHandler struct {
+		impersonationUsecase usecase.Impersonation
+	}
+)
+
+func NewImpersonationHandler(impersonationUsecase usecase.Impersonation) Impersonation {
+	return &impersonationHandler{impersonationUsecase: impersonationUsecase}
+}
+
+func (handler *impersonationHandler) ImpersonateByUserID(ginContext *gin.Context) {
+	userID, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	subject, err := util.GetClaimSubject(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+
+	impersonation := entity.Impersonation{UserID: &userID, Audit: util.GetAudit(ginContext)}
+	impersonation.Audit.ActorID = &subject
+	accessToken, err := handler.impersonationUsecase.Impersonate(ginContext.Request.Context(), impersonation)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, accessToken)
+}
@@ -0,0 +1,108 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/controller/handler"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/util"
+	usecasemock "github.com/sndzhng/gin-template/mock/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func beforeTestImpersonation(test *testing.T) (
+	*usecasemock.MockImpersonation,
+	handler.Impersonation,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	mockImpersonationUsecase := usecasemock.NewMockImpersonation(controller)
+	impersonationHandler := handler.NewImpersonationHandler(mockImpersonationUsecase)
+
+	return mockImpersonationUsecase, impersonationHandler
+}
+
+func TestImpersonationImpersonateByUserID(test *testing.T) {
+	mockImpersonationUsecase, impersonationHandler := beforeTestImpersonation(test)
+
+	path := "/admin/{context}/user/:id/impersonate"
+	adminID := uint64(1)
+	userID := uint64(2)
+	impersonation := entity.Impersonation{UserID: &userID, Audit: testAudit(&adminID)}
+	impersonation.Audit.ActorRoles = []entity.RoleName{entity.SuperAdminRoleName}
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(adminID),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		token := "token"
+		accessToken := entity.AccessToken{AccessToken: &token}
+
+		mockImpersonationUsecase.EXPECT().Impersonate(gomock.Any(), impersonation).Return(accessToken, nil)
+
+		request := httptest.NewRequest(http.MethodPost, strings.ReplaceAll(path, ":id", fmt.Sprint(userID)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, impersonationHandler.ImpersonateByUserID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		encodedAccessToken, err := json.Marshal(accessToken)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedAccessToken), response.Body.String())
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockImpersonationUsecase.EXPECT().Impersonate(gomock.Any(), impersonation).Return(entity.AccessToken{}, util.NewError(common.ErrorCode.NotFound, ""))
+
+		request := httptest.NewRequest(http.MethodPost, strings.ReplaceAll(path, ":id", fmt.Sprint(userID)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, impersonationHandler.ImpersonateByUserID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotFound, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, impersonationHandler.ImpersonateByUserID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+
+	test.Run("InternalError/Claims", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, strings.ReplaceAll(path, ":id", fmt.Sprint(userID)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, impersonationHandler.ImpersonateByUserID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/middleware"
+)
+
+type (
+	Key interface {
+		GetJSONWebKeySet(ginContext *gin.Context)
+	}
+	keyHandler struct{}
+)
+
+func NewKeyHandler() Key {
+	return &keyHandler{}
+}
+
+func (handler *keyHandler) GetJSONWebKeySet(ginContext *gin.Context) {
+	ginContext.Header("Cache-Control", "public, max-age=300")
+	ginContext.JSON(http.StatusOK, middleware.GetJSONWebKeySet())
+}
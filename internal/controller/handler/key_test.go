@@ -0,0 +1,35 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/controller/handler"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyGetJSONWebKeySet(test *testing.T) {
+	keyHandler := handler.NewKeyHandler()
+
+	path := "/.well-known/jwks.json"
+
+	test.Run("Success", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, keyHandler.GetJSONWebKeySet)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		jsonWebKeySet := entity.JSONWebKeySet{}
+		err := json.Unmarshal(response.Body.Bytes(), &jsonWebKeySet)
+		assert.NoError(test, err)
+		assert.NotNil(test, jsonWebKeySet.Keys)
+	})
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+type (
+	MFA interface {
+		Confirm(ginContext *gin.Context)
+		Disable(ginContext *gin.Context)
+		Enroll(ginContext *gin.Context)
+		EnrollByMFAToken(ginContext *gin.Context)
+	}
+	mfaHandler struct {
+		mfaUsecase usecase.MFA
+	}
+)
+
+func NewMFAHandler(mfaUsecase usecase.MFA) MFA {
+	return &mfaHandler{mfaUsecase: mfaUsecase}
+}
+
+func (handler *mfaHandler) Confirm(ginContext *gin.Context) {
+	mfa := entity.MFA{}
+	err := ginContext.ShouldBindJSON(&mfa)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+
+	err = setMFAAccount(ginContext, &mfa)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	mfaRecovery, err := handler.mfaUsecase.Confirm(ginContext.Request.Context(), mfa, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, mfaRecovery)
+}
+
+func (handler *mfaHandler) Disable(ginContext *gin.Context) {
+	mfa := entity.MFA{}
+	err := ginContext.ShouldBindJSON(&mfa)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+
+	err = setMFAAccount(ginContext, &mfa)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	err = handler.mfaUsecase.Disable(ginContext.Request.Context(), mfa, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *mfaHandler) Enroll(ginContext *gin.Context) {
+	mfa := entity.MFA{}
+	err := setMFAAccount(ginContext, &mfa)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	mfaEnrollment, err := handler.mfaUsecase.Enroll(ginContext.Request.Context(), mfa, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, mfaEnrollment)
+}
+
+// description: enroll during login when policy requires mfa and account has not enrolled yet
+func (handler *mfaHandler) EnrollByMFAToken(ginContext *gin.Context) {
+	mfa := entity.MFA{}
+	err := ginContext.ShouldBindJSON(&mfa)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+	if mfa.MFAToken == nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.MFATokenRequired, "mfa token is required"))
+		return
+	}
+	mfa.AdminID, mfa.UserID = nil, nil
+
+	mfaEnrollment, err := handler.mfaUsecase.Enroll(ginContext.Request.Context(), mfa, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, mfaEnrollment)
+}
+
+func setMFAAccount(ginContext *gin.Context, mfa *entity.MFA) error {
+	claims, err := util.GetClaims(ginContext)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	mfa.AdminID, mfa.UserID, err = claims.Account()
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	mfa.MFAToken = nil
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/controller/handler"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/util"
+	usecasemock "github.com/sndzhng/gin-template/mock/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func beforeTestMFA(test *testing.T) (
+	*usecasemock.MockMFA,
+	handler.MFA,
+	gin.HandlerFunc,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	mockMFAUsecase := usecasemock.NewMockMFA(controller)
+	mfaHandler := handler.NewMFAHandler(mockMFAUsecase)
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(1),
+			},
+			Roles: []entity.RoleName{entity.UserRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	return mockMFAUsecase, mfaHandler, mockMiddlewareAuthorization
+}
+
+func TestMFAConfirm(test *testing.T) {
+	mockMFAUsecase, mfaHandler, mockMiddlewareAuthorization := beforeTestMFA(test)
+
+	path := "/{context}/auth/mfa"
+	id := uint64(1)
+	code := "123456"
+	mfa := entity.MFA{
+		UserID: &id,
+		Code:   &code,
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mfaRecovery := entity.MFARecovery{RecoveryCodes: []string{"abcde-12345"}}
+
+		mockMFAUsecase.EXPECT().Confirm(gomock.Any(), mfa, gomock.Any()).Return(mfaRecovery, nil)
+
+		body, err := json.Marshal(mfa)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPatch, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, mockMiddlewareAuthorization, mfaHandler.Confirm)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		encodedMFARecovery, err := json.Marshal(mfaRecovery)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedMFARecovery), response.Body.String())
+	})
+
+	test.Run("Unauthorized", func(test *testing.T) {
+		mockMFAUsecase.EXPECT().Confirm(gomock.Any(), mfa, gomock.Any()).Return(entity.MFARecovery{}, util.NewError(common.ErrorCode.Unauthorized, ""))
+
+		body, err := json.Marshal(mfa)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPatch, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, mockMiddlewareAuthorization, mfaHandler.Confirm)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusUnauthorized, response.Code)
+	})
+
+	test.Run("InternalError/GetClaims", func(test *testing.T) {
+		body, err := json.Marshal(mfa)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPatch, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, mfaHandler.Confirm)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+}
+
+func TestMFADisable(test *testing.T) {
+	mockMFAUsecase, mfaHandler, mockMiddlewareAuthorization := beforeTestMFA(test)
+
+	path := "/{context}/auth/mfa"
+	id := uint64(1)
+	code := "123456"
+	mfa := entity.MFA{
+		UserID: &id,
+		Code:   &code,
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mockMFAUsecase.EXPECT().Disable(gomock.Any(), mfa, gomock.Any()).Return(nil)
+
+		body, err := json.Marshal(mfa)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodDelete, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, mfaHandler.Disable)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("Forbidden", func(test *testing.T) {
+		mockMFAUsecase.EXPECT().Disable(gomock.Any(), mfa, gomock.Any()).Return(util.NewError(common.ErrorCode.Forbidden, ""))
+
+		body, err := json.Marshal(mfa)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodDelete, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, mfaHandler.Disable)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusForbidden, response.Code)
+	})
+}
+
+func TestMFAEnroll(test *testing.T) {
+	mockMFAUsecase, mfaHandler, mockMiddlewareAuthorization := beforeTestMFA(test)
+
+	path := "/{context}/auth/mfa"
+	id := uint64(1)
+	secret := "secret"
+	mfaEnrollment := entity.MFAEnrollment{Secret: &secret}
+
+	test.Run("Success", func(test *testing.T) {
+		mockMFAUsecase.EXPECT().Enroll(gomock.Any(), entity.MFA{UserID: &id}, gomock.Any()).Return(mfaEnrollment, nil)
+
+		request := httptest.NewRequest(http.MethodPost, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, mfaHandler.Enroll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		encodedMFAEnrollment, err := json.Marshal(mfaEnrollment)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedMFAEnrollment), response.Body.String())
+	})
+
+	test.Run("Conflict", func(test *testing.T) {
+		mockMFAUsecase.EXPECT().Enroll(gomock.Any(), entity.MFA{UserID: &id}, gomock.Any()).Return(entity.MFAEnrollment{}, util.NewError(common.ErrorCode.MFAAlreadyEnabled, ""))
+
+		request := httptest.NewRequest(http.MethodPost, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mockMiddlewareAuthorization, mfaHandler.Enroll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusConflict, response.Code)
+	})
+}
+
+func TestMFAEnrollByMFAToken(test *testing.T) {
+	mockMFAUsecase, mfaHandler, _ := beforeTestMFA(test)
+
+	path := "/{context}/auth/mfa/enroll"
+	mfaToken := "mfaToken"
+	mfa := entity.MFA{MFAToken: &mfaToken}
+
+	test.Run("Success", func(test *testing.T) {
+		secret := "secret"
+		mfaEnrollment := entity.MFAEnrollment{Secret: &secret}
+
+		mockMFAUsecase.EXPECT().Enroll(gomock.Any(), mfa, gomock.Any()).Return(mfaEnrollment, nil)
+
+		body, err := json.Marshal(mfa)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mfaHandler.EnrollByMFAToken)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		body, err := json.Marshal(entity.MFA{})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, mfaHandler.EnrollByMFAToken)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
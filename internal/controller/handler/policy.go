@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+type (
+	Policy interface {
+		Get(ginContext *gin.Context)
+		Update(ginContext *gin.Context)
+	}
+	policyHandler struct {
+		policyUsecase usecase.Policy
+	}
+)
+
+func NewPolicyHandler(policyUsecase usecase.Policy) Policy {
+	return &policyHandler{policyUsecase: policyUsecase}
+}
+
+func (handler *policyHandler) Get(ginContext *gin.Context) {
+	policy, err := handler.policyUsecase.Get(ginContext.Request.Context())
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, policy)
+}
+
+func (handler *policyHandler) Update(ginContext *gin.Context) {
+	policy := entity.Policy{}
+	err := ginContext.ShouldBindJSON(&policy)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+	policy.ID, policy.UpdateAt = nil, nil
+
+	err = handler.policyUsecase.Update(ginContext.Request.Context(), policy)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
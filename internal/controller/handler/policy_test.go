@@ -0,0 +1,120 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/controller/handler"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/util"
+	usecasemock "github.com/sndzhng/gin-template/mock/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func beforeTestPolicy(test *testing.T) (
+	*usecasemock.MockPolicy,
+	handler.Policy,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	mockPolicyUsecase := usecasemock.NewMockPolicy(controller)
+	policyHandler := handler.NewPolicyHandler(mockPolicyUsecase)
+
+	return mockPolicyUsecase, policyHandler
+}
+
+func TestPolicyGet(test *testing.T) {
+	mockPolicyUsecase, policyHandler := beforeTestPolicy(test)
+
+	path := "/admin/{context}/policy"
+	isAdminMFARequired := true
+	policy := entity.Policy{IsAdminMFARequired: &isAdminMFARequired}
+
+	test.Run("Success", func(test *testing.T) {
+		mockPolicyUsecase.EXPECT().Get(gomock.Any()).Return(policy, nil)
+
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, policyHandler.Get)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		encodedPolicy, err := json.Marshal(policy)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedPolicy), response.Body.String())
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockPolicyUsecase.EXPECT().Get(gomock.Any()).Return(entity.Policy{}, util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, policyHandler.Get)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+}
+
+func TestPolicyUpdate(test *testing.T) {
+	mockPolicyUsecase, policyHandler := beforeTestPolicy(test)
+
+	path := "/admin/{context}/policy"
+	isAdminMFARequired := true
+	policy := entity.Policy{IsAdminMFARequired: &isAdminMFARequired}
+
+	test.Run("Success", func(test *testing.T) {
+		mockPolicyUsecase.EXPECT().Update(gomock.Any(), policy).Return(nil)
+
+		body, err := json.Marshal(policy)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPatch, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, policyHandler.Update)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockPolicyUsecase.EXPECT().Update(gomock.Any(), policy).Return(util.NewError(common.ErrorCode.Internal, ""))
+
+		body, err := json.Marshal(policy)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPatch, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, policyHandler.Update)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodPatch, path, bytes.NewReader([]byte("{")))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, policyHandler.Update)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
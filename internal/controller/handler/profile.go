@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
 	"github.com/sndzhng/gin-template/internal/entity"
 	"github.com/sndzhng/gin-template/internal/usecase"
 	"github.com/sndzhng/gin-template/internal/util"
@@ -33,33 +34,51 @@ func NewProfileHandler(
 func (handler *profileHandler) GetAdminByToken(ginContext *gin.Context) {
 	subject, err := util.GetClaimSubject(ginContext)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusInternalServerError, Message: err.Error()})
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
 		return
 	}
 
 	admin := entity.Admin{ID: &subject}
-	admin, err = handler.adminUsecase.Get(admin)
+	admin, err = handler.adminUsecase.Get(ginContext.Request.Context(), admin)
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
 	}
 
+	if util.IsNotModified(ginContext, admin.Version) {
+		ginContext.Status(http.StatusNotModified)
+		return
+	}
+
 	ginContext.JSON(http.StatusOK, admin)
 }
 
+// description: impersonator id marks a profile viewed through an impersonated token
 func (handler *profileHandler) GetUserByToken(ginContext *gin.Context) {
 	subject, err := util.GetClaimSubject(ginContext)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusInternalServerError, Message: err.Error()})
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+
+	actorID, err := util.GetClaimActor(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
 		return
 	}
 
 	user := entity.User{ID: &subject}
-	user, err = handler.userUsecase.Get(user)
+	user, err = handler.userUsecase.Get(ginContext.Request.Context(), user)
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
 	}
+	user.ImpersonatorID = actorID
+
+	if util.IsNotModified(ginContext, user.Version) {
+		ginContext.Status(http.StatusNotModified)
+		return
+	}
 
 	ginContext.JSON(http.StatusOK, user)
 }
@@ -10,9 +10,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
 	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/common"
 	"github.com/sndzhng/gin-template/internal/controller/handler"
 	"github.com/sndzhng/gin-template/internal/entity"
-	"github.com/sndzhng/gin-template/internal/middleware"
 	"github.com/sndzhng/gin-template/internal/util"
 	usecasemock "github.com/sndzhng/gin-template/mock/usecase"
 	"github.com/stretchr/testify/assert"
@@ -43,7 +43,7 @@ func TestProfileGetAdminByToken(test *testing.T) {
 	}
 
 	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
-		claims := middleware.CustomClaims{
+		claims := entity.Claims{
 			StandardClaims: jwt.StandardClaims{
 				Subject: fmt.Sprint(id),
 			},
@@ -60,7 +60,7 @@ func TestProfileGetAdminByToken(test *testing.T) {
 			Username: &username,
 		}
 
-		mockAdminUsecase.EXPECT().Get(admin).Return(returnAdmin, nil)
+		mockAdminUsecase.EXPECT().Get(gomock.Any(), admin).Return(returnAdmin, nil)
 
 		request := httptest.NewRequest(http.MethodGet, path, nil)
 		response := httptest.NewRecorder()
@@ -76,8 +76,25 @@ func TestProfileGetAdminByToken(test *testing.T) {
 		assert.Equal(test, string(encodedReturnAdmin), response.Body.String())
 	})
 
+	test.Run("NotModified", func(test *testing.T) {
+		version := uint64(4)
+
+		mockAdminUsecase.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{ID: &id, Version: &version}, nil)
+
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		request.Header.Set("If-None-Match", `"4"`)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockMiddlewareAuthorization, profileHandler.GetAdminByToken)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotModified, response.Code)
+		assert.Equal(test, `"4"`, response.Header().Get("ETag"))
+	})
+
 	test.Run("InternalError", func(test *testing.T) {
-		mockAdminUsecase.EXPECT().Get(admin).Return(entity.Admin{}, util.Error{Code: http.StatusInternalServerError})
+		mockAdminUsecase.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{}, util.NewError(common.ErrorCode.Internal, ""))
 
 		request := httptest.NewRequest(http.MethodGet, path, nil)
 		response := httptest.NewRecorder()
@@ -109,7 +126,7 @@ func TestProfileGetUserByToken(test *testing.T) {
 	user := entity.User{ID: &id}
 
 	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
-		claims := middleware.CustomClaims{
+		claims := entity.Claims{
 			StandardClaims: jwt.StandardClaims{
 				Subject: fmt.Sprint(id),
 			},
@@ -131,7 +148,7 @@ func TestProfileGetUserByToken(test *testing.T) {
 			IsResetPassword: new(bool),
 		}
 
-		mockUserUsecase.EXPECT().Get(user).Return(returnUser, nil)
+		mockUserUsecase.EXPECT().Get(gomock.Any(), user).Return(returnUser, nil)
 
 		request := httptest.NewRequest(http.MethodGet, path, nil)
 		response := httptest.NewRecorder()
@@ -147,8 +164,37 @@ func TestProfileGetUserByToken(test *testing.T) {
 		assert.Equal(test, string(encodedUser), response.Body.String())
 	})
 
+	test.Run("Success/Impersonated", func(test *testing.T) {
+		adminID := uint64(2)
+		mockMiddlewareAuthorizationImpersonated := func(ginContext *gin.Context) {
+			claims := entity.Claims{
+				StandardClaims: jwt.StandardClaims{
+					Subject: fmt.Sprint(id),
+				},
+				Actor: &entity.Actor{Subject: fmt.Sprint(adminID)},
+			}
+			ginContext.Set("claims", &claims)
+		}
+
+		mockUserUsecase.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id}, nil)
+
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockMiddlewareAuthorizationImpersonated, profileHandler.GetUserByToken)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		returnUser := entity.User{}
+		err := json.Unmarshal(response.Body.Bytes(), &returnUser)
+		assert.NoError(test, err)
+		assert.Equal(test, adminID, *returnUser.ImpersonatorID)
+	})
+
 	test.Run("InternalError", func(test *testing.T) {
-		mockUserUsecase.EXPECT().Get(user).Return(entity.User{}, util.Error{Code: http.StatusInternalServerError})
+		mockUserUsecase.EXPECT().Get(gomock.Any(), user).Return(entity.User{}, util.NewError(common.ErrorCode.Internal, ""))
 
 		request := httptest.NewRequest(http.MethodGet, path, nil)
 		response := httptest.NewRecorder()
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+type (
+	Role interface {
+		Create(c *gin.Context)
+		DeleteByID(c *gin.Context)
+		GetAll(c *gin.Context)
+		GetAllPermissions(c *gin.Context)
+		GetByID(c *gin.Context)
+		UpdateByID(c *gin.Context)
+		UpdatePermissionsByID(c *gin.Context)
+	}
+	roleHandler struct {
+		roleUsecase usecase.Role
+	}
+)
+
+func NewRoleHandler(roleUsecase usecase.Role) Role {
+	return &roleHandler{roleUsecase: roleUsecase}
+}
+
+func (handler *roleHandler) Create(ginContext *gin.Context) {
+	role := entity.Role{}
+	err := ginContext.ShouldBindJSON(&role)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+	role.PreventField()
+
+	role, err = handler.roleUsecase.Create(ginContext.Request.Context(), role, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusCreated, role)
+}
+
+func (handler *roleHandler) DeleteByID(ginContext *gin.Context) {
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	err = handler.roleUsecase.Delete(ginContext.Request.Context(), entity.Role{ID: &id}, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *roleHandler) GetAll(ginContext *gin.Context) {
+	roles, err := handler.roleUsecase.GetAll(ginContext.Request.Context())
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, roles)
+}
+
+func (handler *roleHandler) GetAllPermissions(ginContext *gin.Context) {
+	permissions, err := handler.roleUsecase.GetAllPermissions(ginContext.Request.Context())
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, permissions)
+}
+
+func (handler *roleHandler) GetByID(ginContext *gin.Context) {
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	role, err := handler.roleUsecase.Get(ginContext.Request.Context(), entity.Role{ID: &id})
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, role)
+}
+
+func (handler *roleHandler) UpdateByID(ginContext *gin.Context) {
+	role := entity.Role{}
+	err := ginContext.ShouldBindJSON(&role)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+	role.PreventField()
+
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+	role.ID = &id
+
+	err = handler.roleUsecase.Update(ginContext.Request.Context(), role, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *roleHandler) UpdatePermissionsByID(ginContext *gin.Context) {
+	rolePermission := entity.RolePermission{}
+	err := ginContext.ShouldBindJSON(&rolePermission)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	err = handler.roleUsecase.UpdatePermissions(ginContext.Request.Context(), entity.Role{ID: &id}, rolePermission, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
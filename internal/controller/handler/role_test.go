@@ -0,0 +1,294 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/controller/handler"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/util"
+	usecasemock "github.com/sndzhng/gin-template/mock/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func beforeTestRole(test *testing.T) (
+	*usecasemock.MockRole,
+	handler.Role,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	mockRoleUsecase := usecasemock.NewMockRole(controller)
+	roleHandler := handler.NewRoleHandler(mockRoleUsecase)
+
+	return mockRoleUsecase, roleHandler
+}
+
+func TestRoleCreate(test *testing.T) {
+	mockRoleUsecase, roleHandler := beforeTestRole(test)
+
+	path := "/admin/{context}/role"
+	id := uint64(2)
+	name := "SUPPORT"
+	role := entity.Role{Name: &name}
+
+	test.Run("Success", func(test *testing.T) {
+		createdRole := entity.Role{ID: &id, Name: &name}
+
+		mockRoleUsecase.EXPECT().Create(gomock.Any(), role, gomock.Any()).Return(createdRole, nil)
+
+		body, err := json.Marshal(entity.Role{ID: &id, Name: &name})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, roleHandler.Create)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusCreated, response.Code)
+
+		encodedRole, err := json.Marshal(createdRole)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedRole), response.Body.String())
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}"))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(path, roleHandler.Create)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestRoleDeleteByID(test *testing.T) {
+	mockRoleUsecase, roleHandler := beforeTestRole(test)
+
+	path := "/admin/{context}/role/:id"
+	id := uint64(2)
+
+	test.Run("Success", func(test *testing.T) {
+		mockRoleUsecase.EXPECT().Delete(gomock.Any(), entity.Role{ID: &id}, gomock.Any()).Return(nil)
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprint(id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, roleHandler.DeleteByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("Conflict", func(test *testing.T) {
+		mockRoleUsecase.EXPECT().Delete(gomock.Any(), entity.Role{ID: &id}, gomock.Any()).Return(util.NewError(common.ErrorCode.RoleInUse, ""))
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprint(id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, roleHandler.DeleteByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusConflict, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, roleHandler.DeleteByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestRoleGetAll(test *testing.T) {
+	mockRoleUsecase, roleHandler := beforeTestRole(test)
+
+	path := "/admin/{context}/role"
+	id := uint64(1)
+	name := string(entity.SuperAdminRoleName)
+	permissionName := entity.UserReadPermissionName
+
+	test.Run("Success", func(test *testing.T) {
+		roles := []entity.Role{{ID: &id, Name: &name, Permissions: []entity.Permission{{ID: &id, Name: &permissionName}}}}
+
+		mockRoleUsecase.EXPECT().GetAll(gomock.Any()).Return(roles, nil)
+
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, roleHandler.GetAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		encodedRoles, err := json.Marshal(roles)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedRoles), response.Body.String())
+	})
+
+	test.Run("Success/Permissions", func(test *testing.T) {
+		permissions := []entity.Permission{{ID: &id, Name: &permissionName}}
+
+		mockRoleUsecase.EXPECT().GetAllPermissions(gomock.Any()).Return(permissions, nil)
+
+		request := httptest.NewRequest(http.MethodGet, "/admin/{context}/permission", nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET("/admin/{context}/permission", roleHandler.GetAllPermissions)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		encodedPermissions, err := json.Marshal(permissions)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedPermissions), response.Body.String())
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockRoleUsecase.EXPECT().GetAll(gomock.Any()).Return([]entity.Role{}, util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, roleHandler.GetAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+}
+
+func TestRoleGetByID(test *testing.T) {
+	mockRoleUsecase, roleHandler := beforeTestRole(test)
+
+	path := "/admin/{context}/role/:id"
+	id := uint64(2)
+	name := "SUPPORT"
+
+	test.Run("Success", func(test *testing.T) {
+		role := entity.Role{ID: &id, Name: &name}
+
+		mockRoleUsecase.EXPECT().Get(gomock.Any(), entity.Role{ID: &id}).Return(role, nil)
+
+		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprint(id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, roleHandler.GetByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		encodedRole, err := json.Marshal(role)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedRole), response.Body.String())
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockRoleUsecase.EXPECT().Get(gomock.Any(), entity.Role{ID: &id}).Return(entity.Role{}, util.NewError(common.ErrorCode.NotFound, ""))
+
+		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprint(id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, roleHandler.GetByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestRoleUpdateByID(test *testing.T) {
+	mockRoleUsecase, roleHandler := beforeTestRole(test)
+
+	path := "/admin/{context}/role/:id"
+	id := uint64(2)
+	name := "HELPDESK"
+
+	test.Run("Success", func(test *testing.T) {
+		mockRoleUsecase.EXPECT().Update(gomock.Any(), entity.Role{ID: &id, Name: &name}, gomock.Any()).Return(nil)
+
+		body, err := json.Marshal(entity.Role{Name: &name})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPatch, strings.ReplaceAll(path, ":id", fmt.Sprint(id)), bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, roleHandler.UpdateByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("Forbidden", func(test *testing.T) {
+		mockRoleUsecase.EXPECT().Update(gomock.Any(), entity.Role{ID: &id, Name: &name}, gomock.Any()).Return(util.NewError(common.ErrorCode.SuperAdminRoleProtected, ""))
+
+		body, err := json.Marshal(entity.Role{Name: &name})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPatch, strings.ReplaceAll(path, ":id", fmt.Sprint(id)), bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, roleHandler.UpdateByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusForbidden, response.Code)
+	})
+}
+
+func TestRoleUpdatePermissionsByID(test *testing.T) {
+	mockRoleUsecase, roleHandler := beforeTestRole(test)
+
+	path := "/admin/{context}/role/:id/permissions"
+	id := uint64(2)
+	rolePermission := entity.RolePermission{Permissions: []entity.PermissionName{entity.UserReadPermissionName}}
+
+	test.Run("Success", func(test *testing.T) {
+		mockRoleUsecase.EXPECT().UpdatePermissions(gomock.Any(), entity.Role{ID: &id}, rolePermission, gomock.Any()).Return(nil)
+
+		body, err := json.Marshal(rolePermission)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprint(id)), bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PUT(path, roleHandler.UpdatePermissionsByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprint(id)), strings.NewReader("{}"))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PUT(path, roleHandler.UpdatePermissionsByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
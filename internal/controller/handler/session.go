@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+type (
+	Session interface {
+		GetAllByToken(c *gin.Context)
+		GetAllByUserID(c *gin.Context)
+		RevokeAllByUserID(c *gin.Context)
+		RevokeByToken(c *gin.Context)
+		RevokeByUserID(c *gin.Context)
+	}
+	sessionHandler struct {
+		sessionUsecase usecase.Session
+	}
+)
+
+func NewSessionHandler(sessionUsecase usecase.Session) Session {
+	return &sessionHandler{sessionUsecase: sessionUsecase}
+}
+
+// description: session of the presented token is flagged as current
+func (handler *sessionHandler) GetAllByToken(ginContext *gin.Context) {
+	claims, err := util.GetClaims(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+	adminID, userID, err := claims.Account()
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+
+	sessions, err := handler.sessionUsecase.GetAll(ginContext.Request.Context(), entity.Session{AdminID: adminID, UserID: userID})
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+	for index := range sessions {
+		sessions[index].IsCurrent = claims.SessionID != nil && sessions[index].ID != nil && *sessions[index].ID == *claims.SessionID
+	}
+
+	ginContext.JSON(http.StatusOK, sessions)
+}
+
+func (handler *sessionHandler) GetAllByUserID(ginContext *gin.Context) {
+	userID, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	sessions, err := handler.sessionUsecase.GetAll(ginContext.Request.Context(), entity.Session{UserID: &userID})
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, sessions)
+}
+
+func (handler *sessionHandler) RevokeAllByUserID(ginContext *gin.Context) {
+	userID, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	err = handler.sessionUsecase.RevokeAll(ginContext.Request.Context(), entity.Session{UserID: &userID})
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *sessionHandler) RevokeByToken(ginContext *gin.Context) {
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	claims, err := util.GetClaims(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+	adminID, userID, err := claims.Account()
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+		return
+	}
+
+	err = handler.sessionUsecase.Revoke(ginContext.Request.Context(), entity.Session{ID: &id, AdminID: adminID, UserID: userID})
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *sessionHandler) RevokeByUserID(ginContext *gin.Context) {
+	userID, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+	id, err := strconv.ParseUint(ginContext.Param("session_id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	err = handler.sessionUsecase.Revoke(ginContext.Request.Context(), entity.Session{ID: &id, UserID: &userID})
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
@@ -0,0 +1,335 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/controller/handler"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/sndzhng/gin-template/internal/util"
+	usecasemock "github.com/sndzhng/gin-template/mock/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func beforeTestSession(test *testing.T) (
+	*usecasemock.MockSession,
+	handler.Session,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	mockSessionUsecase := usecasemock.NewMockSession(controller)
+	sessionHandler := handler.NewSessionHandler(mockSessionUsecase)
+
+	return mockSessionUsecase, sessionHandler
+}
+
+func TestSessionGetAllByToken(test *testing.T) {
+	mockSessionUsecase, sessionHandler := beforeTestSession(test)
+
+	path := "/{context}/profile/sessions"
+	id := uint64(1)
+	otherID := uint64(2)
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles:     []entity.RoleName{entity.UserRoleName},
+			SessionID: &id,
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mockSessionUsecase.EXPECT().GetAll(gomock.Any(), entity.Session{UserID: &id}).Return(
+			[]entity.Session{
+				{ID: &id, UserID: &id},
+				{ID: &otherID, UserID: &id},
+			},
+			nil,
+		)
+
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockMiddlewareAuthorization, sessionHandler.GetAllByToken)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		sessions := []entity.Session{}
+		err := json.Unmarshal(response.Body.Bytes(), &sessions)
+		assert.NoError(test, err)
+		assert.True(test, sessions[0].IsCurrent)
+		assert.False(test, sessions[1].IsCurrent)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockSessionUsecase.EXPECT().GetAll(gomock.Any(), entity.Session{UserID: &id}).Return([]entity.Session{}, util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockMiddlewareAuthorization, sessionHandler.GetAllByToken)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("InternalError/Claims", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, sessionHandler.GetAllByToken)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+}
+
+func TestSessionGetAllByUserID(test *testing.T) {
+	mockSessionUsecase, sessionHandler := beforeTestSession(test)
+
+	path := "/admin/{context}/user/:id/sessions"
+	id := uint64(1)
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		sessions := []entity.Session{{ID: &id, UserID: &id}}
+
+		mockSessionUsecase.EXPECT().GetAll(gomock.Any(), entity.Session{UserID: &id}).Return(sessions, nil)
+
+		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprint(id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockMiddlewareAuthorization, sessionHandler.GetAllByUserID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+
+		encodedSessions, err := json.Marshal(sessions)
+		assert.NoError(test, err)
+		assert.Equal(test, string(encodedSessions), response.Body.String())
+	})
+
+	test.Run("NotFound/OwnerScope", func(test *testing.T) {
+		ownerID := uint64(2)
+		mockMiddlewareAuthorizationAdmin := func(ginContext *gin.Context) {
+			claims := entity.Claims{
+				StandardClaims: jwt.StandardClaims{
+					Subject: fmt.Sprint(ownerID),
+				},
+				Roles: []entity.RoleName{entity.AdminRoleName},
+			}
+			ginContext.Set("claims", &claims)
+		}
+
+		mockSessionUsecase.EXPECT().GetAll(gomock.Any(), entity.Session{UserID: &id}).DoAndReturn(
+			func(ctx context.Context, _ entity.Session) ([]entity.Session, error) {
+				ownerScope, ok := util.GetOwnerScope(ctx)
+				assert.True(test, ok)
+				assert.Equal(test, &ownerID, ownerScope.AdminID)
+				return []entity.Session{}, util.NewError(common.ErrorCode.NotFound, "")
+			},
+		)
+
+		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprint(id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockMiddlewareAuthorizationAdmin, middleware.ScopeOwner(), sessionHandler.GetAllByUserID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotFound, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.GET(path, mockMiddlewareAuthorization, sessionHandler.GetAllByUserID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestSessionRevokeAllByUserID(test *testing.T) {
+	mockSessionUsecase, sessionHandler := beforeTestSession(test)
+
+	path := "/admin/{context}/user/:id/sessions"
+	id := uint64(1)
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mockSessionUsecase.EXPECT().RevokeAll(gomock.Any(), entity.Session{UserID: &id}).Return(nil)
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprint(id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, sessionHandler.RevokeAllByUserID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockSessionUsecase.EXPECT().RevokeAll(gomock.Any(), entity.Session{UserID: &id}).Return(util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprint(id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, sessionHandler.RevokeAllByUserID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+}
+
+func TestSessionRevokeByToken(test *testing.T) {
+	mockSessionUsecase, sessionHandler := beforeTestSession(test)
+
+	path := "/admin/{context}/profile/sessions/:id"
+	id := uint64(1)
+	sessionID := uint64(2)
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mockSessionUsecase.EXPECT().Revoke(gomock.Any(), entity.Session{ID: &sessionID, AdminID: &id}).Return(nil)
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprint(sessionID)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, sessionHandler.RevokeByToken)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockSessionUsecase.EXPECT().Revoke(gomock.Any(), entity.Session{ID: &sessionID, AdminID: &id}).Return(util.NewError(common.ErrorCode.NotFound, ""))
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprint(sessionID)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, sessionHandler.RevokeByToken)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotFound, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, sessionHandler.RevokeByToken)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestSessionRevokeByUserID(test *testing.T) {
+	mockSessionUsecase, sessionHandler := beforeTestSession(test)
+
+	path := "/admin/{context}/user/:id/sessions/:session_id"
+	id := uint64(1)
+	sessionID := uint64(2)
+	url := strings.NewReplacer(":id", fmt.Sprint(id), ":session_id", fmt.Sprint(sessionID)).Replace(path)
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mockSessionUsecase.EXPECT().Revoke(gomock.Any(), entity.Session{ID: &sessionID, UserID: &id}).Return(nil)
+
+		request := httptest.NewRequest(http.MethodDelete, url, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, sessionHandler.RevokeByUserID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockSessionUsecase.EXPECT().Revoke(gomock.Any(), entity.Session{ID: &sessionID, UserID: &id}).Return(util.NewError(common.ErrorCode.NotFound, ""))
+
+		request := httptest.NewRequest(http.MethodDelete, url, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, sessionHandler.RevokeByUserID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotFound, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, sessionHandler.RevokeByUserID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
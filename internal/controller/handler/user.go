@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
 	"github.com/sndzhng/gin-template/internal/entity"
 	"github.com/sndzhng/gin-template/internal/usecase"
 	"github.com/sndzhng/gin-template/internal/util"
@@ -16,6 +17,10 @@ type (
 		DeleteByID(ginContext *gin.Context)
 		GetAll(ginContext *gin.Context)
 		GetByID(ginContext *gin.Context)
+		PurgeByID(ginContext *gin.Context)
+		ReassignByID(ginContext *gin.Context)
+		RestoreByID(ginContext *gin.Context)
+		UnlockByID(ginContext *gin.Context)
 		UpdateByID(ginContext *gin.Context)
 	}
 	userHandler struct {
@@ -31,19 +36,19 @@ func (handler *userHandler) Create(ginContext *gin.Context) {
 	user := entity.User{}
 	err := ginContext.ShouldBindJSON(&user)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewBindingError(err))
 		return
 	}
 	user.PreventField()
 
 	subject, err := util.GetClaimSubject(ginContext)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusInternalServerError, Message: err.Error()})
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
 		return
 	}
 	user.AdminID = &subject
 
-	err = handler.userUsecase.Create(user)
+	err = handler.userUsecase.Create(ginContext.Request.Context(), user, util.GetAudit(ginContext))
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
@@ -56,12 +61,18 @@ func (handler *userHandler) DeleteByID(ginContext *gin.Context) {
 	user := entity.User{}
 	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
 		return
 	}
 	user.ID = &id
 
-	err = handler.userUsecase.Delete(user)
+	user.Version, err = util.GetIfMatchVersion(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	err = handler.userUsecase.Delete(ginContext.Request.Context(), user, util.GetAudit(ginContext))
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
@@ -73,26 +84,30 @@ func (handler *userHandler) DeleteByID(ginContext *gin.Context) {
 func (handler *userHandler) GetAll(ginContext *gin.Context) {
 	userFilter := entity.UserFilter{}
 	_ = ginContext.ShouldBindQuery(&userFilter)
+	if !userFilter.DeletedFilter.Validate() {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.InvalidDeletedFilter, "invalid deleted filter"))
+		return
+	}
 
 	sortOrder := entity.InitialSortOrder()
 	err := ginContext.ShouldBindQuery(&sortOrder)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewBindingError(err))
 		return
 	}
 	if !sortOrder.Validate() {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: "invalid pagination sort order"})
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.InvalidSortOrder, "invalid pagination sort order"))
 		return
 	}
 
 	pagination := entity.Pagination{}
 	err = ginContext.ShouldBindQuery(&pagination)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewBindingError(err))
 		return
 	}
 
-	users, err := handler.userUsecase.GetAll(&userFilter, &sortOrder, &pagination)
+	users, err := handler.userUsecase.GetAll(ginContext.Request.Context(), &userFilter, &sortOrder, &pagination)
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
@@ -111,20 +126,106 @@ func (handler *userHandler) GetAll(ginContext *gin.Context) {
 func (handler *userHandler) GetByID(ginContext *gin.Context) {
 	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
 		return
 	}
 
 	user := entity.User{ID: &id}
-	user, err = handler.userUsecase.Get(user)
+	user, err = handler.userUsecase.Get(ginContext.Request.Context(), user)
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
 	}
 
+	if util.IsNotModified(ginContext, user.Version) {
+		ginContext.Status(http.StatusNotModified)
+		return
+	}
+
 	ginContext.JSON(http.StatusOK, user)
 }
 
+func (handler *userHandler) PurgeByID(ginContext *gin.Context) {
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	user := entity.User{ID: &id}
+	err = handler.userUsecase.Purge(ginContext.Request.Context(), user, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *userHandler) ReassignByID(ginContext *gin.Context) {
+	userOwner := entity.UserOwner{}
+	err := ginContext.ShouldBindJSON(&userOwner)
+	if err != nil {
+		util.HandleError(ginContext, util.NewBindingError(err))
+		return
+	}
+
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	user := entity.User{ID: &id}
+	user.Version, err = util.GetIfMatchVersion(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	err = handler.userUsecase.Reassign(ginContext.Request.Context(), user, userOwner, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *userHandler) RestoreByID(ginContext *gin.Context) {
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	user := entity.User{ID: &id}
+	err = handler.userUsecase.Restore(ginContext.Request.Context(), user, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
+func (handler *userHandler) UnlockByID(ginContext *gin.Context) {
+	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
+	if err != nil {
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
+		return
+	}
+
+	user := entity.User{ID: &id}
+	err = handler.userUsecase.Unlock(ginContext.Request.Context(), user, util.GetAudit(ginContext))
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
+
 func (handler *userHandler) UpdateByID(ginContext *gin.Context) {
 	user := entity.User{}
 	_ = ginContext.ShouldBindJSON(&user)
@@ -132,12 +233,18 @@ func (handler *userHandler) UpdateByID(ginContext *gin.Context) {
 
 	id, err := strconv.ParseUint(ginContext.Param("id"), 10, 64)
 	if err != nil {
-		util.HandleError(ginContext, util.Error{Code: http.StatusBadRequest, Message: err.Error()})
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.BadRequest, err.Error()))
 		return
 	}
 	user.ID = &id
 
-	err = handler.userUsecase.Update(user)
+	user.Version, err = util.GetIfMatchVersion(ginContext)
+	if err != nil {
+		util.HandleError(ginContext, err)
+		return
+	}
+
+	err = handler.userUsecase.Update(ginContext.Request.Context(), user, util.GetAudit(ginContext))
 	if err != nil {
 		util.HandleError(ginContext, err)
 		return
@@ -2,6 +2,7 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
 	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/common"
 	"github.com/sndzhng/gin-template/internal/controller/handler"
 	"github.com/sndzhng/gin-template/internal/entity"
 	"github.com/sndzhng/gin-template/internal/middleware"
@@ -51,7 +53,7 @@ func TestUserCreate(test *testing.T) {
 	}
 
 	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
-		claims := middleware.CustomClaims{
+		claims := entity.Claims{
 			StandardClaims: jwt.StandardClaims{
 				Subject: fmt.Sprint(id),
 			},
@@ -60,7 +62,7 @@ func TestUserCreate(test *testing.T) {
 	}
 
 	test.Run("Success", func(test *testing.T) {
-		mockUserUsecase.EXPECT().Create(user).Return(nil)
+		mockUserUsecase.EXPECT().Create(gomock.Any(), user, testAudit(&id)).Return(nil)
 
 		body, err := json.Marshal(user)
 		assert.NoError(test, err)
@@ -76,7 +78,7 @@ func TestUserCreate(test *testing.T) {
 	})
 
 	test.Run("InternalError/UserCreate", func(test *testing.T) {
-		mockUserUsecase.EXPECT().Create(user).Return(util.Error{Code: http.StatusInternalServerError})
+		mockUserUsecase.EXPECT().Create(gomock.Any(), user, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
 
 		body, err := json.Marshal(user)
 		assert.NoError(test, err)
@@ -91,6 +93,41 @@ func TestUserCreate(test *testing.T) {
 		assert.Equal(test, http.StatusInternalServerError, response.Code)
 	})
 
+	test.Run("Conflict", func(test *testing.T) {
+		mockUserUsecase.EXPECT().Create(gomock.Any(), user, gomock.Any()).Return(entity.ConstraintError{Type: entity.UniqueConstraintType, Field: "username"})
+
+		body, err := json.Marshal(user)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(url, mockMiddlewareAuthorization, userHandler.Create)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusConflict, response.Code)
+		assert.Contains(test, response.Body.String(), `"code":"DUPLICATE_VALUE"`)
+		assert.Contains(test, response.Body.String(), `"field":"username"`)
+	})
+
+	test.Run("UnprocessableEntity", func(test *testing.T) {
+		mockUserUsecase.EXPECT().Create(gomock.Any(), user, gomock.Any()).Return(entity.ConstraintError{Type: entity.ForeignKeyConstraintType, Field: "admin_id"})
+
+		body, err := json.Marshal(user)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(url, mockMiddlewareAuthorization, userHandler.Create)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusUnprocessableEntity, response.Code)
+		assert.Contains(test, response.Body.String(), `"field":"admin_id"`)
+	})
+
 	test.Run("InternalError/GetClaimSubject", func(test *testing.T) {
 		body, err := json.Marshal(user)
 		assert.NoError(test, err)
@@ -117,6 +154,42 @@ func TestUserCreate(test *testing.T) {
 		router.ServeHTTP(response, request)
 
 		assert.Equal(test, http.StatusBadRequest, response.Code)
+		assert.Contains(test, response.Body.String(), `"code":"VALIDATION_FAILED"`)
+		assert.Contains(test, response.Body.String(), `{"field":"username","rule":"required","message":"username is required"}`)
+	})
+
+	test.Run("BadRequest/Thai", func(test *testing.T) {
+		body, err := json.Marshal(entity.User{})
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		request.Header.Set("Accept-Language", "th-TH,th;q=0.9,en;q=0.8")
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(url, userHandler.Create)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+		assert.Equal(test, "th", response.Header().Get("Content-Language"))
+		assert.Contains(test, response.Body.String(), `"message":"ข้อมูลไม่ถูกต้อง"`)
+		assert.Contains(test, response.Body.String(), `{"field":"username","rule":"required","message":"กรุณาระบุชื่อผู้ใช้"}`)
+	})
+
+	test.Run("BadRequest/Problem", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, url, strings.NewReader("{"))
+		request.Header.Set("Accept", "application/problem+json")
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.POST(url, func(ginContext *gin.Context) { ginContext.Set("request_id", "request") }, userHandler.Create)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+		assert.Equal(test, "application/problem+json", response.Header().Get("Content-Type"))
+		assert.Contains(test, response.Body.String(), `"status":400`)
+		assert.Contains(test, response.Body.String(), `"code":"BAD_REQUEST"`)
+		assert.Contains(test, response.Body.String(), `"request_id":"request"`)
 	})
 }
 
@@ -127,38 +200,103 @@ func TestUserDeleteByID(test *testing.T) {
 	id := uint64(1)
 	user := entity.User{ID: &id}
 
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
 	test.Run("Success", func(test *testing.T) {
-		mockUserUsecase.EXPECT().Delete(user).Return(nil)
+		mockUserUsecase.EXPECT().Delete(gomock.Any(), user, gomock.Any()).Return(nil)
 
 		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		request.Header.Set("If-Match", "*")
 		response := httptest.NewRecorder()
 
 		router := gin.Default()
-		router.DELETE(path, userHandler.DeleteByID)
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.DeleteByID)
 		router.ServeHTTP(response, request)
 
 		assert.Equal(test, http.StatusOK, response.Code)
 	})
 
+	test.Run("Success/IfMatch", func(test *testing.T) {
+		version := uint64(2)
+
+		mockUserUsecase.EXPECT().Delete(gomock.Any(), entity.User{ID: &id, Version: &version}, gomock.Any()).Return(nil)
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		request.Header.Set("If-Match", `"2"`)
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.DeleteByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("PreconditionFailed", func(test *testing.T) {
+		mockUserUsecase.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(util.NewError(common.ErrorCode.VersionMismatch, ""))
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		request.Header.Set("If-Match", `"1"`)
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.DeleteByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusPreconditionFailed, response.Code)
+	})
+
+	test.Run("BadRequest/IfMatch", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		request.Header.Set("If-Match", "version")
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.DeleteByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+
 	test.Run("InternalError", func(t *testing.T) {
-		mockUserUsecase.EXPECT().Delete(user).Return(util.Error{Code: http.StatusInternalServerError})
+		mockUserUsecase.EXPECT().Delete(gomock.Any(), user, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
 
 		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		request.Header.Set("If-Match", "*")
 		response := httptest.NewRecorder()
 
 		router := gin.Default()
-		router.DELETE(path, userHandler.DeleteByID)
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.DeleteByID)
 		router.ServeHTTP(response, request)
 
 		assert.Equal(t, http.StatusInternalServerError, response.Code)
 	})
 
+	test.Run("PreconditionRequired", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.DeleteByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusPreconditionRequired, response.Code)
+	})
+
 	test.Run("BadRequest", func(t *testing.T) {
 		request := httptest.NewRequest(http.MethodDelete, path, nil)
 		response := httptest.NewRecorder()
 
 		router := gin.Default()
-		router.DELETE(path, userHandler.DeleteByID)
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.DeleteByID)
 		router.ServeHTTP(response, request)
 
 		assert.Equal(t, http.StatusBadRequest, response.Code)
@@ -187,6 +325,16 @@ func TestUserGetAll(test *testing.T) {
 		Offset: 0,
 	}
 
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
 	test.Run("Success", func(test *testing.T) {
 		users := []entity.User{
 			{
@@ -198,7 +346,7 @@ func TestUserGetAll(test *testing.T) {
 			},
 		}
 
-		mockUserUsecase.EXPECT().GetAll(&userFilter, &sortOrder, &pagination).Return(users, nil)
+		mockUserUsecase.EXPECT().GetAll(gomock.Any(), &userFilter, &sortOrder, &pagination).Return(users, nil)
 
 		request := httptest.NewRequest(http.MethodGet,
 			fmt.Sprintf(
@@ -209,7 +357,7 @@ func TestUserGetAll(test *testing.T) {
 		response := httptest.NewRecorder()
 
 		router := gin.Default()
-		router.GET(path, userHandler.GetAll)
+		router.GET(path, mockMiddlewareAuthorization, userHandler.GetAll)
 		router.ServeHTTP(response, request)
 
 		assert.Equal(test, http.StatusOK, response.Code)
@@ -224,9 +372,43 @@ func TestUserGetAll(test *testing.T) {
 		assert.Equal(test, string(encodedUsersWithNavigate), response.Body.String())
 	})
 
+	test.Run("Success/OwnerScope", func(test *testing.T) {
+		ownerID := uint64(2)
+		mockMiddlewareAuthorizationAdmin := func(ginContext *gin.Context) {
+			claims := entity.Claims{
+				StandardClaims: jwt.StandardClaims{
+					Subject: fmt.Sprint(ownerID),
+				},
+				Roles: []entity.RoleName{entity.AdminRoleName},
+			}
+			ginContext.Set("claims", &claims)
+		}
+		queryUserFilter := entity.UserFilter{User: entity.User{AdminID: &id}}
+
+		mockUserUsecase.EXPECT().GetAll(gomock.Any(), &queryUserFilter, &sortOrder, &pagination).DoAndReturn(
+			func(ctx context.Context, _ *entity.UserFilter, _ *entity.SortOrder, _ *entity.Pagination) ([]entity.User, error) {
+				ownerScope, ok := util.GetOwnerScope(ctx)
+				assert.True(test, ok)
+				assert.Equal(test, &ownerID, ownerScope.AdminID)
+				return []entity.User{}, nil
+			},
+		)
+
+		request := httptest.NewRequest(http.MethodGet,
+			fmt.Sprintf("%s?admin_id=%d&limit=%d&offset=%d", path, id, pagination.Limit, pagination.Offset), nil,
+		)
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+		router.GET(path, mockMiddlewareAuthorizationAdmin, middleware.ScopeOwner(), userHandler.GetAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
 	test.Run("InternalError", func(t *testing.T) {
-		mockUserUsecase.EXPECT().GetAll(&userFilter, &sortOrder, &pagination).
-			Return([]entity.User{}, util.Error{Code: http.StatusInternalServerError})
+		mockUserUsecase.EXPECT().GetAll(gomock.Any(), &userFilter, &sortOrder, &pagination).
+			Return([]entity.User{}, util.NewError(common.ErrorCode.Internal, ""))
 
 		request := httptest.NewRequest(http.MethodGet,
 			fmt.Sprintf(
@@ -237,7 +419,7 @@ func TestUserGetAll(test *testing.T) {
 		response := httptest.NewRecorder()
 
 		router := gin.Default()
-		router.GET(path, userHandler.GetAll)
+		router.GET(path, mockMiddlewareAuthorization, userHandler.GetAll)
 		router.ServeHTTP(response, request)
 
 		assert.Equal(t, http.StatusInternalServerError, response.Code)
@@ -248,7 +430,18 @@ func TestUserGetAll(test *testing.T) {
 		response := httptest.NewRecorder()
 
 		router := gin.Default()
-		router.GET(path, userHandler.GetAll)
+		router.GET(path, mockMiddlewareAuthorization, userHandler.GetAll)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	test.Run("BadRequest/Deleted", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?deleted=all&limit=%d", path, pagination.Limit), nil)
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+		router.GET(path, mockMiddlewareAuthorization, userHandler.GetAll)
 		router.ServeHTTP(response, request)
 
 		assert.Equal(t, http.StatusBadRequest, response.Code)
@@ -262,6 +455,16 @@ func TestUserGetByID(test *testing.T) {
 	id := uint64(1)
 	user := entity.User{ID: &id}
 
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
 	test.Run("Success", func(test *testing.T) {
 		username := "username"
 		password := "password"
@@ -273,13 +476,13 @@ func TestUserGetByID(test *testing.T) {
 			Password: &password,
 		}
 
-		mockUserUsecase.EXPECT().Get(user).Return(returnUser, nil)
+		mockUserUsecase.EXPECT().Get(gomock.Any(), user).Return(returnUser, nil)
 
 		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
 		response := httptest.NewRecorder()
 
 		router := gin.Default()
-		router.GET(path, userHandler.GetByID)
+		router.GET(path, mockMiddlewareAuthorization, userHandler.GetByID)
 		router.ServeHTTP(response, request)
 
 		assert.Equal(test, http.StatusOK, response.Code)
@@ -289,14 +492,45 @@ func TestUserGetByID(test *testing.T) {
 		assert.Equal(test, string(encodedReturnUser), response.Body.String())
 	})
 
+	test.Run("NotFound/OwnerScope", func(test *testing.T) {
+		ownerID := uint64(2)
+		mockMiddlewareAuthorizationAdmin := func(ginContext *gin.Context) {
+			claims := entity.Claims{
+				StandardClaims: jwt.StandardClaims{
+					Subject: fmt.Sprint(ownerID),
+				},
+				Roles: []entity.RoleName{entity.AdminRoleName},
+			}
+			ginContext.Set("claims", &claims)
+		}
+
+		mockUserUsecase.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).DoAndReturn(
+			func(ctx context.Context, _ entity.User) (entity.User, error) {
+				ownerScope, ok := util.GetOwnerScope(ctx)
+				assert.True(test, ok)
+				assert.Equal(test, &ownerID, ownerScope.AdminID)
+				return entity.User{}, util.NewError(common.ErrorCode.NotFound, "")
+			},
+		)
+
+		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+		router.GET(path, mockMiddlewareAuthorizationAdmin, middleware.ScopeOwner(), userHandler.GetByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotFound, response.Code)
+	})
+
 	test.Run("InternalError", func(t *testing.T) {
-		mockUserUsecase.EXPECT().Get(user).Return(entity.User{}, util.Error{Code: http.StatusInternalServerError})
+		mockUserUsecase.EXPECT().Get(gomock.Any(), user).Return(entity.User{}, util.NewError(common.ErrorCode.Internal, ""))
 
 		request := httptest.NewRequest(http.MethodGet, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
 		response := httptest.NewRecorder()
 
 		router := gin.Default()
-		router.GET(path, userHandler.GetByID)
+		router.GET(path, mockMiddlewareAuthorization, userHandler.GetByID)
 		router.ServeHTTP(response, request)
 
 		assert.Equal(t, http.StatusInternalServerError, response.Code)
@@ -307,13 +541,347 @@ func TestUserGetByID(test *testing.T) {
 		response := httptest.NewRecorder()
 
 		router := gin.Default()
-		router.GET(path, userHandler.GetByID)
+		router.GET(path, mockMiddlewareAuthorization, userHandler.GetByID)
 		router.ServeHTTP(response, request)
 
 		assert.Equal(t, http.StatusBadRequest, response.Code)
 	})
 }
 
+func TestUserPurgeByID(test *testing.T) {
+	mockUserUsecase, userHandler := beforeTestUser(test)
+
+	path := "/admin/{context}/user/:id/purge"
+	id := uint64(1)
+	user := entity.User{ID: &id}
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mockUserUsecase.EXPECT().Purge(gomock.Any(), user, gomock.Any()).Return(nil)
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.PurgeByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("Success/Scoped", func(test *testing.T) {
+		adminID := uint64(2)
+		mockScopedAuthorization := func(ginContext *gin.Context) {
+			claims := entity.Claims{
+				StandardClaims: jwt.StandardClaims{
+					Subject: fmt.Sprint(adminID),
+				},
+				Roles: []entity.RoleName{entity.AdminRoleName},
+			}
+			ginContext.Set("claims", &claims)
+		}
+
+		mockUserUsecase.EXPECT().Purge(gomock.Any(), entity.User{ID: &id}, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ entity.User, _ entity.Audit) error {
+				ownerScope, ok := util.GetOwnerScope(ctx)
+				assert.True(test, ok)
+				assert.Equal(test, &adminID, ownerScope.AdminID)
+				return nil
+			},
+		)
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockScopedAuthorization, middleware.ScopeOwner(), userHandler.PurgeByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockUserUsecase.EXPECT().Purge(gomock.Any(), user, gomock.Any()).Return(util.NewError(common.ErrorCode.NotFound, ""))
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.PurgeByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotFound, response.Code)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserUsecase.EXPECT().Purge(gomock.Any(), user, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.PurgeByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.PurgeByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestUserReassignByID(test *testing.T) {
+	mockUserUsecase, userHandler := beforeTestUser(test)
+
+	path := "/admin/{context}/user/:id/admin"
+	id := uint64(1)
+	newOwnerID := uint64(2)
+	user := entity.User{ID: &id}
+	userOwner := entity.UserOwner{AdminID: &newOwnerID}
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		version := uint64(3)
+
+		mockUserUsecase.EXPECT().Reassign(gomock.Any(), entity.User{ID: &id, Version: &version}, userOwner, gomock.Any()).Return(nil)
+
+		body, err := json.Marshal(userOwner)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), bytes.NewReader(body))
+		request.Header.Set("If-Match", `"3"`)
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+		router.PUT(path, mockMiddlewareAuthorization, userHandler.ReassignByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockUserUsecase.EXPECT().Reassign(gomock.Any(), user, userOwner, gomock.Any()).Return(util.NewError(common.ErrorCode.NotFound, ""))
+
+		body, err := json.Marshal(userOwner)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), bytes.NewReader(body))
+		request.Header.Set("If-Match", "*")
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+		router.PUT(path, mockMiddlewareAuthorization, userHandler.ReassignByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusNotFound, response.Code)
+	})
+
+	test.Run("PreconditionRequired", func(test *testing.T) {
+		body, err := json.Marshal(userOwner)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), bytes.NewReader(body))
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+		router.PUT(path, mockMiddlewareAuthorization, userHandler.ReassignByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusPreconditionRequired, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), strings.NewReader("{}"))
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+		router.PUT(path, mockMiddlewareAuthorization, userHandler.ReassignByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestUserRestoreByID(test *testing.T) {
+	mockUserUsecase, userHandler := beforeTestUser(test)
+
+	path := "/admin/{context}/user/:id/restore"
+	id := uint64(1)
+	user := entity.User{ID: &id}
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mockUserUsecase.EXPECT().Restore(gomock.Any(), user, gomock.Any()).Return(nil)
+
+		request := httptest.NewRequest(http.MethodPatch, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, mockMiddlewareAuthorization, userHandler.RestoreByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("Success/Scoped", func(test *testing.T) {
+		adminID := uint64(2)
+		mockScopedAuthorization := func(ginContext *gin.Context) {
+			claims := entity.Claims{
+				StandardClaims: jwt.StandardClaims{
+					Subject: fmt.Sprint(adminID),
+				},
+				Roles: []entity.RoleName{entity.AdminRoleName},
+			}
+			ginContext.Set("claims", &claims)
+		}
+
+		mockUserUsecase.EXPECT().Restore(gomock.Any(), entity.User{ID: &id}, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ entity.User, _ entity.Audit) error {
+				ownerScope, ok := util.GetOwnerScope(ctx)
+				assert.True(test, ok)
+				assert.Equal(test, &adminID, ownerScope.AdminID)
+				return nil
+			},
+		)
+
+		request := httptest.NewRequest(http.MethodPatch, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, mockScopedAuthorization, middleware.ScopeOwner(), userHandler.RestoreByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("Conflict", func(test *testing.T) {
+		mockUserUsecase.EXPECT().Restore(gomock.Any(), user, gomock.Any()).Return(util.NewError(common.ErrorCode.RestoreConflict, ""))
+
+		request := httptest.NewRequest(http.MethodPatch, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, mockMiddlewareAuthorization, userHandler.RestoreByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusConflict, response.Code)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserUsecase.EXPECT().Restore(gomock.Any(), user, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodPatch, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, mockMiddlewareAuthorization, userHandler.RestoreByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodPatch, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.PATCH(path, mockMiddlewareAuthorization, userHandler.RestoreByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestUserUnlockByID(test *testing.T) {
+	mockUserUsecase, userHandler := beforeTestUser(test)
+
+	path := "/admin/{context}/user/:id/lock"
+	id := uint64(1)
+	user := entity.User{ID: &id}
+
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mockUserUsecase.EXPECT().Unlock(gomock.Any(), user, gomock.Any()).Return(nil)
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.UnlockByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusOK, response.Code)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserUsecase.EXPECT().Unlock(gomock.Any(), user, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
+
+		request := httptest.NewRequest(http.MethodDelete, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.UnlockByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusInternalServerError, response.Code)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, path, nil)
+		response := httptest.NewRecorder()
+		router := gin.Default()
+
+		router.DELETE(path, mockMiddlewareAuthorization, userHandler.UnlockByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusBadRequest, response.Code)
+	})
+}
+
 func TestUserUpdateByID(test *testing.T) {
 	mockUserUsecase, userHandler := beforeTestUser(test)
 
@@ -327,44 +895,70 @@ func TestUserUpdateByID(test *testing.T) {
 		Password: &password,
 	}
 
+	mockMiddlewareAuthorization := func(ginContext *gin.Context) {
+		claims := entity.Claims{
+			StandardClaims: jwt.StandardClaims{
+				Subject: fmt.Sprint(id),
+			},
+			Roles: []entity.RoleName{entity.SuperAdminRoleName},
+		}
+		ginContext.Set("claims", &claims)
+	}
+
 	test.Run("Success", func(test *testing.T) {
-		mockUserUsecase.EXPECT().Update(user).Return(nil)
+		mockUserUsecase.EXPECT().Update(gomock.Any(), user, gomock.Any()).Return(nil)
 
 		body, err := json.Marshal(user)
 		assert.NoError(test, err)
 
 		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), bytes.NewReader(body))
+		request.Header.Set("If-Match", "*")
 		response := httptest.NewRecorder()
 
 		router := gin.Default()
-		router.PUT(path, userHandler.UpdateByID)
+		router.PUT(path, mockMiddlewareAuthorization, userHandler.UpdateByID)
 		router.ServeHTTP(response, request)
 
 		assert.Equal(test, http.StatusOK, response.Code)
 	})
 
 	test.Run("InternalError", func(test *testing.T) {
-		mockUserUsecase.EXPECT().Update(user).Return(util.Error{Code: http.StatusInternalServerError})
+		mockUserUsecase.EXPECT().Update(gomock.Any(), user, gomock.Any()).Return(util.NewError(common.ErrorCode.Internal, ""))
 
 		body, err := json.Marshal(user)
 		assert.NoError(test, err)
 
 		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), bytes.NewReader(body))
+		request.Header.Set("If-Match", "*")
 		response := httptest.NewRecorder()
 
 		router := gin.Default()
-		router.PUT(path, userHandler.UpdateByID)
+		router.PUT(path, mockMiddlewareAuthorization, userHandler.UpdateByID)
 		router.ServeHTTP(response, request)
 
 		assert.Equal(test, http.StatusInternalServerError, response.Code)
 	})
 
+	test.Run("PreconditionRequired", func(test *testing.T) {
+		body, err := json.Marshal(user)
+		assert.NoError(test, err)
+
+		request := httptest.NewRequest(http.MethodPut, strings.ReplaceAll(path, ":id", fmt.Sprintf("%d", id)), bytes.NewReader(body))
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+		router.PUT(path, mockMiddlewareAuthorization, userHandler.UpdateByID)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(test, http.StatusPreconditionRequired, response.Code)
+	})
+
 	test.Run("BadRequest", func(test *testing.T) {
 		request := httptest.NewRequest(http.MethodPut, path, nil)
 		response := httptest.NewRecorder()
 
 		router := gin.Default()
-		router.PUT(path, userHandler.UpdateByID)
+		router.PUT(path, mockMiddlewareAuthorization, userHandler.UpdateByID)
 		router.ServeHTTP(response, request)
 
 		assert.Equal(test, http.StatusBadRequest, response.Code)
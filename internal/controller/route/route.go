@@ -2,6 +2,7 @@ package route
 
 import (
 	"fmt"
+	"log"
 	"net/http"
 	"time"
 
@@ -11,41 +12,91 @@ import (
 	"github.com/sndzhng/gin-template/internal/controller/handler"
 	"github.com/sndzhng/gin-template/internal/datastore"
 	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/identity"
 	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/sndzhng/gin-template/internal/notifier"
 	"github.com/sndzhng/gin-template/internal/repository"
 	"github.com/sndzhng/gin-template/internal/usecase"
 )
 
 func SetupRouter() *gin.Engine {
 	adminRepository := repository.NewAdminRepository(datastore.Postgresql)
+	apiKeyRepository := repository.NewAPIKeyRepository(datastore.Postgresql)
+	auditLogRepository := repository.NewAuditLogRepository(datastore.Postgresql)
+	oidcStateRepository := repository.NewOIDCStateRepository(datastore.Postgresql)
+	passwordHistoryRepository := repository.NewPasswordHistoryRepository(datastore.Postgresql)
+	passwordResetTokenRepository := repository.NewPasswordResetTokenRepository(datastore.Postgresql)
+	permissionRepository := repository.NewPermissionRepository(datastore.Postgresql)
+	policyRepository := repository.NewPolicyRepository(datastore.Postgresql)
+	recoveryCodeRepository := repository.NewRecoveryCodeRepository(datastore.Postgresql)
+	refreshTokenRepository := repository.NewRefreshTokenRepository(datastore.Postgresql)
+	revokedTokenRepository := repository.NewRevokedTokenRepository(datastore.Postgresql)
 	roleRepository := repository.NewRoleRepository(datastore.Postgresql)
+	sessionRepository := repository.NewSessionRepository(datastore.Postgresql)
+	transactionRepository := repository.NewTransactionRepository(datastore.Postgresql)
 	userRepository := repository.NewUserRepository(datastore.Postgresql)
 
-	adminUsecase := usecase.NewAdminUsecase(adminRepository, roleRepository)
-	authUsecase := usecase.NewAuthUsecase(adminRepository, userRepository)
-	userUsecase := usecase.NewUserUsecase(userRepository)
+	adminUsecase := usecase.NewAdminUsecase(adminRepository, passwordHistoryRepository, refreshTokenRepository, revokedTokenRepository, roleRepository, transactionRepository, userRepository)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyRepository)
+	auditLogUsecase := usecase.NewAuditLogUsecase(auditLogRepository)
+	authUsecase := usecase.NewAuthUsecase(
+		adminRepository,
+		oidcStateRepository,
+		passwordHistoryRepository,
+		passwordResetTokenRepository,
+		policyRepository,
+		recoveryCodeRepository,
+		refreshTokenRepository,
+		revokedTokenRepository,
+		roleRepository,
+		sessionRepository,
+		transactionRepository,
+		userRepository,
+		notifier.NewNotifier(),
+		identity.NewProvider(),
+	)
+	impersonationUsecase := usecase.NewImpersonationUsecase(auditLogRepository, userRepository)
+	mfaUsecase := usecase.NewMFAUsecase(adminRepository, policyRepository, recoveryCodeRepository, transactionRepository, userRepository)
+	policyUsecase := usecase.NewPolicyUsecase(policyRepository)
+	roleUsecase := usecase.NewRoleUsecase(adminRepository, permissionRepository, roleRepository, transactionRepository)
+	sessionUsecase := usecase.NewSessionUsecase(refreshTokenRepository, revokedTokenRepository, sessionRepository, transactionRepository, userRepository)
+	userUsecase := usecase.NewUserUsecase(adminRepository, passwordHistoryRepository, refreshTokenRepository, revokedTokenRepository, transactionRepository, userRepository)
 
 	adminHandler := handler.NewAdminHandler(adminUsecase)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
+	auditLogHandler := handler.NewAuditLogHandler(auditLogUsecase)
 	authHandler := handler.NewAuthHandler(authUsecase)
+	impersonationHandler := handler.NewImpersonationHandler(impersonationUsecase)
+	keyHandler := handler.NewKeyHandler()
+	mfaHandler := handler.NewMFAHandler(mfaUsecase)
+	policyHandler := handler.NewPolicyHandler(policyUsecase)
 	profileHandler := handler.NewProfileHandler(adminUsecase, userUsecase)
+	roleHandler := handler.NewRoleHandler(roleUsecase)
+	sessionHandler := handler.NewSessionHandler(sessionUsecase)
 	userHandler := handler.NewUserHandler(userUsecase)
 
 	router := gin.Default()
+	err := router.SetTrustedProxies(config.Server.GetTrustedProxies())
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	router.Use(
+		middleware.RequestID(),
+		middleware.Timeout(config.Server.Timeout, config.Server.RouteTimeout),
 		cors.New(
 			cors.Config{
 				AllowCredentials: true,
-				AllowHeaders:     []string{"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "accept", "origin", "Cache-Control", "X-Requested-With"},
+				AllowHeaders:     []string{"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "accept", "origin", "Cache-Control", "X-Requested-With", "X-API-Key", "X-Request-ID", "X-Setup-Token", "If-Match", "If-None-Match"},
 				AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
 				AllowOrigins:     []string{"*"},
-				ExposeHeaders:    []string{"Content-Length"},
+				ExposeHeaders:    []string{"Content-Length", "X-Request-ID", "ETag"},
 				MaxAge:           12 * time.Hour,
 			},
 		),
 		func(ginContext *gin.Context) {
 			ginContext.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-			ginContext.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+			ginContext.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-API-Key, X-Request-ID, X-Setup-Token, If-Match, If-None-Match")
 			ginContext.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
 			ginContext.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 
@@ -62,44 +113,142 @@ func SetupRouter() *gin.Engine {
 		ginContext.AbortWithStatus(http.StatusNotFound)
 	})
 
+	loginRateLimit := middleware.RateLimit(10, time.Minute)
+
 	noAuthGroup := router.Group("")
 	{
-		noAuthGroup.POST(fmt.Sprintf("/admin/%s/admin/initial", config.Server.Context), adminHandler.Initial)
-		noAuthGroup.POST(fmt.Sprintf("/admin/%s/auth/login", config.Server.Context), authHandler.AdminLogin)
-		noAuthGroup.POST(fmt.Sprintf("/%s/auth/login", config.Server.Context), authHandler.UserLogin)
+		noAuthGroup.GET("/.well-known/jwks.json", keyHandler.GetJSONWebKeySet)
+		noAuthGroup.POST(fmt.Sprintf("/admin/%s/admin/bootstrap", config.Server.Context), loginRateLimit, middleware.SetupToken(), adminHandler.Bootstrap)
+		noAuthGroup.POST(fmt.Sprintf("/admin/%s/auth/login", config.Server.Context), loginRateLimit, authHandler.AdminLogin)
+		noAuthGroup.POST(fmt.Sprintf("/admin/%s/auth/mfa/enroll", config.Server.Context), loginRateLimit, mfaHandler.EnrollByMFAToken)
+		noAuthGroup.GET(fmt.Sprintf("/admin/%s/auth/oidc/callback", config.Server.Context), loginRateLimit, authHandler.AdminOIDCCallback)
+		noAuthGroup.GET(fmt.Sprintf("/admin/%s/auth/oidc/login", config.Server.Context), loginRateLimit, authHandler.AdminOIDCLogin)
+		noAuthGroup.POST(fmt.Sprintf("/admin/%s/auth/mfa/verify", config.Server.Context), loginRateLimit, authHandler.AdminVerifyMFA)
+		noAuthGroup.POST(fmt.Sprintf("/admin/%s/auth/refresh", config.Server.Context), authHandler.AdminRefresh)
+		noAuthGroup.POST(fmt.Sprintf("/%s/auth/forgot", config.Server.Context), loginRateLimit, authHandler.UserForgot)
+		noAuthGroup.POST(fmt.Sprintf("/%s/auth/forgot/confirm", config.Server.Context), loginRateLimit, authHandler.UserForgotConfirm)
+		noAuthGroup.POST(fmt.Sprintf("/%s/auth/login", config.Server.Context), loginRateLimit, authHandler.UserLogin)
+		noAuthGroup.POST(fmt.Sprintf("/%s/auth/mfa/enroll", config.Server.Context), loginRateLimit, mfaHandler.EnrollByMFAToken)
+		noAuthGroup.POST(fmt.Sprintf("/%s/auth/mfa/verify", config.Server.Context), loginRateLimit, authHandler.UserVerifyMFA)
+		noAuthGroup.POST(fmt.Sprintf("/%s/auth/refresh", config.Server.Context), authHandler.UserRefresh)
 	}
-	adminGroup := router.Group(fmt.Sprintf("/admin/%s", config.Server.Context), middleware.Authorization, middleware.VerifyRoles(entity.SuperAdminRoleName))
+	adminResetGroup := router.Group(fmt.Sprintf("/admin/%s", config.Server.Context), middleware.Authorization(nil, revokedTokenRepository), middleware.VerifyAdmin())
 	{
-		admin := adminGroup.Group("/admin")
+		auth := adminResetGroup.Group("/auth")
 		{
-			admin.GET("", adminHandler.GetAll)
-			admin.POST("", adminHandler.Create)
-			admin.GET("/:id", adminHandler.GetByID)
-			admin.PATCH("/:id", adminHandler.UpdateByID)
-			admin.DELETE("/:id", adminHandler.DeleteByID)
+			auth.POST("/logout", authHandler.Logout)
+			auth.POST("/logout/all", authHandler.LogoutAll)
+			auth.PATCH("/reset", authHandler.AdminReset)
 		}
-		profile := adminGroup.Group("/profile")
+		profile := adminResetGroup.Group("/profile")
 		{
 			profile.GET("", profileHandler.GetAdminByToken)
+			profile.GET("/sessions", sessionHandler.GetAllByToken)
+			profile.DELETE("/sessions/:id", sessionHandler.RevokeByToken)
+		}
+	}
+	// description: api key cannot manage api keys or mfa, so a leaked key cannot issue new keys or weaken owner login
+	adminBearerGroup := router.Group(fmt.Sprintf("/admin/%s", config.Server.Context), middleware.Authorization(nil, revokedTokenRepository), middleware.VerifyAdmin(), middleware.VerifyPasswordReset())
+	{
+		apiKey := adminBearerGroup.Group("/api-key", middleware.RequirePermission(entity.APIKeyManagePermissionName))
+		{
+			apiKey.GET("", apiKeyHandler.GetAll)
+			apiKey.POST("", apiKeyHandler.Create)
+			apiKey.GET("/:id", apiKeyHandler.GetByID)
+			apiKey.DELETE("/:id", apiKeyHandler.RevokeByID)
 		}
-		user := adminGroup.Group("/user")
+		auth := adminBearerGroup.Group("/auth")
 		{
-			user.GET("", userHandler.GetAll)
-			user.POST("", userHandler.Create)
-			user.GET("/:id", userHandler.GetByID)
-			user.PATCH("/:id", userHandler.UpdateByID)
-			user.DELETE("/:id", userHandler.DeleteByID)
+			auth.POST("/mfa", mfaHandler.Enroll)
+			auth.PATCH("/mfa", mfaHandler.Confirm)
+			auth.DELETE("/mfa", mfaHandler.Disable)
+		}
+		user := adminBearerGroup.Group("/user")
+		{
+			user.POST("/:id/impersonate", middleware.VerifyRoles(entity.SuperAdminRoleName), impersonationHandler.ImpersonateByUserID)
 		}
 	}
-	userGroup := router.Group(fmt.Sprintf("/%s", config.Server.Context), middleware.Authorization, middleware.VerifyRoles(entity.UserRoleName))
+	adminGroup := router.Group(fmt.Sprintf("/admin/%s", config.Server.Context), middleware.Authorization(apiKeyRepository, revokedTokenRepository), middleware.VerifyAdmin(), middleware.VerifyPasswordReset())
 	{
-		auth := userGroup.Group("/auth")
+		adminRead := middleware.RequirePermission(entity.AdminReadPermissionName)
+		adminWrite := middleware.RequirePermission(entity.AdminWritePermissionName)
+		admin := adminGroup.Group("/admin")
+		{
+			admin.GET("", adminRead, adminHandler.GetAll)
+			admin.POST("", adminWrite, adminHandler.Create)
+			admin.GET("/:id", adminRead, adminHandler.GetByID)
+			admin.PATCH("/:id", adminWrite, adminHandler.UpdateByID)
+			admin.DELETE("/:id", adminWrite, adminHandler.DeleteByID)
+			admin.DELETE("/:id/lock", adminWrite, adminHandler.UnlockByID)
+			admin.DELETE("/:id/purge", adminWrite, adminHandler.PurgeByID)
+			admin.PATCH("/:id/restore", adminWrite, adminHandler.RestoreByID)
+		}
+		audit := adminGroup.Group("/audit")
+		{
+			audit.GET("", middleware.RequirePermission(entity.AuditReadPermissionName), auditLogHandler.GetAll)
+		}
+		permission := adminGroup.Group("/permission")
 		{
-			auth.PATCH("/reset", authHandler.UserReset)
+			permission.GET("", middleware.RequirePermission(entity.RoleReadPermissionName), roleHandler.GetAllPermissions)
 		}
-		profile := userGroup.Group("/profile")
+		policy := adminGroup.Group("/policy")
+		{
+			policy.GET("", middleware.RequirePermission(entity.PolicyReadPermissionName), policyHandler.Get)
+			policy.PATCH("", middleware.RequirePermission(entity.PolicyWritePermissionName), policyHandler.Update)
+		}
+		roleRead := middleware.RequirePermission(entity.RoleReadPermissionName)
+		roleWrite := middleware.RequirePermission(entity.RoleWritePermissionName)
+		role := adminGroup.Group("/role")
+		{
+			role.GET("", roleRead, roleHandler.GetAll)
+			role.POST("", roleWrite, roleHandler.Create)
+			role.GET("/:id", roleRead, roleHandler.GetByID)
+			role.PATCH("/:id", roleWrite, roleHandler.UpdateByID)
+			role.DELETE("/:id", roleWrite, roleHandler.DeleteByID)
+			role.PUT("/:id/permissions", roleWrite, roleHandler.UpdatePermissionsByID)
+		}
+		userRead := middleware.RequirePermission(entity.UserReadPermissionName)
+		userWrite := middleware.RequirePermission(entity.UserWritePermissionName)
+		user := adminGroup.Group("/user", middleware.ScopeOwner())
+		{
+			user.GET("", userRead, userHandler.GetAll)
+			user.POST("", userWrite, userHandler.Create)
+			user.GET("/:id", userRead, userHandler.GetByID)
+			user.PATCH("/:id", userWrite, userHandler.UpdateByID)
+			user.DELETE("/:id", userWrite, userHandler.DeleteByID)
+			user.DELETE("/:id/lock", userWrite, userHandler.UnlockByID)
+			user.DELETE("/:id/purge", userWrite, userHandler.PurgeByID)
+			user.PATCH("/:id/restore", userWrite, userHandler.RestoreByID)
+			user.PUT("/:id/admin", middleware.RequirePermission(entity.UserAssignPermissionName), userHandler.ReassignByID)
+			user.GET("/:id/sessions", userRead, sessionHandler.GetAllByUserID)
+			user.DELETE("/:id/sessions", userWrite, sessionHandler.RevokeAllByUserID)
+			user.DELETE("/:id/sessions/:session_id", userWrite, sessionHandler.RevokeByUserID)
+		}
+	}
+	// description: impersonated requests are audited and sensitive account actions are rejected
+	impersonationRejected := middleware.RejectImpersonation()
+	userResetGroup := router.Group(fmt.Sprintf("/%s", config.Server.Context), middleware.Authorization(nil, revokedTokenRepository), middleware.VerifyRoles(entity.UserRoleName), middleware.AuditImpersonation(auditLogRepository))
+	{
+		auth := userResetGroup.Group("/auth")
+		{
+			auth.POST("/logout", authHandler.Logout)
+			auth.POST("/logout/all", impersonationRejected, authHandler.LogoutAll)
+			auth.PATCH("/reset", impersonationRejected, authHandler.UserReset)
+		}
+		profile := userResetGroup.Group("/profile", middleware.ScopeOwner())
 		{
 			profile.GET("", profileHandler.GetUserByToken)
+			profile.GET("/sessions", sessionHandler.GetAllByToken)
+			profile.DELETE("/sessions/:id", impersonationRejected, sessionHandler.RevokeByToken)
+		}
+	}
+	userGroup := router.Group(fmt.Sprintf("/%s", config.Server.Context), middleware.Authorization(nil, revokedTokenRepository), middleware.VerifyRoles(entity.UserRoleName), middleware.VerifyPasswordReset(), middleware.AuditImpersonation(auditLogRepository))
+	{
+		auth := userGroup.Group("/auth")
+		{
+			auth.POST("/mfa", impersonationRejected, mfaHandler.Enroll)
+			auth.PATCH("/mfa", impersonationRejected, mfaHandler.Confirm)
+			auth.DELETE("/mfa", impersonationRejected, mfaHandler.Disable)
 		}
 	}
 
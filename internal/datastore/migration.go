@@ -0,0 +1,265 @@
+package datastore
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// description: source directory of migration files, relative to repository root where commands are run
+	MigrationDirectory = "internal/datastore/migration"
+
+	// description: arbitrary key shared by every instance so only one of them migrates at a time
+	migrationLockKey = 7305516247
+)
+
+var (
+	//go:embed migration/*.sql
+	migrationFiles embed.FS
+
+	migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+	migrationNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+type (
+	Migration struct {
+		Version uint64
+		Name    string
+		Up      string
+		Down    string
+		ApplyAt *time.Time
+	}
+	schemaMigration struct {
+		Version *uint64    `gorm:"primaryKey"`
+		Name    *string    `gorm:"not null"`
+		ApplyAt *time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	}
+)
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// description: apply every pending migration in version order, each in its own transaction
+func MigrateUp() error {
+	return withMigrationLock(func(connection *gorm.DB, migrations []Migration) error {
+		for _, migration := range migrations {
+			if migration.ApplyAt != nil {
+				continue
+			}
+
+			err := connection.Transaction(func(transaction *gorm.DB) error {
+				err := transaction.Exec(migration.Up).Error
+				if err != nil {
+					return err
+				}
+
+				version, name := migration.Version, migration.Name
+				return transaction.Create(&schemaMigration{Version: &version, Name: &name}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s up: %w", migration.Version, migration.Name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// description: revert the latest applied migrations, steps is the amount to revert
+func MigrateDown(steps int) error {
+	return withMigrationLock(func(connection *gorm.DB, migrations []Migration) error {
+		for index := len(migrations) - 1; index >= 0 && steps > 0; index-- {
+			migration := migrations[index]
+			if migration.ApplyAt == nil {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %04d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			err := connection.Transaction(func(transaction *gorm.DB) error {
+				err := transaction.Exec(migration.Down).Error
+				if err != nil {
+					return err
+				}
+
+				version := migration.Version
+				return transaction.Delete(&schemaMigration{Version: &version}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s down: %w", migration.Version, migration.Name, err)
+			}
+			steps--
+		}
+
+		return nil
+	})
+}
+
+// description: embedded migrations with apply time, nil apply time is pending, read without lock so it answers while another instance migrates
+func MigrationStatus() ([]Migration, error) {
+	migrations, err := readMigrations()
+	if err != nil {
+		return []Migration{}, err
+	}
+	if !Postgresql.Migrator().HasTable(&schemaMigration{}) {
+		return migrations, nil
+	}
+
+	err = setApplyAt(Postgresql, migrations)
+	if err != nil {
+		return []Migration{}, err
+	}
+
+	return migrations, nil
+}
+
+// description: write empty up and down files numbered after the latest one found in directory
+func CreateMigration(directory string, name string) ([]string, error) {
+	if !migrationNamePattern.MatchString(name) {
+		return []string{}, errors.New("migration name must be lower snake case")
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return []string{}, err
+	}
+
+	version := uint64(0)
+	for _, entry := range entries {
+		matches := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		fileVersion, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return []string{}, err
+		}
+		if fileVersion > version {
+			version = fileVersion
+		}
+	}
+
+	paths := []string{}
+	for _, direction := range []string{"up", "down"} {
+		filePath := filepath.Join(directory, fmt.Sprintf("%04d_%s.%s.sql", version+1, name, direction))
+		err = os.WriteFile(filePath, []byte{}, 0o644)
+		if err != nil {
+			return []string{}, err
+		}
+		paths = append(paths, filePath)
+	}
+
+	return paths, nil
+}
+
+// description: session advisory lock is held on a single connection while migrations are read and applied
+func withMigrationLock(fc func(connection *gorm.DB, migrations []Migration) error) error {
+	migrations, err := readMigrations()
+	if err != nil {
+		return err
+	}
+
+	return Postgresql.Connection(func(connection *gorm.DB) error {
+		err := connection.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error
+		if err != nil {
+			return err
+		}
+		defer connection.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey)
+
+		err = connection.Exec("CREATE TABLE IF NOT EXISTS schema_migrations (version bigint PRIMARY KEY, name text NOT NULL, apply_at timestamptz DEFAULT CURRENT_TIMESTAMP)").Error
+		if err != nil {
+			return err
+		}
+
+		err = setApplyAt(connection, migrations)
+		if err != nil {
+			return err
+		}
+
+		return fc(connection, migrations)
+	})
+}
+
+func setApplyAt(connection *gorm.DB, migrations []Migration) error {
+	schemaMigrations := []schemaMigration{}
+	err := connection.Find(&schemaMigrations).Error
+	if err != nil {
+		return err
+	}
+
+	applied := map[uint64]*time.Time{}
+	for _, schemaMigration := range schemaMigrations {
+		applied[*schemaMigration.Version] = schemaMigration.ApplyAt
+	}
+	for index := range migrations {
+		migrations[index].ApplyAt = applied[migrations[index].Version]
+	}
+
+	return nil
+}
+
+func readMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migration")
+	if err != nil {
+		return []Migration{}, err
+	}
+
+	migrationMap := map[uint64]*Migration{}
+	for _, entry := range entries {
+		matches := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return []Migration{}, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+
+		version, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return []Migration{}, err
+		}
+		content, err := migrationFiles.ReadFile(path.Join("migration", entry.Name()))
+		if err != nil {
+			return []Migration{}, err
+		}
+
+		migration, isExist := migrationMap[version]
+		if !isExist {
+			migration = &Migration{Version: version, Name: matches[2]}
+			migrationMap[version] = migration
+		} else if migration.Name != matches[2] {
+			return []Migration{}, fmt.Errorf("migration version %04d is used by %s and %s", version, migration.Name, matches[2])
+		}
+
+		switch matches[3] {
+		case "up":
+			migration.Up = string(content)
+		case "down":
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := []Migration{}
+	for _, migration := range migrationMap {
+		if migration.Up == "" {
+			return []Migration{}, fmt.Errorf("migration %04d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
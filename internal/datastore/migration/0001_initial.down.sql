@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS policies;
+DROP TABLE IF EXISTS password_reset_tokens;
+DROP TABLE IF EXISTS password_histories;
+DROP TABLE IF EXISTS oidc_states;
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS admins;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
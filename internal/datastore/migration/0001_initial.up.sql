@@ -0,0 +1,207 @@
+-- description: baseline schema, idempotent so a database created by the former auto migration is adopted as is
+
+CREATE TABLE IF NOT EXISTS roles (
+	id bigserial PRIMARY KEY,
+	name text NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_name ON roles (name);
+
+CREATE TABLE IF NOT EXISTS permissions (
+	id bigserial PRIMARY KEY,
+	name text NOT NULL,
+	description text DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_permissions_name ON permissions (name);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+	role_id bigint NOT NULL,
+	permission_id bigint NOT NULL,
+	PRIMARY KEY (role_id, permission_id),
+	CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_id) REFERENCES roles (id),
+	CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission_id) REFERENCES permissions (id)
+);
+
+CREATE TABLE IF NOT EXISTS admins (
+	id bigserial PRIMARY KEY,
+	role_id bigint,
+	create_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	update_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	delete_at timestamptz,
+	version bigint NOT NULL DEFAULT 1,
+	last_login_at timestamptz DEFAULT NULL,
+	username text NOT NULL,
+	password_hash bytea NOT NULL,
+	is_reset_password boolean NOT NULL DEFAULT false,
+	failed_login bigint NOT NULL DEFAULT 0,
+	lock_until timestamptz DEFAULT NULL,
+	mfa_secret text DEFAULT NULL,
+	is_mfa_enabled boolean NOT NULL DEFAULT false,
+	oidc_subject text DEFAULT NULL,
+	CONSTRAINT fk_admins_role FOREIGN KEY (role_id) REFERENCES roles (id)
+);
+CREATE INDEX IF NOT EXISTS idx_admins_role_id ON admins (role_id);
+CREATE INDEX IF NOT EXISTS idx_admins_delete_at ON admins (delete_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_admins_username_active ON admins (username) WHERE delete_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS users (
+	id bigserial PRIMARY KEY,
+	admin_id bigint NOT NULL,
+	create_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	update_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	delete_at timestamptz,
+	version bigint NOT NULL DEFAULT 1,
+	last_login_at timestamptz DEFAULT NULL,
+	username text NOT NULL,
+	password_hash bytea NOT NULL,
+	name text NOT NULL,
+	phone text NOT NULL,
+	email text DEFAULT NULL,
+	is_reset_password boolean DEFAULT true,
+	failed_login bigint NOT NULL DEFAULT 0,
+	lock_until timestamptz DEFAULT NULL,
+	mfa_secret text DEFAULT NULL,
+	is_mfa_enabled boolean NOT NULL DEFAULT false,
+	CONSTRAINT fk_users_admin FOREIGN KEY (admin_id) REFERENCES admins (id)
+);
+CREATE INDEX IF NOT EXISTS idx_users_admin_id ON users (admin_id);
+CREATE INDEX IF NOT EXISTS idx_users_delete_at ON users (delete_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_active ON users (username) WHERE delete_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_phone_active ON users (phone) WHERE delete_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS api_keys (
+	id bigserial PRIMARY KEY,
+	admin_id bigint NOT NULL,
+	name text NOT NULL,
+	prefix text NOT NULL,
+	key_hash text NOT NULL,
+	scopes text NOT NULL,
+	create_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	expire_at timestamptz DEFAULT NULL,
+	last_use_at timestamptz DEFAULT NULL,
+	revoke_at timestamptz DEFAULT NULL,
+	CONSTRAINT fk_api_keys_admin FOREIGN KEY (admin_id) REFERENCES admins (id)
+);
+CREATE INDEX IF NOT EXISTS idx_api_keys_admin_id ON api_keys (admin_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_prefix ON api_keys (prefix);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_key_hash ON api_keys (key_hash);
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+	id bigserial PRIMARY KEY,
+	actor_id bigint,
+	action text NOT NULL,
+	entity_type text,
+	entity_id bigint,
+	before jsonb DEFAULT NULL,
+	after jsonb DEFAULT NULL,
+	method text DEFAULT NULL,
+	path text DEFAULT NULL,
+	request_id text DEFAULT NULL,
+	ip_address text DEFAULT NULL,
+	user_agent text DEFAULT NULL,
+	create_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs (action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_request_id ON audit_logs (request_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_create_at ON audit_logs (create_at);
+
+CREATE TABLE IF NOT EXISTS oidc_states (
+	id bigserial PRIMARY KEY,
+	state_hash text NOT NULL,
+	code_verifier text NOT NULL,
+	nonce text NOT NULL,
+	create_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	expire_at timestamptz NOT NULL,
+	use_at timestamptz DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_oidc_states_state_hash ON oidc_states (state_hash);
+
+CREATE TABLE IF NOT EXISTS password_histories (
+	id bigserial PRIMARY KEY,
+	admin_id bigint,
+	user_id bigint,
+	password_hash bytea NOT NULL,
+	create_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_password_histories_admin_id ON password_histories (admin_id);
+CREATE INDEX IF NOT EXISTS idx_password_histories_user_id ON password_histories (user_id);
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+	id bigserial PRIMARY KEY,
+	user_id bigint NOT NULL,
+	token_hash text NOT NULL,
+	create_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	expire_at timestamptz NOT NULL,
+	use_at timestamptz DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_password_reset_tokens_token_hash ON password_reset_tokens (token_hash);
+
+CREATE TABLE IF NOT EXISTS policies (
+	id bigserial PRIMARY KEY,
+	is_admin_mfa_required boolean NOT NULL DEFAULT false,
+	update_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+	id bigserial PRIMARY KEY,
+	admin_id bigint,
+	user_id bigint,
+	code_hash text NOT NULL,
+	create_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	use_at timestamptz DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_admin_id ON recovery_codes (admin_id);
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_user_id ON recovery_codes (user_id);
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_code_hash ON recovery_codes (code_hash);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+	id bigserial PRIMARY KEY,
+	admin_id bigint,
+	user_id bigint,
+	family_id text NOT NULL,
+	token_hash text NOT NULL,
+	create_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	expire_at timestamptz NOT NULL,
+	revoke_at timestamptz DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_admin_id ON refresh_tokens (admin_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+	id bigserial PRIMARY KEY,
+	jti text,
+	session_id bigint,
+	admin_id bigint,
+	user_id bigint,
+	create_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	expire_at timestamptz NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_revoked_tokens_jti ON revoked_tokens (jti);
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_session_id ON revoked_tokens (session_id);
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_admin_id ON revoked_tokens (admin_id);
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_user_id ON revoked_tokens (user_id);
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expire_at ON revoked_tokens (expire_at);
+
+CREATE TABLE IF NOT EXISTS sessions (
+	id bigserial PRIMARY KEY,
+	admin_id bigint,
+	user_id bigint,
+	family_id text NOT NULL,
+	user_agent text DEFAULT NULL,
+	ip_address text DEFAULT NULL,
+	create_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	last_seen_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_admin_id ON sessions (admin_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sessions_family_id ON sessions (family_id);
+
+-- description: unique index of username, phone and oidc subject used to include soft deleted rows
+DROP INDEX IF EXISTS idx_admins_oidc_subject;
+DROP INDEX IF EXISTS idx_admins_username;
+DROP INDEX IF EXISTS idx_users_phone;
+DROP INDEX IF EXISTS idx_users_username;
@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS mfa_last_step;
+ALTER TABLE admins DROP COLUMN IF EXISTS mfa_last_step;
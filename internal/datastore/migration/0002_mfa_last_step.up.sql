@@ -0,0 +1,3 @@
+-- description: last accepted totp time step, code of this step or an earlier one is rejected as replay
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS mfa_last_step bigint DEFAULT NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS mfa_last_step bigint DEFAULT NULL;
@@ -0,0 +1 @@
+-- description: nothing to revert, a fresh database gets the same columns and constraints from 0001_initial, they go with the tables on its down migration
@@ -0,0 +1,31 @@
+-- description: columns and constraints added since the former auto migration, a database adopted by 0001_initial lacks them
+ALTER TABLE admins
+	ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1,
+	ADD COLUMN IF NOT EXISTS is_reset_password boolean NOT NULL DEFAULT false,
+	ADD COLUMN IF NOT EXISTS failed_login bigint NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS lock_until timestamptz DEFAULT NULL,
+	ADD COLUMN IF NOT EXISTS mfa_secret text DEFAULT NULL,
+	ADD COLUMN IF NOT EXISTS is_mfa_enabled boolean NOT NULL DEFAULT false,
+	ADD COLUMN IF NOT EXISTS oidc_subject text DEFAULT NULL;
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conrelid = 'admins'::regclass AND conname = 'fk_admins_role') THEN
+		ALTER TABLE admins ADD CONSTRAINT fk_admins_role FOREIGN KEY (role_id) REFERENCES roles (id);
+	END IF;
+END $$;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_admins_oidc_subject_active ON admins (oidc_subject) WHERE delete_at IS NULL;
+
+ALTER TABLE users
+	ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1,
+	ADD COLUMN IF NOT EXISTS email text DEFAULT NULL,
+	ADD COLUMN IF NOT EXISTS is_reset_password boolean DEFAULT true,
+	ADD COLUMN IF NOT EXISTS failed_login bigint NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS lock_until timestamptz DEFAULT NULL,
+	ADD COLUMN IF NOT EXISTS mfa_secret text DEFAULT NULL,
+	ADD COLUMN IF NOT EXISTS is_mfa_enabled boolean NOT NULL DEFAULT false;
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conrelid = 'users'::regclass AND conname = 'fk_users_admin') THEN
+		ALTER TABLE users ADD CONSTRAINT fk_users_admin FOREIGN KEY (admin_id) REFERENCES admins (id);
+	END IF;
+END $$;
@@ -0,0 +1,157 @@
+package datastore_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/datastore"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	// description: dsn of disposable database e.g. host=localhost user=postgres dbname=test sslmode=disable, test is skipped without it
+	testPostgresqlDSNKey = "TEST_POSTGRESQL_DSN"
+)
+
+type (
+	// description: models as they were at the baseline commit, whose schema was created by auto migration
+	baselineRole struct {
+		ID   *uint64 `gorm:"primaryKey"`
+		Name *string `gorm:"not null;uniqueIndex"`
+	}
+	baselineAdmin struct {
+		ID           *uint64        `gorm:"primaryKey"`
+		Role         *baselineRole  `gorm:"foreignKey:RoleID"`
+		RoleID       *uint64        `gorm:"index"`
+		CreateAt     *time.Time     `gorm:"default:CURRENT_TIMESTAMP"`
+		UpdateAt     *time.Time     `gorm:"default:CURRENT_TIMESTAMP"`
+		DeleteAt     gorm.DeletedAt `gorm:"index"`
+		LastLoginAt  *time.Time     `gorm:"default:null"`
+		Username     *string        `gorm:"not null;uniqueIndex"`
+		PasswordHash *[]byte        `gorm:"not null"`
+	}
+	baselineUser struct {
+		ID              *uint64        `gorm:"primaryKey"`
+		Admin           *baselineAdmin `gorm:"foreignKey:AdminID"`
+		AdminID         *uint64        `gorm:"index;not null"`
+		CreateAt        *time.Time     `gorm:"default:CURRENT_TIMESTAMP"`
+		UpdateAt        *time.Time     `gorm:"default:CURRENT_TIMESTAMP"`
+		DeleteAt        gorm.DeletedAt `gorm:"index"`
+		LastLoginAt     *time.Time     `gorm:"default:null"`
+		Username        *string        `gorm:"uniqueIndex;not null"`
+		PasswordHash    *[]byte        `gorm:"not null"`
+		Name            *string        `gorm:"not null"`
+		Phone           *string        `gorm:"uniqueIndex;not null"`
+		IsResetPassword *bool          `gorm:"default:true"`
+	}
+)
+
+func (baselineRole) TableName() string {
+	return "roles"
+}
+
+func (baselineAdmin) TableName() string {
+	return "admins"
+}
+
+func (baselineUser) TableName() string {
+	return "users"
+}
+
+func TestMigrateUpBaselineSchema(t *testing.T) {
+	dsn := os.Getenv(testPostgresqlDSNKey)
+	if dsn == "" {
+		t.Skipf("%s is not set", testPostgresqlDSNKey)
+	}
+
+	postgresql, err := gorm.Open(postgres.Open(dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// description: own schema so tables of other tests and of developer are untouched
+	schemaName := fmt.Sprintf("migration_test_%d", time.Now().UnixNano())
+	err = postgresql.Exec(fmt.Sprintf("CREATE SCHEMA %s", schemaName)).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer postgresql.Exec(fmt.Sprintf("DROP SCHEMA %s CASCADE", schemaName))
+
+	datastore.Postgresql, err = gorm.Open(postgres.Open(fmt.Sprintf("%s search_path=%s", dsn, schemaName)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = datastore.Postgresql.AutoMigrate(&baselineRole{}, &baselineAdmin{}, &baselineUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roleName, username, passwordHash := "SUPER_ADMIN", "admin01", []byte("hash")
+	role := baselineRole{Name: &roleName}
+	err = datastore.Postgresql.Create(&role).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin := baselineAdmin{RoleID: role.ID, Username: &username, PasswordHash: &passwordHash}
+	err = datastore.Postgresql.Create(&admin).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, phone := "user01", "0800000000"
+	user := baselineUser{AdminID: admin.ID, Username: &username, PasswordHash: &passwordHash, Name: &name, Phone: &phone}
+	err = datastore.Postgresql.Create(&user).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = datastore.MigrateUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrator := datastore.Postgresql.Migrator()
+	for _, model := range []interface{}{&entity.Admin{}, &entity.User{}} {
+		statement := &gorm.Statement{DB: datastore.Postgresql}
+		err = statement.Parse(model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, field := range statement.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			assert.True(t, migrator.HasColumn(model, field.DBName), "%s.%s", statement.Schema.Table, field.DBName)
+		}
+	}
+	for _, index := range []string{"idx_admins_username_active", "idx_admins_oidc_subject_active"} {
+		assert.True(t, migrator.HasIndex("admins", index), index)
+	}
+	for _, index := range []string{"idx_users_username_active", "idx_users_phone_active"} {
+		assert.True(t, migrator.HasIndex("users", index), index)
+	}
+	assert.False(t, migrator.HasIndex("admins", "idx_admins_username"))
+	assert.False(t, migrator.HasIndex("users", "idx_users_phone"))
+
+	migratedAdmin := entity.Admin{}
+	err = datastore.Postgresql.First(&migratedAdmin, *admin.ID).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(1), *migratedAdmin.Version)
+	assert.Equal(t, 0, *migratedAdmin.FailedLogin)
+	assert.False(t, *migratedAdmin.IsMFAEnabled)
+
+	migratedUser := entity.User{}
+	err = datastore.Postgresql.First(&migratedUser, *user.ID).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(1), *migratedUser.Version)
+	assert.True(t, *migratedUser.IsResetPassword)
+}
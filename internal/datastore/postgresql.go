@@ -1,18 +1,30 @@
 package datastore
 
 import (
+	"errors"
 	"fmt"
 	"log"
-	"strings"
+	"regexp"
 
+	"github.com/jackc/pgconn"
 	"github.com/sndzhng/gin-template/internal/config"
 	"github.com/sndzhng/gin-template/internal/entity"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	checkViolationCode      = "23514"
+	foreignKeyViolationCode = "23503"
+	uniqueViolationCode     = "23505"
 )
 
 var (
 	Postgresql *gorm.DB
+
+	// description: detail of unique and foreign key violation e.g. Key (username)=(admin01) already exists.
+	violationKeyPattern = regexp.MustCompile(`^Key \(([^)]+)\)=`)
 )
 
 func ConnectPostgresql() {
@@ -32,6 +44,11 @@ func ConnectPostgresql() {
 		log.Fatal(err)
 	}
 
+	err = registerErrorTranslation(Postgresql)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// description: validate connection timezone
 	timezone := ""
 	err = Postgresql.Raw("SHOW TIME ZONE").Scan(&timezone).Error
@@ -40,71 +57,101 @@ func ConnectPostgresql() {
 	} else if timezone != config.Datastore.Postgresql.TimeZone {
 		log.Fatal("postgresql timezone is not Asia/Bangkok")
 	}
+}
 
-	// description: migrate enum with values map type name
-	migrateEnums(
-		map[string][]interface{}{
-			// "name": {"value", "value"},
-		},
-	)
-
-	// description: migrate table
-	err = Postgresql.AutoMigrate(
-		&entity.Admin{},
-		&entity.Role{},
-		&entity.User{},
-	)
-	if err != nil {
-		log.Fatal(err)
+// description: constraint violation is returned by every statement as entity.ConstraintError instead of driver error
+func registerErrorTranslation(postgresql *gorm.DB) error {
+	callback := postgresql.Callback()
+	errs := []error{
+		callback.Create().After("*").Register("translate_error", translateError),
+		callback.Delete().After("*").Register("translate_error", translateError),
+		callback.Query().After("*").Register("translate_error", translateError),
+		callback.Raw().After("*").Register("translate_error", translateError),
+		callback.Row().After("*").Register("translate_error", translateError),
+		callback.Update().After("*").Register("translate_error", translateError),
+	}
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
 	}
 
-	// description: migrate constraints with table, constraint name and condition for migrate
-	migrateConstraints(
-		[][3]string{
-			// {"users", "password_is_not_null", "password IS NOT NULL"},
-		},
-	)
+	return nil
 }
 
-func migrateConstraints(constraints [][3]string) {
-	query := "DO $$	BEGIN "
-	for _, constraint := range constraints {
-		query += fmt.Sprintf("IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = '%s' AND conrelid = '%s'::regclass) THEN ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s); END IF; ", constraint[1], constraint[0], constraint[0], constraint[1], constraint[2])
+func translateError(postgresql *gorm.DB) {
+	pgError := &pgconn.PgError{}
+	if !errors.As(postgresql.Error, &pgError) {
+		return
 	}
-	query += "END $$;"
 
-	err := Postgresql.Exec(query).Error
-	if err != nil {
-		log.Fatal(err)
+	constraintError := entity.ConstraintError{Constraint: pgError.ConstraintName, Field: pgError.ColumnName}
+	switch pgError.Code {
+	case checkViolationCode:
+		constraintError.Type = entity.CheckConstraintType
+	case foreignKeyViolationCode:
+		constraintError.Type = entity.ForeignKeyConstraintType
+	case uniqueViolationCode:
+		constraintError.Type = entity.UniqueConstraintType
+	default:
+		return
+	}
+
+	matches := violationKeyPattern.FindStringSubmatch(pgError.Detail)
+	if matches != nil {
+		constraintError.Field = matches[1]
+	}
+	// description: check violation names no column, constraint name is the next best hint
+	if constraintError.Field == "" {
+		constraintError.Field = pgError.ConstraintName
 	}
+
+	postgresql.Error = constraintError
 }
 
-func migrateEnums(enumMapDataType map[string][]interface{}) {
-	existedDataTypes := []string{}
-	err := Postgresql.Raw("SELECT enumtypid::regtype AS enum_name FROM pg_enum	GROUP BY enum_name;").Scan(&existedDataTypes).Error
+// description: seed permission catalogue and default roles, run after migrations on every start
+func SeedPostgresql() {
+	migratePermissions(entity.PermissionDescriptions)
+	migrateRoles(entity.DefaultRolePermissions)
+}
+
+func migratePermissions(permissionDescriptions map[entity.PermissionName]string) {
+	for name, description := range permissionDescriptions {
+		name, description := name, description
+		err := Postgresql.
+			Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoUpdates: clause.AssignmentColumns([]string{"description"})}).
+			Create(&entity.Permission{Name: &name, Description: &description}).Error
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// description: grant default permissions only to newly created role so later changes are kept, except super admin
+func migrateRoles(rolePermissions map[entity.RoleName][]entity.PermissionName) {
+	// description: align id sequence with rows inserted with explicit id
+	err := Postgresql.Exec("SELECT setval(pg_get_serial_sequence('roles', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM roles").Error
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	for dataType := range enumMapDataType {
-		isExist := false
-		for _, existedDataType := range existedDataTypes {
-			if existedDataType == dataType {
-				isExist = true
-				break
-			}
+	for roleName, permissionNames := range rolePermissions {
+		name := string(roleName)
+		result := Postgresql.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.Role{Name: &name})
+		if result.Error != nil {
+			log.Fatal(result.Error)
+		}
+		if result.RowsAffected == 0 && roleName != entity.SuperAdminRoleName {
+			continue
 		}
 
-		if !isExist {
-			stringEnums := []string{}
-			for _, enum := range enumMapDataType[dataType] {
-				stringEnums = append(stringEnums, fmt.Sprintf("'%v'", enum))
-			}
-
-			err = Postgresql.Exec(fmt.Sprintf("CREATE TYPE %s AS ENUM (%v)", dataType, strings.Join(stringEnums, ", "))).Error
-			if err != nil {
-				log.Fatal(err)
-			}
+		err = Postgresql.Exec(
+			"INSERT INTO role_permissions (role_id, permission_id) SELECT roles.id, permissions.id FROM roles CROSS JOIN permissions WHERE roles.name = ? AND permissions.name IN ? ON CONFLICT DO NOTHING",
+			name,
+			permissionNames,
+		).Error
+		if err != nil {
+			log.Fatal(err)
 		}
 	}
 }
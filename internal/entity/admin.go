@@ -8,16 +8,29 @@ import (
 
 type (
 	Admin struct {
-		ID           *uint64        `gorm:"primaryKey" json:"id"`
-		Role         *Role          `gorm:"foreignKey:RoleID" form:"-" json:"role,omitempty"`
-		RoleID       *uint64        `binding:"required" form:"role_id" gorm:"index" json:"role_id"`
-		CreateAt     *time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"create_at"`
-		UpdateAt     *time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"update_at"`
-		DeleteAt     gorm.DeletedAt `gorm:"index" json:"delete_at"`
-		LastLoginAt  *time.Time     `gorm:"default:null" json:"last_login_at"`
-		Username     *string        `binding:"required" form:"username" gorm:"not null;uniqueIndex" json:"username"`
-		Password     *string        `binding:"required" gorm:"-" json:"password,omitempty"`
-		PasswordHash *[]byte        `gorm:"not null" json:"-"`
+		ID              *uint64        `gorm:"primaryKey" json:"id"`
+		Role            *Role          `gorm:"foreignKey:RoleID" form:"-" json:"role,omitempty"`
+		RoleID          *uint64        `binding:"required" form:"role_id" gorm:"index" json:"role_id"`
+		CreateAt        *time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"create_at"`
+		UpdateAt        *time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"update_at"`
+		DeleteAt        gorm.DeletedAt `gorm:"index" json:"delete_at"`
+		Version         *uint64        `form:"-" gorm:"default:1;not null" json:"version"`
+		LastLoginAt     *time.Time     `gorm:"default:null" json:"last_login_at"`
+		Username        *string        `binding:"required" form:"username" gorm:"not null;uniqueIndex:idx_admins_username_active,where:delete_at IS NULL" json:"username"`
+		Password        *string        `binding:"required" gorm:"-" json:"password,omitempty"`
+		PasswordHash    *[]byte        `gorm:"not null" json:"-"`
+		IsResetPassword *bool          `form:"is_reset_password" gorm:"default:false;not null" json:"is_reset_password"`
+		FailedLogin     *int           `gorm:"default:0;not null" json:"failed_login"`
+		LockUntil       *time.Time     `gorm:"default:null" json:"lock_until"`
+		MFASecret       *string        `gorm:"default:null" json:"-"`
+		MFALastStep     *int64         `gorm:"default:null" json:"-"`
+		IsMFAEnabled    *bool          `gorm:"default:false;not null" json:"is_mfa_enabled"`
+		OIDCSubject     *string        `form:"-" gorm:"default:null;uniqueIndex:idx_admins_oidc_subject_active,where:delete_at IS NULL" json:"oidc_subject"`
+	}
+	// description: first super admin, role is always super admin so only credentials are taken
+	AdminBootstrap struct {
+		Username *string `binding:"required" json:"username"`
+		Password *string `binding:"required" json:"password"`
 	}
 	AdminsWithNavigate struct {
 		Admins     []Admin `json:"admins"`
@@ -26,12 +39,25 @@ type (
 	}
 	AdminFilter struct {
 		Admin
+		DeletedFilter
 		CreateAtAfter  *time.Time `form:"create_at_after" time_format:"2006-01-02T15:04:05" gorm:"-"`
 		CreateAtBefore *time.Time `form:"create_at_before" time_format:"2006-01-02T15:04:05" gorm:"-"`
+		IsLocked       *bool      `form:"is_locked" gorm:"-"`
 	}
 )
 
+func (admin *Admin) IsLocked() bool {
+	return admin.LockUntil != nil && time.Now().Before(*admin.LockUntil)
+}
+
 func (admin *Admin) PreventField() {
 	admin.ID = nil
+	admin.IsResetPassword = nil
 	admin.LastLoginAt = nil
+	admin.Version = nil
+	admin.FailedLogin = nil
+	admin.LockUntil = nil
+	admin.MFASecret = nil
+	admin.IsMFAEnabled = nil
+	admin.OIDCSubject = nil
 }
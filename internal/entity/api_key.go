@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"net/http"
+	"time"
+)
+
+type (
+	// description: key is returned once on create, only prefix and hash are stored
+	APIKey struct {
+		ID        *uint64       `gorm:"primaryKey" json:"id"`
+		Admin     *Admin        `gorm:"foreignKey:AdminID" form:"-" json:"-"`
+		AdminID   *uint64       `form:"admin_id" gorm:"index;not null" json:"admin_id"`
+		Name      *string       `binding:"required" form:"name" gorm:"not null" json:"name"`
+		Prefix    *string       `form:"prefix" gorm:"not null;index" json:"prefix"`
+		Key       *string       `form:"-" gorm:"-" json:"key,omitempty"`
+		KeyHash   *string       `form:"-" gorm:"not null;uniqueIndex" json:"-"`
+		Scopes    []APIKeyScope `binding:"required,min=1,dive,oneof=read write" form:"-" gorm:"serializer:json;not null" json:"scopes"`
+		CreateAt  *time.Time    `gorm:"default:CURRENT_TIMESTAMP" json:"create_at"`
+		ExpireAt  *time.Time    `form:"-" gorm:"default:null" json:"expire_at"`
+		LastUseAt *time.Time    `gorm:"default:null" json:"last_use_at"`
+		RevokeAt  *time.Time    `gorm:"default:null" json:"revoke_at"`
+	}
+	APIKeyScope         string
+	APIKeysWithNavigate struct {
+		APIKeys    []APIKey `json:"api_keys"`
+		Pagination `json:"pagination"`
+		SortOrder  `json:"sort_order"`
+	}
+	APIKeyFilter struct {
+		APIKey
+		IsActive *bool `form:"is_active" gorm:"-"`
+	}
+)
+
+const (
+	ReadAPIKeyScope  APIKeyScope = "read"
+	WriteAPIKeyScope APIKeyScope = "write"
+)
+
+func (apiKey *APIKey) IsActive() bool {
+	return apiKey.RevokeAt == nil && (apiKey.ExpireAt == nil || time.Now().Before(*apiKey.ExpireAt))
+}
+
+// description: read scope allows safe methods only, write scope allows every method
+func (apiKey *APIKey) IsAllowed(method string) bool {
+	for _, scope := range apiKey.Scopes {
+		switch {
+		case scope == WriteAPIKeyScope,
+			scope == ReadAPIKeyScope && (method == http.MethodGet || method == http.MethodHead):
+			return true
+		}
+	}
+
+	return false
+}
+
+func (apiKey *APIKey) PreventField() {
+	apiKey.ID = nil
+	apiKey.AdminID = nil
+	apiKey.Prefix = nil
+	apiKey.Key = nil
+	apiKey.KeyHash = nil
+	apiKey.CreateAt = nil
+	apiKey.LastUseAt = nil
+	apiKey.RevokeAt = nil
+}
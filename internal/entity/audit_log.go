@@ -0,0 +1,164 @@
+package entity
+
+import (
+	"reflect"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+const (
+	CreateAuditAction              AuditAction = "CREATE"
+	DeleteAuditAction              AuditAction = "DELETE"
+	DisableMFAAuditAction          AuditAction = "DISABLE_MFA"
+	EnableMFAAuditAction           AuditAction = "ENABLE_MFA"
+	EnrollMFAAuditAction           AuditAction = "ENROLL_MFA"
+	ForgotPasswordAuditAction      AuditAction = "FORGOT_PASSWORD"
+	ImpersonateAuditAction         AuditAction = "IMPERSONATE"
+	ImpersonatedRequestAuditAction AuditAction = "IMPERSONATED_REQUEST"
+	PurgeAuditAction               AuditAction = "PURGE"
+	ReassignAuditAction            AuditAction = "REASSIGN"
+	ResetPasswordAuditAction       AuditAction = "RESET_PASSWORD"
+	RestoreAuditAction             AuditAction = "RESTORE"
+	RevokeAuditAction              AuditAction = "REVOKE"
+	UnlockAuditAction              AuditAction = "UNLOCK"
+	UpdateAuditAction              AuditAction = "UPDATE"
+
+	AdminAuditEntityType  AuditEntityType = "admin"
+	APIKeyAuditEntityType AuditEntityType = "api_key"
+	RoleAuditEntityType   AuditEntityType = "role"
+	UserAuditEntityType   AuditEntityType = "user"
+
+	auditRedacted = "[REDACTED]"
+)
+
+var (
+	auditNamingStrategy  = schema.NamingStrategy{}
+	auditRedactedColumns = map[string]bool{"password_hash": true, "mfa_secret": true, "key_hash": true}
+	auditTimeType        = reflect.TypeOf(time.Time{})
+)
+
+type (
+	// description: append only, actor is the admin behind the action and entity is the record it touched
+	AuditLog struct {
+		ID         *uint64          `gorm:"primaryKey" json:"id"`
+		ActorID    *uint64          `form:"actor_id" gorm:"index" json:"actor_id"`
+		Action     *AuditAction     `form:"action" gorm:"index;not null" json:"action"`
+		EntityType *AuditEntityType `form:"entity_type" gorm:"index:idx_audit_logs_entity" json:"entity_type"`
+		EntityID   *uint64          `form:"entity_id" gorm:"index:idx_audit_logs_entity" json:"entity_id"`
+		Before     AuditChange      `form:"-" gorm:"serializer:json;type:jsonb;default:null" json:"before,omitempty"`
+		After      AuditChange      `form:"-" gorm:"serializer:json;type:jsonb;default:null" json:"after,omitempty"`
+		Method     *string          `form:"-" gorm:"default:null" json:"method,omitempty"`
+		Path       *string          `form:"-" gorm:"default:null" json:"path,omitempty"`
+		RequestID  *string          `form:"request_id" gorm:"default:null;index" json:"request_id,omitempty"`
+		IPAddress  *string          `form:"-" gorm:"default:null" json:"ip_address"`
+		UserAgent  *string          `form:"-" gorm:"default:null" json:"user_agent"`
+		CreateAt   *time.Time       `form:"-" gorm:"default:CURRENT_TIMESTAMP;index" json:"create_at"`
+	}
+	AuditAction     string
+	AuditChange     map[string]interface{}
+	AuditEntityType string
+	// description: request metadata of the caller, taken from claims and request by handler, roles and permissions authorize the change
+	Audit struct {
+		ActorID          *uint64
+		ActorRoles       []RoleName
+		ActorPermissions []PermissionName
+		RequestID        *string
+		IPAddress        *string
+		UserAgent        *string
+	}
+	AuditLogsWithNavigate struct {
+		AuditLogs  []AuditLog `json:"audit_logs"`
+		Pagination `json:"pagination"`
+		SortOrder  `json:"sort_order"`
+	}
+	AuditLogFilter struct {
+		AuditLog
+		CreateAtAfter  *time.Time `form:"create_at_after" time_format:"2006-01-02T15:04:05" gorm:"-"`
+		CreateAtBefore *time.Time `form:"create_at_before" time_format:"2006-01-02T15:04:05" gorm:"-"`
+	}
+)
+
+func NewAuditLog(audit Audit, action AuditAction, entityType AuditEntityType, entityID *uint64) AuditLog {
+	return AuditLog{
+		ActorID:    audit.ActorID,
+		Action:     &action,
+		EntityType: &entityType,
+		EntityID:   entityID,
+		RequestID:  audit.RequestID,
+		IPAddress:  audit.IPAddress,
+		UserAgent:  audit.UserAgent,
+	}
+}
+
+func (audit Audit) HasActorPermission(expectedPermission PermissionName) bool {
+	for _, permission := range audit.ActorPermissions {
+		if permission == expectedPermission {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (audit Audit) HasActorRole(expectedRole RoleName) bool {
+	for _, role := range audit.ActorRoles {
+		if role == expectedRole {
+			return true
+		}
+	}
+
+	return false
+}
+
+// description: keep changed columns only, nil before is a create and nil after is a delete, nil field of after is not updated
+func (auditLog AuditLog) WithChange(before interface{}, after interface{}) AuditLog {
+	beforeValues, afterValues := auditValues(before), auditValues(after)
+	if before == nil || after == nil {
+		auditLog.Before, auditLog.After = beforeValues, afterValues
+		return auditLog
+	}
+
+	auditLog.Before, auditLog.After = AuditChange{}, AuditChange{}
+	for column, afterValue := range afterValues {
+		beforeValue, ok := beforeValues[column]
+		if ok && !auditRedactedColumns[column] && reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+
+		auditLog.Before[column] = beforeValue
+		auditLog.After[column] = afterValue
+	}
+
+	return auditLog
+}
+
+// description: persisted non nil columns of entity, associations and fields without column are skipped, secrets are redacted
+func auditValues(value interface{}) AuditChange {
+	values := AuditChange{}
+	if value == nil {
+		return values
+	}
+
+	reflectValue := reflect.Indirect(reflect.ValueOf(value))
+	reflectType := reflectValue.Type()
+	for index := 0; index < reflectType.NumField(); index++ {
+		field := reflectType.Field(index)
+		fieldValue := reflectValue.Field(index)
+		if field.Tag.Get("gorm") == "-" || fieldValue.Kind() != reflect.Ptr || fieldValue.IsNil() {
+			continue
+		}
+		if fieldValue.Elem().Kind() == reflect.Struct && fieldValue.Elem().Type() != auditTimeType {
+			continue
+		}
+
+		column := auditNamingStrategy.ColumnName("", field.Name)
+		if auditRedactedColumns[column] {
+			values[column] = auditRedacted
+			continue
+		}
+		values[column] = fieldValue.Elem().Interface()
+	}
+
+	return values
+}
@@ -1,12 +1,38 @@
 package entity
 
+import "time"
+
 type (
 	AccessToken struct {
-		AccessToken *string `json:"access_token"`
+		AccessToken   *string  `json:"access_token"`
+		RefreshToken  *string  `json:"refresh_token,omitempty"`
+		MFARequired   *bool    `json:"mfa_required,omitempty"`
+		MFAToken      *string  `json:"mfa_token,omitempty"`
+		RecoveryCodes []string `json:"recovery_codes,omitempty"`
+	}
+	Forgot struct {
+		Username *string `binding:"required" json:"username"`
+	}
+	ForgotConfirm struct {
+		Token    *string `binding:"required" json:"token"`
+		Password *string `binding:"required" json:"password"`
 	}
 	Login struct {
 		Username *string `binding:"required" json:"username"`
 		Password *string `binding:"required" json:"password"`
+		Device   Device  `json:"-"`
+	}
+	Logout struct {
+		AdminID      *uint64
+		UserID       *uint64
+		SessionID    *uint64
+		JTI          *string
+		ExpireAt     *time.Time
+		RefreshToken *string `json:"refresh_token"`
+	}
+	Refresh struct {
+		RefreshToken *string `binding:"required" json:"refresh_token"`
+		Device       Device  `json:"-"`
 	}
 	Reset struct {
 		ID       *uint64
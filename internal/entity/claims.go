@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+type (
+	// description: claims of access token, mfa token and api key, set in gin context as claims by authorization middleware
+	Claims struct {
+		jwt.StandardClaims
+		Actor           *Actor           `json:"act,omitempty"`
+		APIKeyID        *uint64          `json:"api_key_id,omitempty"`
+		IsResetPassword bool             `json:"is_reset_password,omitempty"`
+		Permissions     []PermissionName `json:"permissions,omitempty"`
+		Purpose         string           `json:"purpose,omitempty"`
+		Roles           []RoleName       `json:"roles"`
+		SessionID       *uint64          `json:"sid,omitempty"`
+	}
+	// description: actor claim (rfc 8693) names the admin behind an impersonated user token
+	Actor struct {
+		Subject string `json:"sub"`
+	}
+)
+
+// description: nil when token is not impersonated
+func (claims *Claims) ActorID() (*uint64, error) {
+	if claims.Actor == nil {
+		return nil, nil
+	}
+
+	actorID, err := strconv.ParseUint(claims.Actor.Subject, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &actorID, nil
+}
+
+func (claims *Claims) HasPermission(expectedPermission PermissionName) bool {
+	for _, permission := range claims.Permissions {
+		if permission == expectedPermission {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (claims *Claims) IsUser() bool {
+	for _, role := range claims.Roles {
+		if role == UserRoleName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// description: subject is admin id or user id depending on roles
+func (claims *Claims) Account() (*uint64, *uint64, error) {
+	subject, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if claims.IsUser() {
+		return nil, &subject, nil
+	}
+
+	return &subject, nil, nil
+}
+
+func (claims *Claims) RevokedToken() (RevokedToken, error) {
+	adminID, userID, err := claims.Account()
+	if err != nil {
+		return RevokedToken{}, err
+	}
+	expireAt := time.Unix(claims.ExpiresAt, 0)
+
+	revokedToken := RevokedToken{
+		SessionID: claims.SessionID,
+		AdminID:   adminID,
+		UserID:    userID,
+		ExpireAt:  &expireAt,
+	}
+	if claims.Id != "" {
+		revokedToken.JTI = &claims.Id
+	}
+
+	return revokedToken, nil
+}
@@ -3,9 +3,20 @@ package entity
 import (
 	"math"
 	"strings"
+	"time"
+)
+
+const (
+	DeletedInclude = "include"
+	DeletedOnly    = "only"
 )
 
 type (
+	// description: soft deleted rows are hidden unless deleted is include or only
+	DeletedFilter struct {
+		Deleted        *string    `form:"deleted" gorm:"-"`
+		DeleteAtBefore *time.Time `form:"delete_at_before" time_format:"2006-01-02T15:04:05" gorm:"-"`
+	}
 	Pagination struct {
 		Limit       int    `binding:"required,min=1,max=1000" form:"limit" json:"limit" gorm:"-"`
 		Offset      int    `binding:"min=0" form:"offset" json:"offset" gorm:"-"`
@@ -25,6 +36,19 @@ func (pagination *Pagination) CalculateTotal() {
 	}
 }
 
+func (deletedFilter *DeletedFilter) Validate() bool {
+	if deletedFilter.Deleted == nil {
+		return true
+	}
+
+	switch *deletedFilter.Deleted {
+	case DeletedInclude, DeletedOnly:
+		return true
+	default:
+		return false
+	}
+}
+
 func InitialSortOrder() SortOrder {
 	return SortOrder{
 		Sort:  "id",
@@ -0,0 +1,23 @@
+package entity
+
+import "fmt"
+
+const (
+	CheckConstraintType      ConstraintType = "check"
+	ForeignKeyConstraintType ConstraintType = "foreign_key"
+	UniqueConstraintType     ConstraintType = "unique"
+)
+
+type (
+	// description: write rejected by database constraint, field is the offending column which matches json name
+	ConstraintError struct {
+		Type       ConstraintType
+		Constraint string
+		Field      string
+	}
+	ConstraintType string
+)
+
+func (constraintError ConstraintError) Error() string {
+	return fmt.Sprintf("%s constraint %s violated on %s", constraintError.Type, constraintError.Constraint, constraintError.Field)
+}
@@ -0,0 +1,9 @@
+package entity
+
+type (
+	// description: admin acting as user, actor of audit becomes act claim of the issued user token
+	Impersonation struct {
+		UserID *uint64
+		Audit  Audit
+	}
+)
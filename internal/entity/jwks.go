@@ -0,0 +1,18 @@
+package entity
+
+type (
+	JSONWebKey struct {
+		Algorithm string `json:"alg"`
+		Curve     string `json:"crv,omitempty"`
+		Exponent  string `json:"e,omitempty"`
+		KeyID     string `json:"kid"`
+		KeyType   string `json:"kty"`
+		Modulus   string `json:"n,omitempty"`
+		Use       string `json:"use"`
+		X         string `json:"x,omitempty"`
+		Y         string `json:"y,omitempty"`
+	}
+	JSONWebKeySet struct {
+		Keys []JSONWebKey `json:"keys"`
+	}
+)
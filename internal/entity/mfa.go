@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+type (
+	MFA struct {
+		AdminID      *uint64 `json:"-"`
+		UserID       *uint64 `json:"-"`
+		Code         *string `json:"code"`
+		MFAToken     *string `json:"mfa_token"`
+		RecoveryCode *string `json:"recovery_code"`
+		Device       Device  `json:"-"`
+	}
+	MFAEnrollment struct {
+		Secret *string `json:"secret"`
+		URI    *string `json:"uri"`
+		QRCode *string `json:"qr_code"`
+	}
+	MFARecovery struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	RecoveryCode struct {
+		ID       *uint64    `gorm:"primaryKey" json:"id"`
+		AdminID  *uint64    `gorm:"index" json:"admin_id,omitempty"`
+		UserID   *uint64    `gorm:"index" json:"user_id,omitempty"`
+		CodeHash *string    `gorm:"not null;index" json:"-"`
+		CreateAt *time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"create_at"`
+		UseAt    *time.Time `gorm:"default:null" json:"use_at"`
+	}
+)
@@ -0,0 +1,16 @@
+package entity
+
+type (
+	Notification struct {
+		Channel   NotificationChannel
+		Recipient string
+		Subject   string
+		Body      string
+	}
+	NotificationChannel string
+)
+
+const (
+	EmailNotificationChannel NotificationChannel = "EMAIL"
+	SMSNotificationChannel   NotificationChannel = "SMS"
+)
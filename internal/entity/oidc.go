@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+type (
+	// description: authorization request waiting for callback, code verifier and nonce never leave the server
+	OIDCState struct {
+		ID           *uint64    `gorm:"primaryKey" json:"id"`
+		StateHash    *string    `gorm:"not null;uniqueIndex" json:"-"`
+		CodeVerifier *string    `gorm:"not null" json:"-"`
+		Nonce        *string    `gorm:"not null" json:"-"`
+		CreateAt     *time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"create_at"`
+		ExpireAt     *time.Time `gorm:"not null" json:"expire_at"`
+		UseAt        *time.Time `gorm:"default:null" json:"use_at"`
+	}
+	OIDCCallback struct {
+		Code             *string `form:"code"`
+		State            *string `form:"state"`
+		Error            *string `form:"error"`
+		ErrorDescription *string `form:"error_description"`
+		Device           Device  `form:"-"`
+	}
+	OIDCIdentity struct {
+		Subject  string
+		Username string
+		Email    string
+		Groups   []string
+	}
+)
+
+func (oidcState *OIDCState) IsExpired() bool {
+	return oidcState.ExpireAt == nil || time.Now().After(*oidcState.ExpireAt)
+}
+
+func (oidcState *OIDCState) IsUsed() bool {
+	return oidcState.UseAt != nil
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type (
+	PasswordHistory struct {
+		ID           *uint64    `gorm:"primaryKey" json:"id"`
+		AdminID      *uint64    `gorm:"index" json:"admin_id,omitempty"`
+		UserID       *uint64    `gorm:"index" json:"user_id,omitempty"`
+		PasswordHash *[]byte    `gorm:"not null" json:"-"`
+		CreateAt     *time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"create_at"`
+	}
+)
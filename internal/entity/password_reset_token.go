@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type (
+	PasswordResetToken struct {
+		ID        *uint64    `gorm:"primaryKey" json:"id"`
+		UserID    *uint64    `gorm:"index;not null" json:"user_id"`
+		TokenHash *string    `gorm:"not null;uniqueIndex" json:"-"`
+		CreateAt  *time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"create_at"`
+		ExpireAt  *time.Time `gorm:"not null" json:"expire_at"`
+		UseAt     *time.Time `gorm:"default:null" json:"use_at"`
+	}
+)
+
+func (passwordResetToken *PasswordResetToken) IsExpired() bool {
+	return passwordResetToken.ExpireAt == nil || time.Now().After(*passwordResetToken.ExpireAt)
+}
+
+func (passwordResetToken *PasswordResetToken) IsUsed() bool {
+	return passwordResetToken.UseAt != nil
+}
@@ -0,0 +1,55 @@
+package entity
+
+import "sort"
+
+type (
+	Permission struct {
+		ID          *uint64         `gorm:"primaryKey" json:"id"`
+		Name        *PermissionName `gorm:"not null;uniqueIndex" json:"name"`
+		Description *string         `gorm:"default:null" json:"description"`
+	}
+	PermissionName string
+	// description: replace all permissions of a role
+	RolePermission struct {
+		Permissions []PermissionName `binding:"required,dive,required" json:"permissions"`
+	}
+)
+
+const (
+	AdminReadPermissionName    PermissionName = "admin:read"
+	AdminWritePermissionName   PermissionName = "admin:write"
+	APIKeyManagePermissionName PermissionName = "api_key:manage"
+	AuditReadPermissionName    PermissionName = "audit:read"
+	PolicyReadPermissionName   PermissionName = "policy:read"
+	PolicyWritePermissionName  PermissionName = "policy:write"
+	RoleReadPermissionName     PermissionName = "role:read"
+	RoleWritePermissionName    PermissionName = "role:write"
+	UserAssignPermissionName   PermissionName = "user:assign"
+	UserReadPermissionName     PermissionName = "user:read"
+	UserWritePermissionName    PermissionName = "user:write"
+)
+
+// description: permission catalogue seeded into database, routes can only require permissions listed here
+var PermissionDescriptions = map[PermissionName]string{
+	AdminReadPermissionName:    "list and view admins",
+	AdminWritePermissionName:   "create, update, delete and unlock admins",
+	APIKeyManagePermissionName: "create, list and revoke own api keys",
+	AuditReadPermissionName:    "list audit log of admin and user changes",
+	PolicyReadPermissionName:   "view security policy",
+	PolicyWritePermissionName:  "update security policy",
+	RoleReadPermissionName:     "list and view roles and permissions",
+	RoleWritePermissionName:    "create, update, delete roles and assign permissions",
+	UserAssignPermissionName:   "reassign owning admin of users",
+	UserReadPermissionName:     "list and view users and their sessions",
+	UserWritePermissionName:    "create, update, delete, unlock users and revoke their sessions",
+}
+
+func AllPermissionNames() []PermissionName {
+	permissionNames := []PermissionName{}
+	for permissionName := range PermissionDescriptions {
+		permissionNames = append(permissionNames, permissionName)
+	}
+	sort.Slice(permissionNames, func(i, j int) bool { return permissionNames[i] < permissionNames[j] })
+
+	return permissionNames
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type (
+	Policy struct {
+		ID                 *uint64    `gorm:"primaryKey" json:"-"`
+		IsAdminMFARequired *bool      `gorm:"default:false;not null" json:"is_admin_mfa_required"`
+		UpdateAt           *time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"update_at"`
+	}
+)
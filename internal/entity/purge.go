@@ -0,0 +1,9 @@
+package entity
+
+type (
+	// description: amount of soft deleted rows permanently removed by one purge run
+	PurgeResult struct {
+		AdminAmount int `json:"admin_amount"`
+		UserAmount  int `json:"user_amount"`
+	}
+)
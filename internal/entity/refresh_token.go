@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+type (
+	RefreshToken struct {
+		ID        *uint64    `gorm:"primaryKey" json:"id"`
+		AdminID   *uint64    `gorm:"index" json:"admin_id,omitempty"`
+		UserID    *uint64    `gorm:"index" json:"user_id,omitempty"`
+		FamilyID  *string    `gorm:"not null;index" json:"family_id"`
+		TokenHash *string    `gorm:"not null;uniqueIndex" json:"-"`
+		CreateAt  *time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"create_at"`
+		ExpireAt  *time.Time `gorm:"not null" json:"expire_at"`
+		RevokeAt  *time.Time `gorm:"default:null" json:"revoke_at"`
+	}
+)
+
+func (refreshToken *RefreshToken) IsExpired() bool {
+	return refreshToken.ExpireAt == nil || time.Now().After(*refreshToken.ExpireAt)
+}
+
+func (refreshToken *RefreshToken) IsRevoked() bool {
+	return refreshToken.RevokeAt != nil
+}
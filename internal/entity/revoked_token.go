@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type (
+	// description: revoke single token by jti, every token of session by session id, or every token of admin/user issued before create at
+	RevokedToken struct {
+		ID        *uint64    `gorm:"primaryKey" json:"id"`
+		JTI       *string    `gorm:"uniqueIndex" json:"jti,omitempty"`
+		SessionID *uint64    `gorm:"index" json:"session_id,omitempty"`
+		AdminID   *uint64    `gorm:"index" json:"admin_id,omitempty"`
+		UserID    *uint64    `gorm:"index" json:"user_id,omitempty"`
+		CreateAt  *time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"create_at"`
+		ExpireAt  *time.Time `gorm:"not null;index" json:"expire_at"`
+	}
+)
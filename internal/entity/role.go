@@ -2,13 +2,37 @@ package entity
 
 type (
 	Role struct {
-		ID   *uint64 `gorm:"primaryKey" json:"id"`
-		Name *string `gorm:"not null;uniqueIndex" json:"name"`
+		ID          *uint64      `gorm:"primaryKey" json:"id"`
+		Name        *string      `binding:"required" gorm:"not null;uniqueIndex" json:"name"`
+		Permissions []Permission `binding:"-" gorm:"many2many:role_permissions" json:"permissions,omitempty"`
 	}
 	RoleName string
 )
 
 const (
+	AdminRoleName      RoleName = "ADMIN"
 	SuperAdminRoleName RoleName = "SUPER_ADMIN"
 	UserRoleName       RoleName = "USER"
 )
+
+// description: roles seeded when missing, super admin is granted every permission on each migration
+var DefaultRolePermissions = map[RoleName][]PermissionName{
+	AdminRoleName:      {UserReadPermissionName, UserWritePermissionName},
+	SuperAdminRoleName: AllPermissionNames(),
+}
+
+func (role *Role) PermissionNames() []PermissionName {
+	permissionNames := []PermissionName{}
+	for _, permission := range role.Permissions {
+		if permission.Name != nil {
+			permissionNames = append(permissionNames, *permission.Name)
+		}
+	}
+
+	return permissionNames
+}
+
+func (role *Role) PreventField() {
+	role.ID = nil
+	role.Permissions = nil
+}
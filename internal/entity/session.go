@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type (
+	// description: one login, linked to its refresh token family, active while the family has a usable refresh token
+	Session struct {
+		ID         *uint64    `gorm:"primaryKey" json:"id"`
+		AdminID    *uint64    `gorm:"index" json:"admin_id,omitempty"`
+		UserID     *uint64    `gorm:"index" json:"user_id,omitempty"`
+		FamilyID   *string    `gorm:"not null;uniqueIndex" json:"-"`
+		UserAgent  *string    `gorm:"default:null" json:"user_agent"`
+		IPAddress  *string    `gorm:"default:null" json:"ip_address"`
+		CreateAt   *time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"create_at"`
+		LastSeenAt *time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"last_seen_at"`
+		IsCurrent  bool       `gorm:"-" json:"is_current"`
+	}
+	Device struct {
+		UserAgent *string
+		IPAddress *string
+	}
+)
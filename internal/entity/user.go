@@ -14,13 +14,25 @@ type (
 		CreateAt        *time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"create_at"`
 		UpdateAt        *time.Time     `gorm:"default:CURRENT_TIMESTAMP" json:"update_at"`
 		DeleteAt        gorm.DeletedAt `gorm:"index" json:"delete_at"`
+		Version         *uint64        `form:"-" gorm:"default:1;not null" json:"version"`
 		LastLoginAt     *time.Time     `gorm:"default:null" json:"last_login_at"`
-		Username        *string        `binding:"required" form:"username" gorm:"uniqueIndex;not null" json:"username"`
+		Username        *string        `binding:"required" form:"username" gorm:"uniqueIndex:idx_users_username_active,where:delete_at IS NULL;not null" json:"username"`
 		Password        *string        `binding:"required" gorm:"-" json:"password,omitempty"`
 		PasswordHash    *[]byte        `gorm:"not null" json:"-"`
 		Name            *string        `binding:"required" form:"name" gorm:"not null" json:"name"`
-		Phone           *string        `binding:"required" form:"phone" gorm:"uniqueIndex;not null" json:"phone"`
+		Phone           *string        `binding:"required" form:"phone" gorm:"uniqueIndex:idx_users_phone_active,where:delete_at IS NULL;not null" json:"phone"`
+		Email           *string        `binding:"omitempty,email" form:"email" gorm:"default:null" json:"email"`
 		IsResetPassword *bool          `form:"is_reset_password" gorm:"default:true" json:"is_reset_password"`
+		FailedLogin     *int           `gorm:"default:0;not null" json:"failed_login"`
+		LockUntil       *time.Time     `gorm:"default:null" json:"lock_until"`
+		MFASecret       *string        `gorm:"default:null" json:"-"`
+		MFALastStep     *int64         `gorm:"default:null" json:"-"`
+		IsMFAEnabled    *bool          `gorm:"default:false;not null" json:"is_mfa_enabled"`
+		ImpersonatorID  *uint64        `gorm:"-" json:"impersonator_id,omitempty"`
+	}
+	// description: reassign owning admin of a user
+	UserOwner struct {
+		AdminID *uint64 `binding:"required" json:"admin_id"`
 	}
 	UsersWithNavigate struct {
 		Users      []User `json:"users"`
@@ -29,15 +41,27 @@ type (
 	}
 	UserFilter struct {
 		User
+		DeletedFilter
 		CreateAtAfter  *time.Time `form:"create_at_after" time_format:"2006-01-02T15:04:05" gorm:"-"`
 		CreateAtBefore *time.Time `form:"create_at_before" time_format:"2006-01-02T15:04:05" gorm:"-"`
+		IsLocked       *bool      `form:"is_locked" gorm:"-"`
 		Search         *string    `form:"search" gorm:"-"`
 	}
 )
 
+func (user *User) IsLocked() bool {
+	return user.LockUntil != nil && time.Now().Before(*user.LockUntil)
+}
+
 func (user *User) PreventField() {
 	user.AdminID = nil
 	user.ID = nil
 	user.IsResetPassword = nil
 	user.LastLoginAt = nil
+	user.Version = nil
+	user.FailedLogin = nil
+	user.LockUntil = nil
+	user.MFASecret = nil
+	user.IsMFAEnabled = nil
+	user.ImpersonatorID = nil
 }
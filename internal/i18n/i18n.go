@@ -0,0 +1,101 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	EnglishLocale = "en"
+	ThaiLocale    = "th"
+
+	// description: used when accept language is missing or matches no bundle, and when key is missing from matched bundle
+	DefaultLocale = EnglishLocale
+)
+
+var (
+	//go:embed locale/*.json
+	localeFiles embed.FS
+
+	bundles      = mustLoadBundles()
+	bundlesMutex = sync.RWMutex{}
+	// description: same order as matcher tags, match index points into it
+	locales       = []string{EnglishLocale, ThaiLocale}
+	localeMatcher = language.NewMatcher([]language.Tag{language.English, language.Thai})
+)
+
+// description: locale of bundle best matching accept language header, e.g. th-TH,th;q=0.9,en;q=0.8 is th
+func MatchLocale(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+
+	_, index, confidence := localeMatcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+
+	return locales[index]
+}
+
+// description: message of key with {name} placeholders replaced by params, false when no bundle has the key
+func Translate(locale string, key string, params map[string]string) (string, bool) {
+	bundlesMutex.RLock()
+	message, ok := bundles[locale][key]
+	if !ok {
+		message, ok = bundles[DefaultLocale][key]
+	}
+	bundlesMutex.RUnlock()
+	if !ok {
+		return "", false
+	}
+
+	replacements := []string{}
+	for name, value := range params {
+		replacements = append(replacements, fmt.Sprintf("{%s}", name), value)
+	}
+
+	return strings.NewReplacer(replacements...).Replace(message), true
+}
+
+// description: let handler add or override keys, e.g. from its constructor, key already in bundle is replaced
+func AddMessages(locale string, messages map[string]string) error {
+	bundlesMutex.Lock()
+	defer bundlesMutex.Unlock()
+
+	bundle, ok := bundles[locale]
+	if !ok {
+		return fmt.Errorf("locale %s is not supported", locale)
+	}
+	for key, message := range messages {
+		bundle[key] = message
+	}
+
+	return nil
+}
+
+func mustLoadBundles() map[string]map[string]string {
+	bundles := map[string]map[string]string{}
+	for _, locale := range locales {
+		content, err := localeFiles.ReadFile(path.Join("locale", locale+".json"))
+		if err != nil {
+			panic(err)
+		}
+
+		bundle := map[string]string{}
+		err = json.Unmarshal(content, &bundle)
+		if err != nil {
+			panic(fmt.Errorf("locale %s: %w", locale, err))
+		}
+		bundles[locale] = bundle
+	}
+
+	return bundles
+}
@@ -0,0 +1,74 @@
+package i18n_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchLocale(test *testing.T) {
+	test.Run("Success/Thai", func(test *testing.T) {
+		assert.Equal(test, i18n.ThaiLocale, i18n.MatchLocale("th-TH,th;q=0.9,en;q=0.8"))
+	})
+
+	test.Run("Success/English", func(test *testing.T) {
+		assert.Equal(test, i18n.EnglishLocale, i18n.MatchLocale("en-US,en;q=0.9"))
+	})
+
+	test.Run("Success/Default", func(test *testing.T) {
+		assert.Equal(test, i18n.DefaultLocale, i18n.MatchLocale(""))
+		assert.Equal(test, i18n.DefaultLocale, i18n.MatchLocale("ja-JP"))
+		assert.Equal(test, i18n.DefaultLocale, i18n.MatchLocale("invalid;;"))
+	})
+}
+
+func TestTranslate(test *testing.T) {
+	test.Run("Success", func(test *testing.T) {
+		message, ok := i18n.Translate(i18n.ThaiLocale, "validation.min_length", map[string]string{"field": "รหัสผ่าน", "param": "12"})
+		assert.True(test, ok)
+		assert.Equal(test, "รหัสผ่าน ต้องมีความยาวอย่างน้อย 12 ตัวอักษร", message)
+	})
+
+	test.Run("Success/Catalogue", func(test *testing.T) {
+		errorCodes := reflect.ValueOf(common.ErrorCode)
+		for index := 0; index < errorCodes.NumField(); index++ {
+			code := errorCodes.Field(index).String()
+			for _, locale := range []string{i18n.EnglishLocale, i18n.ThaiLocale} {
+				message, ok := i18n.Translate(locale, code, nil)
+				assert.True(test, ok, "%s has no %s message", code, locale)
+				if locale == i18n.ThaiLocale {
+					english, _ := i18n.Translate(i18n.EnglishLocale, code, nil)
+					assert.NotEqual(test, english, message, "%s has no thai translation", code)
+				}
+			}
+		}
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		_, ok := i18n.Translate(i18n.ThaiLocale, "unknown.key", nil)
+		assert.False(test, ok)
+	})
+}
+
+func TestAddMessages(test *testing.T) {
+	test.Run("Success", func(test *testing.T) {
+		err := i18n.AddMessages(i18n.ThaiLocale, map[string]string{"greeting": "สวัสดี {name}"})
+		assert.NoError(test, err)
+
+		message, ok := i18n.Translate(i18n.ThaiLocale, "greeting", map[string]string{"name": "admin"})
+		assert.True(test, ok)
+		assert.Equal(test, "สวัสดี admin", message)
+
+		// description: english falls back to default locale, which lacks the key too
+		_, ok = i18n.Translate(i18n.EnglishLocale, "greeting", nil)
+		assert.False(test, ok)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		err := i18n.AddMessages("ja", map[string]string{"greeting": "こんにちは"})
+		assert.Error(test, err)
+	})
+}
@@ -0,0 +1,159 @@
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"golang.org/x/oauth2"
+)
+
+//go:generate mockgen -package=identitymock -destination=../../mock/identity/provider.go . Provider
+
+const providerTimeout = 30 * time.Second
+
+type (
+	Provider interface {
+		AuthCodeURL(state, nonce, codeVerifier string) string
+		Exchange(code, nonce, codeVerifier string) (entity.OIDCIdentity, error)
+	}
+	oidcProvider struct {
+		groupClaim    string
+		oauth2Config  oauth2.Config
+		usernameClaim string
+		verifier      *oidc.IDTokenVerifier
+	}
+)
+
+// description: empty issuer disables oidc login, discovery failure stops the server
+func NewProvider() Provider {
+	if config.OIDC.Issuer == "" {
+		return nil
+	}
+
+	provider, err := NewOIDCProvider(config.OIDC)
+	if err != nil {
+		log.Fatalf("Error initial oidc provider: %s", err)
+	}
+
+	return provider
+}
+
+// description: endpoints and signing keys are discovered from issuer well-known configuration
+func NewOIDCProvider(oidcConfig config.OIDCConfig) (Provider, error) {
+	context, cancel := context.WithTimeout(context.Background(), providerTimeout)
+	defer cancel()
+
+	provider, err := oidc.NewProvider(context, oidcConfig.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := []string{oidc.ScopeOpenID}
+	for _, scope := range strings.Split(oidcConfig.Scope, ",") {
+		scope = strings.TrimSpace(scope)
+		if scope != "" && scope != oidc.ScopeOpenID {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &oidcProvider{
+		groupClaim: oidcConfig.GroupClaim,
+		oauth2Config: oauth2.Config{
+			ClientID:     oidcConfig.ClientID,
+			ClientSecret: oidcConfig.ClientSecret,
+			Endpoint:     provider.Endpoint(),
+			RedirectURL:  oidcConfig.RedirectURL,
+			Scopes:       scopes,
+		},
+		usernameClaim: oidcConfig.UsernameClaim,
+		verifier:      provider.Verifier(&oidc.Config{ClientID: oidcConfig.ClientID}),
+	}, nil
+}
+
+func (provider *oidcProvider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	return provider.oauth2Config.AuthCodeURL(
+		state,
+		oidc.Nonce(nonce),
+		oauth2.SetAuthURLParam("code_challenge", codeChallenge(codeVerifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+}
+
+// description: exchange code with pkce verifier, then verify signature, issuer, audience, expiry and nonce of id token
+func (provider *oidcProvider) Exchange(code, nonce, codeVerifier string) (entity.OIDCIdentity, error) {
+	context, cancel := context.WithTimeout(context.Background(), providerTimeout)
+	defer cancel()
+
+	token, err := provider.oauth2Config.Exchange(context, code, oauth2.SetAuthURLParam("code_verifier", codeVerifier))
+	if err != nil {
+		return entity.OIDCIdentity{}, err
+	}
+
+	rawIDToken, isString := token.Extra("id_token").(string)
+	if !isString || rawIDToken == "" {
+		return entity.OIDCIdentity{}, errors.New("id token not found")
+	}
+
+	idToken, err := provider.verifier.Verify(context, rawIDToken)
+	if err != nil {
+		return entity.OIDCIdentity{}, err
+	}
+	if idToken.Nonce != nonce {
+		return entity.OIDCIdentity{}, errors.New("invalid nonce")
+	}
+
+	claims := map[string]interface{}{}
+	err = idToken.Claims(&claims)
+	if err != nil {
+		return entity.OIDCIdentity{}, err
+	}
+
+	identity := entity.OIDCIdentity{Subject: idToken.Subject}
+	identity.Username, _ = claims[provider.usernameClaim].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.Groups, err = getGroups(claims[provider.groupClaim])
+	if err != nil {
+		return entity.OIDCIdentity{}, err
+	}
+
+	return identity, nil
+}
+
+// description: s256 challenge of rfc 7636
+func codeChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// description: identity providers send groups as array, single string or omit the claim
+func getGroups(claim interface{}) ([]string, error) {
+	switch value := claim.(type) {
+	case nil:
+		return []string{}, nil
+	case string:
+		return []string{value}, nil
+	case []interface{}:
+		groups := []string{}
+		for _, group := range value {
+			groupString, isString := group.(string)
+			if !isString {
+				return []string{}, fmt.Errorf("invalid group claim %v", group)
+			}
+			groups = append(groups, groupString)
+		}
+
+		return groups, nil
+	default:
+		return []string{}, fmt.Errorf("invalid group claim %v", claim)
+	}
+}
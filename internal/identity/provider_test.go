@@ -0,0 +1,300 @@
+package identity_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testClientID    = "gin-template"
+	testKeyID       = "test-key"
+	testRedirectURL = "http://localhost/admin/api/auth/oidc/callback"
+)
+
+type (
+	// description: local identity provider, authorize endpoint signs in immediately and redirects with code
+	mockOIDCServer struct {
+		*httptest.Server
+		claims         jwt.MapClaims
+		requests       map[string]url.Values
+		mutex          sync.Mutex
+		privateKey     *rsa.PrivateKey
+		signPrivateKey *rsa.PrivateKey
+	}
+)
+
+func newMockOIDCServer(test *testing.T) *mockOIDCServer {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(test, err)
+
+	server := &mockOIDCServer{
+		claims:         jwt.MapClaims{},
+		requests:       map[string]url.Values{},
+		privateKey:     privateKey,
+		signPrivateKey: privateKey,
+	}
+
+	serveMux := http.NewServeMux()
+	serveMux.HandleFunc("/.well-known/openid-configuration", server.discovery)
+	serveMux.HandleFunc("/authorize", server.authorize)
+	serveMux.HandleFunc("/jwks", server.jwks)
+	serveMux.HandleFunc("/token", server.token)
+	server.Server = httptest.NewServer(serveMux)
+	test.Cleanup(server.Close)
+
+	return server
+}
+
+func (server *mockOIDCServer) discovery(responseWriter http.ResponseWriter, request *http.Request) {
+	_ = json.NewEncoder(responseWriter).Encode(map[string]interface{}{
+		"issuer":                                server.URL,
+		"authorization_endpoint":                server.URL + "/authorize",
+		"token_endpoint":                        server.URL + "/token",
+		"jwks_uri":                              server.URL + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (server *mockOIDCServer) authorize(responseWriter http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	code := "code-" + query.Get("state")
+
+	server.mutex.Lock()
+	server.requests[code] = query
+	server.mutex.Unlock()
+
+	http.Redirect(responseWriter, request, query.Get("redirect_uri")+"?code="+code+"&state="+query.Get("state"), http.StatusFound)
+}
+
+func (server *mockOIDCServer) jwks(responseWriter http.ResponseWriter, request *http.Request) {
+	publicKey := server.privateKey.PublicKey
+	_ = json.NewEncoder(responseWriter).Encode(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": testKeyID,
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+}
+
+// description: code is single use and verifier must match challenge sent to authorize endpoint
+func (server *mockOIDCServer) token(responseWriter http.ResponseWriter, request *http.Request) {
+	_ = request.ParseForm()
+
+	server.mutex.Lock()
+	authorizeQuery, isExist := server.requests[request.PostForm.Get("code")]
+	delete(server.requests, request.PostForm.Get("code"))
+	server.mutex.Unlock()
+
+	hash := sha256.Sum256([]byte(request.PostForm.Get("code_verifier")))
+	if !isExist || authorizeQuery.Get("code_challenge_method") != "S256" || authorizeQuery.Get("code_challenge") != base64.RawURLEncoding.EncodeToString(hash[:]) {
+		responseWriter.Header().Set("Content-Type", "application/json")
+		responseWriter.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(responseWriter).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":   server.URL,
+		"aud":   testClientID,
+		"sub":   "subject",
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"nonce": authorizeQuery.Get("nonce"),
+	}
+	for key, value := range server.claims {
+		claims[key] = value
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testKeyID
+	idToken, _ := token.SignedString(server.signPrivateKey)
+
+	responseWriter.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(responseWriter).Encode(map[string]interface{}{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     idToken,
+	})
+}
+
+// description: follow authorization url like a browser and return code from redirect to callback
+func (server *mockOIDCServer) login(test *testing.T, authorizationURL string) string {
+	client := &http.Client{CheckRedirect: func(request *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	response, err := client.Get(authorizationURL)
+	assert.NoError(test, err)
+	defer response.Body.Close()
+
+	location, err := url.Parse(response.Header.Get("Location"))
+	assert.NoError(test, err)
+
+	return location.Query().Get("code")
+}
+
+func beforeTestProvider(test *testing.T) (*mockOIDCServer, identity.Provider) {
+	server := newMockOIDCServer(test)
+
+	provider, err := identity.NewOIDCProvider(config.OIDCConfig{
+		ClientID:      testClientID,
+		ClientSecret:  "secret",
+		GroupClaim:    "groups",
+		Issuer:        server.URL,
+		RedirectURL:   testRedirectURL,
+		Scope:         "openid,profile,email",
+		UsernameClaim: "preferred_username",
+	})
+	assert.NoError(test, err)
+
+	return server, provider
+}
+
+func TestNewOIDCProvider(test *testing.T) {
+	test.Run("Success", func(test *testing.T) {
+		_, provider := beforeTestProvider(test)
+
+		assert.NotNil(test, provider)
+	})
+	test.Run("ErrorDiscovery", func(test *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		_, err := identity.NewOIDCProvider(config.OIDCConfig{Issuer: server.URL})
+
+		assert.Error(test, err)
+	})
+}
+
+func TestProviderAuthCodeURL(test *testing.T) {
+	server, provider := beforeTestProvider(test)
+
+	authorizationURL, err := url.Parse(provider.AuthCodeURL("state", "nonce", "verifier"))
+	assert.NoError(test, err)
+
+	hash := sha256.Sum256([]byte("verifier"))
+	query := authorizationURL.Query()
+	assert.Equal(test, server.URL+"/authorize", authorizationURL.Scheme+"://"+authorizationURL.Host+authorizationURL.Path)
+	assert.Equal(test, "code", query.Get("response_type"))
+	assert.Equal(test, testClientID, query.Get("client_id"))
+	assert.Equal(test, testRedirectURL, query.Get("redirect_uri"))
+	assert.Equal(test, "openid profile email", query.Get("scope"))
+	assert.Equal(test, "state", query.Get("state"))
+	assert.Equal(test, "nonce", query.Get("nonce"))
+	assert.Equal(test, "S256", query.Get("code_challenge_method"))
+	assert.Equal(test, base64.RawURLEncoding.EncodeToString(hash[:]), query.Get("code_challenge"))
+	assert.Empty(test, query.Get("code_verifier"))
+}
+
+func TestProviderExchange(test *testing.T) {
+	test.Run("Success", func(test *testing.T) {
+		server, provider := beforeTestProvider(test)
+		server.claims = jwt.MapClaims{
+			"preferred_username": "admin.oidc",
+			"email":              "admin@example.com",
+			"groups":             []string{"gin-template-admin", "staff"},
+		}
+
+		code := server.login(test, provider.AuthCodeURL("state", "nonce", "verifier"))
+		oidcIdentity, err := provider.Exchange(code, "nonce", "verifier")
+
+		assert.NoError(test, err)
+		assert.Equal(test, "subject", oidcIdentity.Subject)
+		assert.Equal(test, "admin.oidc", oidcIdentity.Username)
+		assert.Equal(test, "admin@example.com", oidcIdentity.Email)
+		assert.Equal(test, []string{"gin-template-admin", "staff"}, oidcIdentity.Groups)
+	})
+	test.Run("SuccessSingleGroup", func(test *testing.T) {
+		server, provider := beforeTestProvider(test)
+		server.claims = jwt.MapClaims{"groups": "gin-template-admin"}
+
+		code := server.login(test, provider.AuthCodeURL("state", "nonce", "verifier"))
+		oidcIdentity, err := provider.Exchange(code, "nonce", "verifier")
+
+		assert.NoError(test, err)
+		assert.Equal(test, []string{"gin-template-admin"}, oidcIdentity.Groups)
+	})
+	test.Run("ErrorCodeVerifier", func(test *testing.T) {
+		server, provider := beforeTestProvider(test)
+
+		code := server.login(test, provider.AuthCodeURL("state", "nonce", "verifier"))
+		_, err := provider.Exchange(code, "nonce", "another-verifier")
+
+		assert.Error(test, err)
+	})
+	test.Run("ErrorCodeReused", func(test *testing.T) {
+		server, provider := beforeTestProvider(test)
+
+		code := server.login(test, provider.AuthCodeURL("state", "nonce", "verifier"))
+		_, err := provider.Exchange(code, "nonce", "verifier")
+		assert.NoError(test, err)
+
+		_, err = provider.Exchange(code, "nonce", "verifier")
+		assert.Error(test, err)
+	})
+	test.Run("ErrorNonce", func(test *testing.T) {
+		server, provider := beforeTestProvider(test)
+
+		code := server.login(test, provider.AuthCodeURL("state", "nonce", "verifier"))
+		_, err := provider.Exchange(code, "another-nonce", "verifier")
+
+		assert.EqualError(test, err, "invalid nonce")
+	})
+	test.Run("ErrorAudience", func(test *testing.T) {
+		server, provider := beforeTestProvider(test)
+		server.claims = jwt.MapClaims{"aud": "another-client"}
+
+		code := server.login(test, provider.AuthCodeURL("state", "nonce", "verifier"))
+		_, err := provider.Exchange(code, "nonce", "verifier")
+
+		assert.Error(test, err)
+	})
+	test.Run("ErrorExpired", func(test *testing.T) {
+		server, provider := beforeTestProvider(test)
+		server.claims = jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()}
+
+		code := server.login(test, provider.AuthCodeURL("state", "nonce", "verifier"))
+		_, err := provider.Exchange(code, "nonce", "verifier")
+
+		assert.Error(test, err)
+	})
+	test.Run("ErrorSignature", func(test *testing.T) {
+		server, provider := beforeTestProvider(test)
+		signPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(test, err)
+		server.signPrivateKey = signPrivateKey
+
+		code := server.login(test, provider.AuthCodeURL("state", "nonce", "verifier"))
+		_, err = provider.Exchange(code, "nonce", "verifier")
+
+		assert.Error(test, err)
+	})
+	test.Run("ErrorGroupClaim", func(test *testing.T) {
+		server, provider := beforeTestProvider(test)
+		server.claims = jwt.MapClaims{"groups": []int{1}}
+
+		code := server.login(test, provider.AuthCodeURL("state", "nonce", "verifier"))
+		_, err := provider.Exchange(code, "nonce", "verifier")
+
+		assert.Error(test, err)
+	})
+}
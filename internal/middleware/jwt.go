@@ -1,67 +1,188 @@
 package middleware
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
-	"net/http"
-	"os"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
+	"github.com/sndzhng/gin-template/internal/common"
 	"github.com/sndzhng/gin-template/internal/config"
 	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/util"
+	"gorm.io/gorm"
 )
 
-type CustomClaims struct {
-	jwt.StandardClaims
-	Roles []entity.RoleName `json:"roles"`
-}
+const (
+	apiKeyHeader                = "X-API-Key"
+	apiKeyLastUseInterval       = time.Minute
+	impersonationExpireDuration = 15 * time.Minute
+	mfaPurpose                  = "MFA"
+	mfaTokenExpireDuration      = 5 * time.Minute
+)
 
-func Authorization(ginContext *gin.Context) {
-	tokenString := strings.TrimPrefix(ginContext.Request.Header.Get("Authorization"), "Bearer ")
+var (
+	// description: claims are missing when middleware runs before authorization
+	errorClaimsNotFound = util.NewError(common.ErrorCode.Unauthorized, "claims not found")
+	errorInvalidToken   = util.NewError(common.ErrorCode.Unauthorized, "invalid token")
+)
 
-	tokenJWT, err := jwt.ParseWithClaims(tokenString, &CustomClaims{},
-		func(tokenJWT *jwt.Token) (interface{}, error) {
-			_, ok := tokenJWT.Method.(*jwt.SigningMethodHMAC)
-			if !ok {
-				return nil, errors.New("invalid token")
+// description: nil api key repository accepts bearer token only
+func Authorization(apiKeyRepository repository.APIKey, revokedTokenRepository repository.RevokedToken) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		if key := ginContext.Request.Header.Get(apiKeyHeader); key != "" && apiKeyRepository != nil {
+			claims, err := authorizeAPIKey(ginContext.Request.Context(), apiKeyRepository, key, ginContext.Request.Method)
+			if err != nil {
+				util.HandleError(ginContext, err)
+				return
 			}
 
-			return []byte(config.JWT.Key), nil
-		})
+			ginContext.Set("claims", claims)
+			return
+		}
+
+		tokenString := strings.TrimPrefix(ginContext.Request.Header.Get("Authorization"), "Bearer ")
+
+		tokenJWT, err := jwt.ParseWithClaims(tokenString, &entity.Claims{}, verifyKey)
+		if err != nil {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.Unauthorized, err.Error()))
+			return
+		}
+
+		claims, ok := tokenJWT.Claims.(*entity.Claims)
+		if !ok || claims.Purpose != "" {
+			util.HandleError(ginContext, errorInvalidToken)
+			return
+		}
+
+		revokedToken, err := claims.RevokedToken()
+		if err != nil {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.Unauthorized, err.Error()))
+			return
+		}
+		isRevoked, err := revokedTokenRepository.IsRevoked(ginContext.Request.Context(), revokedToken, time.Unix(claims.IssuedAt, 0))
+		if err != nil {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+			return
+		} else if isRevoked {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.Unauthorized, "token is revoked"))
+			return
+		}
+
+		ginContext.Set("claims", tokenJWT.Claims)
+	}
+}
+
+// description: api key acts as its owner admin with owner roles and permissions, restricted to methods allowed by key scopes
+func authorizeAPIKey(ctx context.Context, apiKeyRepository repository.APIKey, key string, method string) (*entity.Claims, error) {
+	keyHash := HashAPIKey(key)
+	apiKey, err := apiKeyRepository.Get(ctx, entity.APIKey{KeyHash: &keyHash})
 	if err != nil {
-		ginContext.AbortWithStatus(http.StatusUnauthorized)
-		return
+		if err == gorm.ErrRecordNotFound {
+			return nil, util.NewError(common.ErrorCode.Unauthorized, "invalid api key")
+		}
+		return nil, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	if !apiKey.IsActive() || apiKey.Admin == nil || apiKey.Admin.Role == nil || apiKey.Admin.Role.Name == nil {
+		return nil, util.NewError(common.ErrorCode.Unauthorized, "inactive api key")
+	}
+	if !apiKey.IsAllowed(method) {
+		return nil, util.NewError(common.ErrorCode.Forbidden, "method is not allowed by api key scopes")
+	}
+
+	if apiKey.LastUseAt == nil || time.Since(*apiKey.LastUseAt) > apiKeyLastUseInterval {
+		err = apiKeyRepository.UpdateLastUse(ctx, apiKey)
+		if err != nil {
+			return nil, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
 	}
 
-	_, ok := tokenJWT.Claims.(*CustomClaims)
-	if !ok {
-		ginContext.AbortWithStatus(http.StatusUnauthorized)
-		return
+	claims := &entity.Claims{
+		StandardClaims: jwt.StandardClaims{Subject: strconv.FormatUint(*apiKey.AdminID, 10)},
+		APIKeyID:       apiKey.ID,
+		Permissions:    apiKey.Admin.Role.PermissionNames(),
+		Roles:          []entity.RoleName{entity.RoleName(*apiKey.Admin.Role.Name)},
+	}
+	if apiKey.Admin.IsResetPassword != nil {
+		claims.IsResetPassword = *apiKey.Admin.IsResetPassword
 	}
 
-	ginContext.Set("claims", tokenJWT.Claims)
+	return claims, nil
 }
 
-func GenerateJWT(subject uint64, roles ...entity.RoleName) (string, error) {
-	expireMinute, err := time.ParseDuration(os.Getenv("JWT_EXPIRE_MINUTE"))
+// description: keys are high entropy so unsalted sha256 is enough and allows lookup by hash
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// description: is reset password, session id and permissions are carried as claims so they are enforced without database lookup
+func GenerateJWT(subject uint64, sessionID *uint64, isResetPassword bool, roles []entity.RoleName, permissions []entity.PermissionName) (string, error) {
+	expireMinute, err := time.ParseDuration(config.JWT.ExpireMinute)
 	if err != nil {
 		return "", err
 	}
 
-	claims := &CustomClaims{
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(expireMinute).Unix(),
-			IssuedAt:  time.Now().Unix(),
-			Subject:   strconv.FormatUint(subject, 10),
-		},
-		Roles: roles,
+	claims := &entity.Claims{IsResetPassword: isResetPassword, Permissions: permissions, Roles: roles, SessionID: sessionID}
+
+	return generateJWT(subject, claims, expireMinute)
+}
+
+// description: short lived user token without session or refresh token, is reset password follows the user so admin sees what user sees
+func GenerateImpersonationJWT(subject uint64, actor uint64, isResetPassword bool) (string, error) {
+	claims := &entity.Claims{
+		Actor:           &entity.Actor{Subject: strconv.FormatUint(actor, 10)},
+		IsResetPassword: isResetPassword,
+		Roles:           []entity.RoleName{entity.UserRoleName},
+	}
+
+	return generateJWT(subject, claims, impersonationExpireDuration)
+}
+
+// description: intermediate token proving password check, only accepted by mfa endpoints
+func GenerateMFAToken(subject uint64, roles ...entity.RoleName) (string, error) {
+	claims := &entity.Claims{Purpose: mfaPurpose, Roles: roles}
+
+	return generateJWT(subject, claims, mfaTokenExpireDuration)
+}
+
+func ParseMFAToken(tokenString string) (*entity.Claims, error) {
+	tokenJWT, err := jwt.ParseWithClaims(tokenString, &entity.Claims{}, verifyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := tokenJWT.Claims.(*entity.Claims)
+	if !ok || claims.Purpose != mfaPurpose {
+		return nil, errors.New("invalid mfa token")
+	}
+
+	return claims, nil
+}
+
+func generateJWT(subject uint64, claims *entity.Claims, expireDuration time.Duration) (string, error) {
+	jtiBytes := make([]byte, 16)
+	_, err := rand.Read(jtiBytes)
+	if err != nil {
+		return "", err
 	}
-	tokenJWT := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	tokenString, err := tokenJWT.SignedString([]byte(os.Getenv("JWT_KEY")))
+	claims.StandardClaims = jwt.StandardClaims{
+		ExpiresAt: time.Now().Add(expireDuration).Unix(),
+		Id:        hex.EncodeToString(jtiBytes),
+		IssuedAt:  time.Now().Unix(),
+		Subject:   strconv.FormatUint(subject, 10),
+	}
+	tokenString, err := signJWT(claims)
 	if err != nil {
 		return "", err
 	}
@@ -71,12 +192,12 @@ func GenerateJWT(subject uint64, roles ...entity.RoleName) (string, error) {
 
 func VerifyRoles(expectedRoles ...entity.RoleName) gin.HandlerFunc {
 	return func(ginContext *gin.Context) {
-		if ginContext.Keys["claims"] == nil {
-			ginContext.AbortWithStatus(http.StatusUnauthorized)
+		claims, ok := ginContext.Keys["claims"].(*entity.Claims)
+		if !ok {
+			util.HandleError(ginContext, errorClaimsNotFound)
 			return
 		}
 
-		claims := ginContext.MustGet("claims").(*CustomClaims)
 		for _, expectedRole := range expectedRoles {
 			for _, userRole := range claims.Roles {
 				if userRole == expectedRole {
@@ -85,6 +206,125 @@ func VerifyRoles(expectedRoles ...entity.RoleName) gin.HandlerFunc {
 			}
 		}
 
-		ginContext.AbortWithStatus(http.StatusUnauthorized)
+		util.HandleError(ginContext, util.NewError(common.ErrorCode.Unauthorized, "role is not allowed"))
+	}
+}
+
+// description: admin roles are defined in database, so any account that is not a user is an admin
+func VerifyAdmin() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		claims, ok := ginContext.Keys["claims"].(*entity.Claims)
+		if !ok {
+			util.HandleError(ginContext, errorClaimsNotFound)
+			return
+		}
+
+		if len(claims.Roles) == 0 || claims.IsUser() {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.Unauthorized, "admin role is required"))
+			return
+		}
+	}
+}
+
+// description: must run after authorization, permissions are taken from the admin role when token is issued
+func RequirePermission(expectedPermission entity.PermissionName) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		claims, ok := ginContext.Keys["claims"].(*entity.Claims)
+		if !ok {
+			util.HandleError(ginContext, errorClaimsNotFound)
+			return
+		}
+
+		if !claims.HasPermission(expectedPermission) {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.Forbidden, fmt.Sprintf("%s permission is required", expectedPermission)))
+			return
+		}
+	}
+}
+
+// description: resolve owner scope of admin once for every user route, must run after authorization
+func ScopeOwner() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		err := util.SetOwnerScope(ginContext)
+		if err != nil {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.Unauthorized, err.Error()))
+			return
+		}
+	}
+}
+
+// description: reject account with temporary password until reset, must run after authorization
+func VerifyPasswordReset() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		claims, ok := ginContext.Keys["claims"].(*entity.Claims)
+		if !ok {
+			util.HandleError(ginContext, errorClaimsNotFound)
+			return
+		}
+
+		if claims.IsResetPassword {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.PasswordResetRequired, "password reset required"))
+			return
+		}
+	}
+}
+
+// description: record every impersonated request before it runs, must run after authorization
+func AuditImpersonation(auditLogRepository repository.AuditLog) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		claims, ok := ginContext.Keys["claims"].(*entity.Claims)
+		if !ok {
+			util.HandleError(ginContext, errorClaimsNotFound)
+			return
+		}
+
+		actorID, err := claims.ActorID()
+		if err != nil {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.Unauthorized, err.Error()))
+			return
+		} else if actorID == nil {
+			return
+		}
+
+		_, userID, err := claims.Account()
+		if err != nil {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.Unauthorized, err.Error()))
+			return
+		}
+
+		method := ginContext.Request.Method
+		path := ginContext.Request.URL.Path
+		requestID := ginContext.GetString("request_id")
+		ipAddress := ginContext.ClientIP()
+		userAgent := ginContext.Request.UserAgent()
+		auditLog := entity.NewAuditLog(
+			entity.Audit{ActorID: actorID, RequestID: &requestID, IPAddress: &ipAddress, UserAgent: &userAgent},
+			entity.ImpersonatedRequestAuditAction,
+			entity.UserAuditEntityType,
+			userID,
+		)
+		auditLog.Method = &method
+		auditLog.Path = &path
+		err = auditLogRepository.Create(ginContext.Request.Context(), auditLog)
+		if err != nil {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.Internal, err.Error()))
+			return
+		}
+	}
+}
+
+// description: block sensitive actions such as password reset while impersonating, must run after authorization
+func RejectImpersonation() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		claims, ok := ginContext.Keys["claims"].(*entity.Claims)
+		if !ok {
+			util.HandleError(ginContext, errorClaimsNotFound)
+			return
+		}
+
+		if claims.Actor != nil {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.ImpersonationForbidden, "action not allowed while impersonating"))
+			return
+		}
 	}
 }
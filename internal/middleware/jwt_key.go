@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+)
+
+type (
+	jwtKey struct {
+		method    jwt.SigningMethod
+		publicKey crypto.PublicKey
+	}
+	jwtSigner struct {
+		keyID      string
+		method     jwt.SigningMethod
+		privateKey interface{}
+	}
+)
+
+var (
+	hmacVerifyUntil time.Time
+	signer          *jwtSigner
+	verifyKeys      = map[string]jwtKey{}
+)
+
+// description: load signing key and verification keys, public key files are named {kid}.pem
+func InitialJWTKeys() {
+	hmacVerifyUntil, signer, verifyKeys = time.Time{}, nil, map[string]jwtKey{}
+	switch config.JWT.Algorithm {
+	case "", jwt.SigningMethodHS256.Alg():
+		return
+	}
+
+	if config.JWT.HMACVerifyUntil != "" {
+		var err error
+		hmacVerifyUntil, err = time.Parse(time.RFC3339, config.JWT.HMACVerifyUntil)
+		if err != nil {
+			log.Fatalf("Error parse JWT_HMAC_VERIFY_UNTIL: %s", err)
+		}
+	}
+
+	privateKeyPEM, err := os.ReadFile(config.JWT.PrivateKeyFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	privateKey, publicKey, err := parsePrivateKeyPEM(config.JWT.Algorithm, privateKeyPEM)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if config.JWT.KeyID == "" {
+		log.Fatal("JWT_KEY_ID is required for asymmetric signing")
+	}
+
+	signer = &jwtSigner{
+		keyID:      config.JWT.KeyID,
+		method:     jwt.GetSigningMethod(config.JWT.Algorithm),
+		privateKey: privateKey,
+	}
+	verifyKeys[config.JWT.KeyID] = jwtKey{method: signer.method, publicKey: publicKey}
+
+	if config.JWT.PublicKeyDirectory == "" {
+		return
+	}
+	paths, err := filepath.Glob(filepath.Join(config.JWT.PublicKeyDirectory, "*.pem"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, path := range paths {
+		keyID := strings.TrimSuffix(filepath.Base(path), ".pem")
+		if keyID == config.JWT.KeyID {
+			continue
+		}
+
+		publicKeyPEM, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		key, err := parsePublicKeyPEM(publicKeyPEM)
+		if err != nil {
+			log.Fatalf("Error parse public key %s: %s", path, err)
+		}
+		verifyKeys[keyID] = key
+	}
+}
+
+func GetJSONWebKeySet() entity.JSONWebKeySet {
+	jsonWebKeySet := entity.JSONWebKeySet{Keys: []entity.JSONWebKey{}}
+	for keyID, key := range verifyKeys {
+		jsonWebKey := entity.JSONWebKey{
+			Algorithm: key.method.Alg(),
+			KeyID:     keyID,
+			Use:       "sig",
+		}
+
+		switch publicKey := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jsonWebKey.KeyType = "RSA"
+			jsonWebKey.Modulus = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jsonWebKey.Exponent = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case *ecdsa.PublicKey:
+			size := (publicKey.Curve.Params().BitSize + 7) / 8
+			jsonWebKey.KeyType = "EC"
+			jsonWebKey.Curve = publicKey.Curve.Params().Name
+			jsonWebKey.X = base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, size)))
+			jsonWebKey.Y = base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, size)))
+		case ed25519.PublicKey:
+			jsonWebKey.KeyType = "OKP"
+			jsonWebKey.Curve = "Ed25519"
+			jsonWebKey.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		default:
+			continue
+		}
+
+		jsonWebKeySet.Keys = append(jsonWebKeySet.Keys, jsonWebKey)
+	}
+	sort.Slice(jsonWebKeySet.Keys, func(i, j int) bool {
+		return jsonWebKeySet.Keys[i].KeyID < jsonWebKeySet.Keys[j].KeyID
+	})
+
+	return jsonWebKeySet
+}
+
+// description: sign with asymmetric key when configured, otherwise fallback to shared secret
+func signJWT(claims jwt.Claims) (string, error) {
+	if signer == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(config.JWT.Key))
+	}
+
+	tokenJWT := jwt.NewWithClaims(signer.method, claims)
+	tokenJWT.Header["kid"] = signer.keyID
+
+	return tokenJWT.SignedString(signer.privateKey)
+}
+
+// description: pick verification key by kid, algorithm must match the key to prevent algorithm confusion,
+// shared secret is refused once asymmetric signing is configured unless still within the migration deadline
+func verifyKey(tokenJWT *jwt.Token) (interface{}, error) {
+	if _, ok := tokenJWT.Method.(*jwt.SigningMethodHMAC); ok {
+		if tokenJWT.Method != jwt.SigningMethodHS256 || config.JWT.Key == "" {
+			return nil, errors.New("invalid token")
+		}
+		if signer != nil && !time.Now().Before(hmacVerifyUntil) {
+			return nil, errors.New("invalid token")
+		}
+
+		return []byte(config.JWT.Key), nil
+	}
+
+	keyID, _ := tokenJWT.Header["kid"].(string)
+	key, isExist := verifyKeys[keyID]
+	if !isExist || key.method.Alg() != tokenJWT.Method.Alg() {
+		return nil, errors.New("invalid token")
+	}
+
+	return key.publicKey, nil
+}
+
+func parsePrivateKeyPEM(algorithm string, privateKeyPEM []byte) (interface{}, crypto.PublicKey, error) {
+	switch algorithm {
+	case jwt.SigningMethodRS256.Alg():
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return nil, nil, err
+		}
+		return privateKey, &privateKey.PublicKey, nil
+	case jwt.SigningMethodES256.Alg():
+		privateKey, err := jwt.ParseECPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return nil, nil, err
+		}
+		if privateKey.Curve != elliptic.P256() {
+			return nil, nil, errors.New("ES256 requires P-256 key")
+		}
+		return privateKey, &privateKey.PublicKey, nil
+	case jwt.SigningMethodEdDSA.Alg():
+		privateKey, err := jwt.ParseEdPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return nil, nil, err
+		}
+		return privateKey, privateKey.(ed25519.PrivateKey).Public(), nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported jwt algorithm %s", algorithm)
+	}
+}
+
+func parsePublicKeyPEM(publicKeyPEM []byte) (jwtKey, error) {
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return jwtKey{}, errors.New("invalid pem")
+	}
+
+	if publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyPEM); err == nil {
+		return jwtKey{method: jwt.SigningMethodRS256, publicKey: publicKey}, nil
+	}
+	if publicKey, err := jwt.ParseECPublicKeyFromPEM(publicKeyPEM); err == nil {
+		if publicKey.Curve != elliptic.P256() {
+			return jwtKey{}, errors.New("ES256 requires P-256 key")
+		}
+		return jwtKey{method: jwt.SigningMethodES256, publicKey: publicKey}, nil
+	}
+	if publicKey, err := jwt.ParseEdPublicKeyFromPEM(publicKeyPEM); err == nil {
+		return jwtKey{method: jwt.SigningMethodEdDSA, publicKey: publicKey}, nil
+	}
+
+	return jwtKey{}, errors.New("unsupported public key")
+}
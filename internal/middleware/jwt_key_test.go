@@ -0,0 +1,189 @@
+package middleware_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+// description: write private key of kid to directory, public key goes to public directory as {kid}.pem
+func writeTestKey(test *testing.T, directory string, keyID string, privateKey crypto.Signer) []byte {
+	privateKeyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		test.Fatal(err)
+	}
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	if err != nil {
+		test.Fatal(err)
+	}
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER})
+
+	err = os.WriteFile(filepath.Join(directory, keyID+".key"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDER}), 0600)
+	if err != nil {
+		test.Fatal(err)
+	}
+	err = os.MkdirAll(filepath.Join(directory, "public"), 0700)
+	if err != nil {
+		test.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(directory, "public", keyID+".pem"), publicKeyPEM, 0600)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	return publicKeyPEM
+}
+
+func setTestJWTConfig(test *testing.T, jwtConfig config.JWTConfig) {
+	previousJWTConfig := config.JWT
+	config.JWT = jwtConfig
+	middleware.InitialJWTKeys()
+	test.Cleanup(func() {
+		config.JWT = previousJWTConfig
+		middleware.InitialJWTKeys()
+	})
+}
+
+func signTestHS256(test *testing.T, key []byte, keyID string) string {
+	claims := &entity.Claims{Purpose: "MFA", StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Add(time.Minute).Unix(), Subject: "1"}}
+	tokenJWT := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if keyID != "" {
+		tokenJWT.Header["kid"] = keyID
+	}
+
+	tokenString, err := tokenJWT.SignedString(key)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	return tokenString
+}
+
+func TestJWTKey(test *testing.T) {
+	directory := test.TempDir()
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		test.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		test.Fatal(err)
+	}
+	writeTestKey(test, directory, "old", ed25519Key)
+	rsaPublicKeyPEM := writeTestKey(test, directory, "new", rsaKey)
+
+	test.Run("RoundTrip/HS256", func(test *testing.T) {
+		setTestJWTConfig(test, config.JWTConfig{Algorithm: jwt.SigningMethodHS256.Alg(), Key: "secret"})
+
+		tokenString, err := middleware.GenerateMFAToken(1)
+		assert.NoError(test, err)
+
+		claims, err := middleware.ParseMFAToken(tokenString)
+		assert.NoError(test, err)
+		assert.Equal(test, "1", claims.Subject)
+	})
+
+	test.Run("RoundTrip/EdDSA", func(test *testing.T) {
+		setTestJWTConfig(test, config.JWTConfig{Algorithm: jwt.SigningMethodEdDSA.Alg(), KeyID: "old", PrivateKeyFile: filepath.Join(directory, "old.key")})
+
+		tokenString, err := middleware.GenerateMFAToken(1)
+		assert.NoError(test, err)
+
+		claims, err := middleware.ParseMFAToken(tokenString)
+		assert.NoError(test, err)
+		assert.Equal(test, "1", claims.Subject)
+	})
+
+	test.Run("Rotation", func(test *testing.T) {
+		setTestJWTConfig(test, config.JWTConfig{Algorithm: jwt.SigningMethodEdDSA.Alg(), KeyID: "old", PrivateKeyFile: filepath.Join(directory, "old.key")})
+		oldTokenString, err := middleware.GenerateMFAToken(1)
+		assert.NoError(test, err)
+
+		setTestJWTConfig(test, config.JWTConfig{
+			Algorithm:          jwt.SigningMethodRS256.Alg(),
+			KeyID:              "new",
+			PrivateKeyFile:     filepath.Join(directory, "new.key"),
+			PublicKeyDirectory: filepath.Join(directory, "public"),
+		})
+		newTokenString, err := middleware.GenerateMFAToken(1)
+		assert.NoError(test, err)
+
+		_, err = middleware.ParseMFAToken(oldTokenString)
+		assert.NoError(test, err)
+		_, err = middleware.ParseMFAToken(newTokenString)
+		assert.NoError(test, err)
+
+		setTestJWTConfig(test, config.JWTConfig{Algorithm: jwt.SigningMethodRS256.Alg(), KeyID: "new", PrivateKeyFile: filepath.Join(directory, "new.key")})
+		_, err = middleware.ParseMFAToken(oldTokenString)
+		assert.Error(test, err)
+	})
+
+	test.Run("AlgorithmConfusion", func(test *testing.T) {
+		setTestJWTConfig(test, config.JWTConfig{Algorithm: jwt.SigningMethodRS256.Alg(), Key: "secret", KeyID: "new", PrivateKeyFile: filepath.Join(directory, "new.key")})
+
+		_, err := middleware.ParseMFAToken(signTestHS256(test, rsaPublicKeyPEM, "new"))
+		assert.Error(test, err)
+	})
+
+	test.Run("HMACMigration/Active", func(test *testing.T) {
+		setTestJWTConfig(test, config.JWTConfig{
+			Algorithm:       jwt.SigningMethodRS256.Alg(),
+			HMACVerifyUntil: time.Now().Add(time.Hour).Format(time.RFC3339),
+			Key:             "secret",
+			KeyID:           "new",
+			PrivateKeyFile:  filepath.Join(directory, "new.key"),
+		})
+
+		_, err := middleware.ParseMFAToken(signTestHS256(test, []byte("secret"), ""))
+		assert.NoError(test, err)
+		_, err = middleware.ParseMFAToken(signTestHS256(test, rsaPublicKeyPEM, "new"))
+		assert.Error(test, err)
+	})
+
+	test.Run("HMACMigration/Expired", func(test *testing.T) {
+		setTestJWTConfig(test, config.JWTConfig{
+			Algorithm:       jwt.SigningMethodRS256.Alg(),
+			HMACVerifyUntil: time.Now().Add(-time.Hour).Format(time.RFC3339),
+			Key:             "secret",
+			KeyID:           "new",
+			PrivateKeyFile:  filepath.Join(directory, "new.key"),
+		})
+
+		_, err := middleware.ParseMFAToken(signTestHS256(test, []byte("secret"), ""))
+		assert.Error(test, err)
+	})
+
+	test.Run("JSONWebKeySet", func(test *testing.T) {
+		setTestJWTConfig(test, config.JWTConfig{
+			Algorithm:          jwt.SigningMethodRS256.Alg(),
+			KeyID:              "new",
+			PrivateKeyFile:     filepath.Join(directory, "new.key"),
+			PublicKeyDirectory: filepath.Join(directory, "public"),
+		})
+
+		jsonWebKeySet := middleware.GetJSONWebKeySet()
+		assert.Len(test, jsonWebKeySet.Keys, 2)
+		assert.Equal(test, "new", jsonWebKeySet.Keys[0].KeyID)
+		assert.Equal(test, "RSA", jsonWebKeySet.Keys[0].KeyType)
+		assert.Equal(test, "RS256", jsonWebKeySet.Keys[0].Algorithm)
+		assert.Equal(test, "AQAB", jsonWebKeySet.Keys[0].Exponent)
+		assert.NotEmpty(test, jsonWebKeySet.Keys[0].Modulus)
+		assert.Equal(test, "old", jsonWebKeySet.Keys[1].KeyID)
+		assert.Equal(test, "OKP", jsonWebKeySet.Keys[1].KeyType)
+		assert.Equal(test, "Ed25519", jsonWebKeySet.Keys[1].Curve)
+		assert.Equal(test, "EdDSA", jsonWebKeySet.Keys[1].Algorithm)
+	})
+}
@@ -0,0 +1,302 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/sndzhng/gin-template/internal/util"
+	repositorymock "github.com/sndzhng/gin-template/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func beforeTestAuthorization(test *testing.T) (
+	*repositorymock.MockAPIKey,
+	*repositorymock.MockRevokedToken,
+	*gin.Engine,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	setTestJWTConfig(test, config.JWTConfig{Algorithm: jwt.SigningMethodHS256.Alg(), ExpireMinute: "1m", Key: "secret"})
+
+	mockAPIKeyRepository := repositorymock.NewMockAPIKey(controller)
+	mockRevokedTokenRepository := repositorymock.NewMockRevokedToken(controller)
+	router := gin.New()
+	router.Use(middleware.Authorization(mockAPIKeyRepository, mockRevokedTokenRepository))
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		router.Handle(method, "/resource", func(ginContext *gin.Context) {
+			claims, err := util.GetClaims(ginContext)
+			assert.NoError(test, err)
+			ginContext.String(http.StatusOK, claims.Subject)
+		})
+	}
+
+	return mockAPIKeyRepository, mockRevokedTokenRepository, router
+}
+
+// description: claims set by a preceding handler stand in for authorization, nil claims leave them unset
+func newClaimsRouter(claims *entity.Claims, handlers ...gin.HandlerFunc) *gin.Engine {
+	router := gin.New()
+	router.Use(func(ginContext *gin.Context) {
+		if claims != nil {
+			ginContext.Set("claims", claims)
+		}
+	})
+	router.GET("/resource", handlers...)
+
+	return router
+}
+
+func TestAuthorization(test *testing.T) {
+	mockAPIKeyRepository, mockRevokedTokenRepository, router := beforeTestAuthorization(test)
+
+	id := uint64(1)
+	sessionID := uint64(2)
+	key := "gtk_00000000_secret"
+	roleName := string(entity.AdminRoleName)
+	recentUseAt := time.Now()
+	revokeAt := time.Now().Add(-time.Minute)
+	accessToken, err := middleware.GenerateJWT(id, &sessionID, false, []entity.RoleName{entity.AdminRoleName}, []entity.PermissionName{entity.UserReadPermissionName})
+	assert.NoError(test, err)
+	mfaToken, err := middleware.GenerateMFAToken(id, entity.AdminRoleName)
+	assert.NoError(test, err)
+	newAPIKey := func(scope entity.APIKeyScope) entity.APIKey {
+		return entity.APIKey{
+			ID:      &id,
+			AdminID: &id,
+			Admin:   &entity.Admin{ID: &id, Role: &entity.Role{Name: &roleName}},
+			Scopes:  []entity.APIKeyScope{scope},
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		method       string
+		token        string
+		apiKey       string
+		expect       func()
+		expectedCode int
+	}{
+		{
+			name:   "Success",
+			method: http.MethodGet,
+			token:  accessToken,
+			expect: func() {
+				mockRevokedTokenRepository.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, revokedToken entity.RevokedToken, issueAt time.Time) (bool, error) {
+						assert.Equal(test, sessionID, *revokedToken.SessionID)
+						assert.Equal(test, id, *revokedToken.AdminID)
+						return false, nil
+					},
+				)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "Unauthorized/Revoked",
+			method: http.MethodGet,
+			token:  accessToken,
+			expect: func() {
+				mockRevokedTokenRepository.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "InternalError/Revoked",
+			method: http.MethodGet,
+			token:  accessToken,
+			expect: func() {
+				mockRevokedTokenRepository.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, errors.New("internal error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:         "Unauthorized/MFAPurpose",
+			method:       http.MethodGet,
+			token:        mfaToken,
+			expect:       func() {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "Unauthorized/InvalidToken",
+			method:       http.MethodGet,
+			token:        "invalid",
+			expect:       func() {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "Success/APIKey",
+			method: http.MethodGet,
+			apiKey: key,
+			expect: func() {
+				keyHash := middleware.HashAPIKey(key)
+				mockAPIKeyRepository.EXPECT().Get(gomock.Any(), entity.APIKey{KeyHash: &keyHash}).Return(newAPIKey(entity.ReadAPIKeyScope), nil)
+				mockAPIKeyRepository.EXPECT().UpdateLastUse(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "Success/APIKeyRecentlyUsed",
+			method: http.MethodPost,
+			apiKey: key,
+			expect: func() {
+				apiKey := newAPIKey(entity.WriteAPIKeyScope)
+				apiKey.LastUseAt = &recentUseAt
+				mockAPIKeyRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(apiKey, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "Forbidden/APIKeyMethod",
+			method: http.MethodPost,
+			apiKey: key,
+			expect: func() {
+				mockAPIKeyRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(newAPIKey(entity.ReadAPIKeyScope), nil)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:   "Unauthorized/APIKeyRevoked",
+			method: http.MethodGet,
+			apiKey: key,
+			expect: func() {
+				apiKey := newAPIKey(entity.WriteAPIKeyScope)
+				apiKey.RevokeAt = &revokeAt
+				mockAPIKeyRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(apiKey, nil)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "Unauthorized/APIKeyNotFound",
+			method: http.MethodGet,
+			apiKey: key,
+			expect: func() {
+				mockAPIKeyRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.APIKey{}, gorm.ErrRecordNotFound)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			testCase.expect()
+
+			request := httptest.NewRequest(testCase.method, "/resource", nil)
+			if testCase.token != "" {
+				request.Header.Set("Authorization", "Bearer "+testCase.token)
+			}
+			if testCase.apiKey != "" {
+				request.Header.Set("X-API-Key", testCase.apiKey)
+			}
+			response := httptest.NewRecorder()
+
+			router.ServeHTTP(response, request)
+
+			assert.Equal(test, testCase.expectedCode, response.Code)
+			if testCase.expectedCode == http.StatusOK {
+				assert.Equal(test, "1", response.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequirePermission(test *testing.T) {
+	testCases := []struct {
+		name         string
+		claims       *entity.Claims
+		expectedCode int
+	}{
+		{
+			name:         "Success",
+			claims:       &entity.Claims{Permissions: []entity.PermissionName{entity.UserReadPermissionName}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Forbidden",
+			claims:       &entity.Claims{Permissions: []entity.PermissionName{entity.AuditReadPermissionName}},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "Unauthorized/ClaimsNotFound",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			router := newClaimsRouter(testCase.claims, middleware.RequirePermission(entity.UserReadPermissionName), func(ginContext *gin.Context) {
+				ginContext.Status(http.StatusOK)
+			})
+
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/resource", nil))
+
+			assert.Equal(test, testCase.expectedCode, response.Code)
+		})
+	}
+}
+
+func TestScopeOwner(test *testing.T) {
+	id := uint64(1)
+
+	testCases := []struct {
+		name               string
+		claims             *entity.Claims
+		expectedOwnerScope util.OwnerScope
+		expectedCode       int
+	}{
+		{
+			name:               "Success/SuperAdmin",
+			claims:             &entity.Claims{Roles: []entity.RoleName{entity.SuperAdminRoleName}, StandardClaims: jwt.StandardClaims{Subject: "1"}},
+			expectedOwnerScope: util.OwnerScope{IsAll: true},
+			expectedCode:       http.StatusOK,
+		},
+		{
+			name:               "Success/Admin",
+			claims:             &entity.Claims{Roles: []entity.RoleName{entity.AdminRoleName}, StandardClaims: jwt.StandardClaims{Subject: "1"}},
+			expectedOwnerScope: util.OwnerScope{AdminID: &id},
+			expectedCode:       http.StatusOK,
+		},
+		{
+			name:               "Success/User",
+			claims:             &entity.Claims{Roles: []entity.RoleName{entity.UserRoleName}, StandardClaims: jwt.StandardClaims{Subject: "1"}},
+			expectedOwnerScope: util.OwnerScope{UserID: &id},
+			expectedCode:       http.StatusOK,
+		},
+		{
+			name:         "Unauthorized/InvalidSubject",
+			claims:       &entity.Claims{Roles: []entity.RoleName{entity.AdminRoleName}, StandardClaims: jwt.StandardClaims{Subject: "admin"}},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "Unauthorized/ClaimsNotFound",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			router := newClaimsRouter(testCase.claims, middleware.ScopeOwner(), func(ginContext *gin.Context) {
+				ownerScope, ok := util.GetOwnerScope(ginContext.Request.Context())
+				assert.True(test, ok)
+				assert.Equal(test, testCase.expectedOwnerScope, ownerScope)
+				ginContext.Status(http.StatusOK)
+			})
+
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/resource", nil))
+
+			assert.Equal(test, testCase.expectedCode, response.Code)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+type rateLimitCounter struct {
+	count   int
+	resetAt time.Time
+}
+
+// description: fixed window rate limit per client ip and route, counters live in memory of each instance
+func RateLimit(limit int, window time.Duration) gin.HandlerFunc {
+	mutex := sync.Mutex{}
+	counters := map[string]*rateLimitCounter{}
+	sweepAt := time.Now().Add(window)
+
+	return func(ginContext *gin.Context) {
+		currentTime := time.Now()
+		key := ginContext.ClientIP() + " " + ginContext.FullPath()
+
+		mutex.Lock()
+		if currentTime.After(sweepAt) {
+			for counterKey, counter := range counters {
+				if currentTime.After(counter.resetAt) {
+					delete(counters, counterKey)
+				}
+			}
+			sweepAt = currentTime.Add(window)
+		}
+
+		counter, isExist := counters[key]
+		if !isExist || currentTime.After(counter.resetAt) {
+			counter = &rateLimitCounter{resetAt: currentTime.Add(window)}
+			counters[key] = counter
+		}
+		counter.count++
+		count, resetAt := counter.count, counter.resetAt
+		mutex.Unlock()
+
+		if count > limit {
+			ginContext.Header("Retry-After", strconv.Itoa(int(resetAt.Sub(currentTime).Seconds())+1))
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.RateLimited, "rate limit exceeded"))
+			return
+		}
+
+		ginContext.Next()
+	}
+}
@@ -0,0 +1,91 @@
+package middleware_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(test *testing.T) {
+	path := "/auth/login"
+	otherPath := "/auth/refresh"
+
+	testCases := []struct {
+		name           string
+		trustedProxies []string
+		forwardedFors  []string
+		paths          []string
+		expectedCodes  []int
+	}{
+		{
+			name:          "Success",
+			forwardedFors: []string{"", ""},
+			expectedCodes: []int{http.StatusOK, http.StatusOK},
+		},
+		{
+			name:          "TooManyRequests",
+			forwardedFors: []string{"", "", ""},
+			expectedCodes: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:          "TooManyRequests/UntrustedForwardedFor",
+			forwardedFors: []string{"198.51.100.1", "198.51.100.2", "198.51.100.3"},
+			expectedCodes: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:          "Success/PerRoute",
+			forwardedFors: []string{"", "", ""},
+			paths:         []string{path, path, otherPath},
+			expectedCodes: []int{http.StatusOK, http.StatusOK, http.StatusOK},
+		},
+		{
+			name:           "Success/TrustedForwardedFor",
+			trustedProxies: []string{"192.0.2.0/24"},
+			forwardedFors:  []string{"198.51.100.1", "198.51.100.2", "198.51.100.3"},
+			expectedCodes:  []int{http.StatusOK, http.StatusOK, http.StatusOK},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			router := gin.New()
+			err := router.SetTrustedProxies(testCase.trustedProxies)
+			if err != nil {
+				test.Fatal(err)
+			}
+			router.Use(middleware.RateLimit(2, time.Minute))
+			for _, routePath := range []string{path, otherPath} {
+				router.POST(routePath, func(ginContext *gin.Context) {
+					ginContext.Status(http.StatusOK)
+				})
+			}
+
+			for index, forwardedFor := range testCase.forwardedFors {
+				requestPath := path
+				if testCase.paths != nil {
+					requestPath = testCase.paths[index]
+				}
+				request := httptest.NewRequest(http.MethodPost, requestPath, nil)
+				if forwardedFor != "" {
+					request.Header.Set("X-Forwarded-For", forwardedFor)
+				}
+				response := httptest.NewRecorder()
+
+				router.ServeHTTP(response, request)
+
+				assert.Equal(test, testCase.expectedCodes[index], response.Code, fmt.Sprint("request ", index))
+				if response.Code == http.StatusTooManyRequests {
+					assert.NotEmpty(test, response.Header().Get("Retry-After"))
+					assert.Contains(test, response.Body.String(), common.ErrorCode.RateLimited)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+var requestIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
+
+// description: keep request id from upstream proxy when well formed, otherwise generate one, echoed in response header
+func RequestID() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		requestID := ginContext.Request.Header.Get(RequestIDHeader)
+		if !requestIDRegexp.MatchString(requestID) {
+			requestIDBytes := make([]byte, 16)
+			_, _ = rand.Read(requestIDBytes)
+			requestID = hex.EncodeToString(requestIDBytes)
+		}
+
+		ginContext.Set("request_id", requestID)
+		ginContext.Writer.Header().Set(RequestIDHeader, requestID)
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+const setupTokenHeader = "X-Setup-Token"
+
+// description: guard bootstrap with token from config, empty token disables the route so bootstrap is only done by command
+func SetupToken() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		if config.Bootstrap.SetupToken == "" {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.NotFound, "setup token is not configured"))
+			return
+		}
+
+		setupToken := ginContext.GetHeader(setupTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(setupToken), []byte(config.Bootstrap.SetupToken)) != 1 {
+			util.HandleError(ginContext, util.NewError(common.ErrorCode.Unauthorized, "invalid setup token"))
+			return
+		}
+
+		ginContext.Next()
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// description: deadline on request context so queries of a slow request are cancelled, route timeout overrides default and empty or zero timeout disables it
+func Timeout(defaultTimeout string, routeTimeouts string) gin.HandlerFunc {
+	timeout := time.Duration(0)
+	if defaultTimeout != "" {
+		parsedTimeout, err := time.ParseDuration(defaultTimeout)
+		if err != nil {
+			log.Fatalf("Error parsing timeout %s", err)
+		}
+		timeout = parsedTimeout
+	}
+
+	timeouts, err := parseRouteTimeouts(routeTimeouts)
+	if err != nil {
+		log.Fatalf("Error parsing route timeout %s", err)
+	}
+
+	return func(ginContext *gin.Context) {
+		routeTimeout, isExist := timeouts[fmt.Sprintf("%s %s", ginContext.Request.Method, ginContext.FullPath())]
+		if !isExist {
+			routeTimeout = timeout
+		}
+		if routeTimeout <= 0 {
+			ginContext.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(ginContext.Request.Context(), routeTimeout)
+		defer cancel()
+
+		ginContext.Request = ginContext.Request.WithContext(ctx)
+		ginContext.Next()
+	}
+}
+
+// description: comma separated list of `METHOD full path=duration`, full path is the registered route e.g. `GET /admin/api/audit=30s`
+func parseRouteTimeouts(routeTimeouts string) (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+	for _, routeTimeout := range strings.Split(routeTimeouts, ",") {
+		routeTimeout = strings.TrimSpace(routeTimeout)
+		if routeTimeout == "" {
+			continue
+		}
+
+		separatorIndex := strings.LastIndex(routeTimeout, "=")
+		if separatorIndex < 0 {
+			return map[string]time.Duration{}, fmt.Errorf("missing duration of %s", routeTimeout)
+		}
+
+		timeout, err := time.ParseDuration(strings.TrimSpace(routeTimeout[separatorIndex+1:]))
+		if err != nil {
+			return map[string]time.Duration{}, err
+		}
+		timeouts[strings.Join(strings.Fields(routeTimeout[:separatorIndex]), " ")] = timeout
+	}
+
+	return timeouts, nil
+}
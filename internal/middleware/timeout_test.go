@@ -0,0 +1,71 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/sndzhng/gin-template/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeout(test *testing.T) {
+	path := "/resource"
+
+	testCases := []struct {
+		name           string
+		defaultTimeout string
+		routeTimeouts  string
+		expectedCode   int
+	}{
+		{
+			name:         "Success/Disabled",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:           "Success/WithinTimeout",
+			defaultTimeout: "1m",
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:           "Success/RouteDisabled",
+			defaultTimeout: "1ms",
+			routeTimeouts:  "GET /resource=0s",
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:           "ServiceUnavailable",
+			defaultTimeout: "1ms",
+			expectedCode:   http.StatusServiceUnavailable,
+		},
+		{
+			name:           "ServiceUnavailable/Route",
+			defaultTimeout: "1m",
+			routeTimeouts:  "POST /resource=1m, GET /resource=1ms",
+			expectedCode:   http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			router := gin.New()
+			router.GET(path, middleware.Timeout(testCase.defaultTimeout, testCase.routeTimeouts), func(ginContext *gin.Context) {
+				ctx := ginContext.Request.Context()
+				select {
+				case <-ctx.Done():
+					util.HandleError(ginContext, ctx.Err())
+				case <-time.After(50 * time.Millisecond):
+					ginContext.Status(http.StatusOK)
+				}
+			})
+
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(test, testCase.expectedCode, response.Code)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+)
+
+//go:generate mockgen -package=notifiermock -destination=../../mock/notifier/notifier.go . Notifier
+
+const (
+	consoleDriver = "console"
+	fileDriver    = "file"
+	smtpDriver    = "smtp"
+)
+
+type Notifier interface {
+	Send(notification entity.Notification) error
+}
+
+// description: console driver is the default so local environment works without mail server
+func NewNotifier() Notifier {
+	switch config.Notifier.Driver {
+	case smtpDriver:
+		return NewSMTPNotifier(config.Notifier.SMTP)
+	case fileDriver:
+		return NewWriterNotifier(config.Notifier.File)
+	default:
+		return NewWriterNotifier("")
+	}
+}
@@ -0,0 +1,52 @@
+package notifier
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+)
+
+type smtpNotifier struct {
+	smtpConfig config.SMTPConfig
+}
+
+func NewSMTPNotifier(smtpConfig config.SMTPConfig) Notifier {
+	return &smtpNotifier{smtpConfig: smtpConfig}
+}
+
+// description: only email channel is delivered, authentication is skipped when username is empty for local mail catcher
+func (notifier *smtpNotifier) Send(notification entity.Notification) error {
+	if notification.Channel != entity.EmailNotificationChannel {
+		return fmt.Errorf("smtp notifier does not support %s channel", notification.Channel)
+	}
+
+	auth := smtp.Auth(nil)
+	if notifier.smtpConfig.Username != "" {
+		auth = smtp.PlainAuth("", notifier.smtpConfig.Username, notifier.smtpConfig.Password, notifier.smtpConfig.Host)
+	}
+
+	message := strings.Join(
+		[]string{
+			fmt.Sprintf("From: %s", notifier.smtpConfig.From),
+			fmt.Sprintf("To: %s", notification.Recipient),
+			fmt.Sprintf("Subject: %s", notification.Subject),
+			"MIME-Version: 1.0",
+			"Content-Type: text/plain; charset=UTF-8",
+			"",
+			notification.Body,
+		},
+		"\r\n",
+	)
+
+	return smtp.SendMail(
+		net.JoinHostPort(notifier.smtpConfig.Host, notifier.smtpConfig.Port),
+		auth,
+		notifier.smtpConfig.From,
+		[]string{notification.Recipient},
+		[]byte(message),
+	)
+}
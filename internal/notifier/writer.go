@@ -0,0 +1,48 @@
+package notifier
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+)
+
+type writerNotifier struct {
+	mutex sync.Mutex
+	path  string
+}
+
+// description: write notification to file at path, or standard output when path is empty
+func NewWriterNotifier(path string) Notifier {
+	return &writerNotifier{path: path}
+}
+
+func (notifier *writerNotifier) Send(notification entity.Notification) error {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	writer := io.Writer(os.Stdout)
+	if notifier.path != "" {
+		file, err := os.OpenFile(notifier.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	_, err := fmt.Fprintf(
+		writer,
+		"[%s] %s to %s\nSubject: %s\n%s\n\n",
+		time.Now().Format(time.RFC3339),
+		notification.Channel,
+		notification.Recipient,
+		notification.Subject,
+		notification.Body,
+	)
+
+	return err
+}
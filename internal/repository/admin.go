@@ -1,22 +1,33 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/sndzhng/gin-template/internal/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //go:generate mockgen -package=repositorymock -destination=../../mock/repository/admin.go . Admin
 
 type (
 	Admin interface {
-		Create(admin entity.Admin) error
-		Delete(admin entity.Admin) error
-		Get(admin entity.Admin) (entity.Admin, error)
-		GetAll(adminFilter *entity.AdminFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.Admin, error)
-		Update(admin entity.Admin) error
+		Create(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error
+		CreateFirst(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) (bool, error)
+		Delete(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error
+		Get(ctx context.Context, admin entity.Admin) (entity.Admin, error)
+		GetAll(ctx context.Context, adminFilter *entity.AdminFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.Admin, error)
+		GetDeleted(ctx context.Context, admin entity.Admin) (entity.Admin, error)
+		IncreaseFailedLogin(ctx context.Context, admin entity.Admin) (int, error)
+		Purge(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error
+		Restore(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error
+		Update(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error
+		UpdateLock(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error
+		UpdateMFA(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error
+		UpdateMFAStep(ctx context.Context, admin entity.Admin) error
 	}
 	adminRepository struct {
 		postgresql *gorm.DB
@@ -27,8 +38,15 @@ func NewAdminRepository(postgresql *gorm.DB) Admin {
 	return &adminRepository{postgresql: postgresql}
 }
 
-func (repository *adminRepository) Create(admin entity.Admin) error {
-	err := repository.postgresql.Create(&admin).Error
+func (repository *adminRepository) Create(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := transaction.Create(&admin).Error
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, admin.ID, auditLogs)
+	})
 	if err != nil {
 		return err
 	}
@@ -36,8 +54,57 @@ func (repository *adminRepository) Create(admin entity.Admin) error {
 	return nil
 }
 
-func (repository *adminRepository) Delete(admin entity.Admin) error {
-	err := repository.postgresql.Delete(&admin).Error
+// description: table is locked so concurrent callers cannot both see no admin, false when any admin exists including soft deleted one
+func (repository *adminRepository) CreateFirst(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) (bool, error) {
+	isCreated := false
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := transaction.Exec("LOCK TABLE admins IN SHARE ROW EXCLUSIVE MODE").Error
+		if err != nil {
+			return err
+		}
+
+		count := int64(0)
+		err = transaction.Unscoped().Model(&entity.Admin{}).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		err = transaction.Create(&admin).Error
+		if err != nil {
+			return err
+		}
+		isCreated = true
+
+		return createAuditLogs(transaction, admin.ID, auditLogs)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return isCreated, nil
+}
+
+func (repository *adminRepository) Delete(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		connection := transaction
+		if admin.Version != nil {
+			connection = connection.Where("version = ?", *admin.Version)
+			admin.Version = nil
+		}
+
+		result := connection.Delete(&admin)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return createAuditLogs(transaction, admin.ID, auditLogs)
+	})
 	if err != nil {
 		return err
 	}
@@ -45,8 +112,9 @@ func (repository *adminRepository) Delete(admin entity.Admin) error {
 	return nil
 }
 
-func (repository *adminRepository) Get(admin entity.Admin) (entity.Admin, error) {
-	err := repository.postgresql.Joins("Role").First(&admin, admin).Error
+// description: role permissions are preloaded so login can embed them in claims
+func (repository *adminRepository) Get(ctx context.Context, admin entity.Admin) (entity.Admin, error) {
+	err := bindTransaction(ctx, repository.postgresql).Joins("Role").Preload("Role.Permissions").First(&admin, admin).Error
 	if err != nil {
 		return entity.Admin{}, err
 	}
@@ -54,8 +122,8 @@ func (repository *adminRepository) Get(admin entity.Admin) (entity.Admin, error)
 	return admin, nil
 }
 
-func (repository *adminRepository) GetAll(adminFilter *entity.AdminFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.Admin, error) {
-	connection := repository.postgresql
+func (repository *adminRepository) GetAll(ctx context.Context, adminFilter *entity.AdminFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.Admin, error) {
+	connection := scopeDeleted(bindTransaction(ctx, repository.postgresql), "admins", adminFilter.DeletedFilter)
 
 	if adminFilter.CreateAtAfter != nil {
 		connection = connection.Where("admins.create_at > ?", *adminFilter.CreateAtAfter)
@@ -63,6 +131,13 @@ func (repository *adminRepository) GetAll(adminFilter *entity.AdminFilter, sortO
 	if adminFilter.CreateAtBefore != nil {
 		connection = connection.Where("admins.create_at < ?", *adminFilter.CreateAtBefore)
 	}
+	if adminFilter.IsLocked != nil {
+		if *adminFilter.IsLocked {
+			connection = connection.Where("admins.lock_until > ?", time.Now())
+		} else {
+			connection = connection.Where("admins.lock_until IS NULL OR admins.lock_until <= ?", time.Now())
+		}
+	}
 
 	if pagination != nil {
 		pagination.RecordCount = new(int64)
@@ -87,8 +162,177 @@ func (repository *adminRepository) GetAll(adminFilter *entity.AdminFilter, sortO
 	return admins, nil
 }
 
-func (repository *adminRepository) Update(admin entity.Admin) error {
-	err := repository.postgresql.Updates(&admin).Error
+func (repository *adminRepository) GetDeleted(ctx context.Context, admin entity.Admin) (entity.Admin, error) {
+	err := bindTransaction(ctx, repository.postgresql).Unscoped().Joins("Role").Where("admins.delete_at IS NOT NULL").First(&admin, admin).Error
+	if err != nil {
+		return entity.Admin{}, err
+	}
+
+	return admin, nil
+}
+
+// description: only soft deleted admin is purged, dependent rows are removed in the same transaction
+func (repository *adminRepository) Purge(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := purgeDependents(
+			transaction,
+			"admin_id",
+			admin.ID,
+			&entity.APIKey{},
+			&entity.PasswordHistory{},
+			&entity.RecoveryCode{},
+			&entity.RefreshToken{},
+			&entity.Session{},
+		)
+		if err != nil {
+			return err
+		}
+
+		result := transaction.Unscoped().Where("delete_at IS NOT NULL").Delete(&entity.Admin{ID: admin.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return createAuditLogs(transaction, admin.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *adminRepository) Restore(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		result := transaction.
+			Unscoped().
+			Model(&entity.Admin{ID: admin.ID}).
+			Where("delete_at IS NOT NULL").
+			Updates(map[string]interface{}{"delete_at": nil, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return createAuditLogs(transaction, admin.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *adminRepository) Update(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := bumpVersion(transaction, &entity.Admin{ID: admin.ID}, admin.Version)
+		if err != nil {
+			return err
+		}
+		admin.Version = nil
+
+		err = transaction.Omit(clause.Associations).Updates(&admin).Error
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, admin.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// description: increase in one statement so concurrent failures are all counted, returns the increased count
+func (repository *adminRepository) IncreaseFailedLogin(ctx context.Context, admin entity.Admin) (int, error) {
+	updatedAdmin := entity.Admin{ID: admin.ID}
+	result := bindTransaction(ctx, repository.postgresql).
+		Model(&updatedAdmin).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "failed_login"}}}).
+		Updates(map[string]interface{}{"failed_login": gorm.Expr("failed_login + 1"), "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 || updatedAdmin.FailedLogin == nil {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	return *updatedAdmin.FailedLogin, nil
+}
+
+// description: update lock fields including zero value to reset failed login and unlock
+func (repository *adminRepository) UpdateLock(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := transaction.
+			Model(&entity.Admin{ID: admin.ID}).
+			Select("failed_login", "lock_until").
+			Updates(&admin).Error
+		if err != nil {
+			return err
+		}
+
+		err = bumpVersion(transaction, &entity.Admin{ID: admin.ID}, nil)
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, admin.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// description: update mfa fields including zero value to disable mfa
+func (repository *adminRepository) UpdateMFA(ctx context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := transaction.
+			Model(&entity.Admin{ID: admin.ID}).
+			Select("mfa_secret", "is_mfa_enabled").
+			Updates(&admin).Error
+		if err != nil {
+			return err
+		}
+
+		err = bumpVersion(transaction, &entity.Admin{ID: admin.ID}, nil)
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, admin.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// description: record not found when step is not after the last accepted one, so concurrent replay of a code fails
+func (repository *adminRepository) UpdateMFAStep(ctx context.Context, admin entity.Admin) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		result := transaction.
+			Model(&entity.Admin{ID: admin.ID}).
+			Where("mfa_last_step IS NULL OR mfa_last_step < ?", *admin.MFALastStep).
+			Update("mfa_last_step", *admin.MFALastStep)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return bumpVersion(transaction, &entity.Admin{ID: admin.ID}, nil)
+	})
 	if err != nil {
 		return err
 	}
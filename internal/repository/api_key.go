@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=repositorymock -destination=../../mock/repository/api_key.go . APIKey
+
+type (
+	APIKey interface {
+		Create(ctx context.Context, apiKey entity.APIKey, auditLogs ...entity.AuditLog) error
+		Get(ctx context.Context, apiKey entity.APIKey) (entity.APIKey, error)
+		GetAll(ctx context.Context, apiKeyFilter *entity.APIKeyFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.APIKey, error)
+		Revoke(ctx context.Context, apiKey entity.APIKey, auditLogs ...entity.AuditLog) error
+		UpdateLastUse(ctx context.Context, apiKey entity.APIKey) error
+	}
+	apiKeyRepository struct {
+		postgresql *gorm.DB
+	}
+)
+
+func NewAPIKeyRepository(postgresql *gorm.DB) APIKey {
+	return &apiKeyRepository{postgresql: postgresql}
+}
+
+func (repository *apiKeyRepository) Create(ctx context.Context, apiKey entity.APIKey, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := transaction.Create(&apiKey).Error
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, apiKey.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// description: preload owner with role and permissions, owner is nil when admin was deleted
+func (repository *apiKeyRepository) Get(ctx context.Context, apiKey entity.APIKey) (entity.APIKey, error) {
+	err := bindTransaction(ctx, repository.postgresql).Preload("Admin.Role.Permissions").Where(&apiKey).First(&apiKey).Error
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	return apiKey, nil
+}
+
+func (repository *apiKeyRepository) GetAll(ctx context.Context, apiKeyFilter *entity.APIKeyFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.APIKey, error) {
+	connection := bindTransaction(ctx, repository.postgresql)
+
+	if apiKeyFilter.IsActive != nil {
+		if *apiKeyFilter.IsActive {
+			connection = connection.Where("api_keys.revoke_at IS NULL AND (api_keys.expire_at IS NULL OR api_keys.expire_at > ?)", time.Now())
+		} else {
+			connection = connection.Where("api_keys.revoke_at IS NOT NULL OR api_keys.expire_at <= ?", time.Now())
+		}
+	}
+
+	if pagination != nil {
+		pagination.RecordCount = new(int64)
+		err := connection.Model(&entity.APIKey{}).Where(&apiKeyFilter.APIKey).Count(pagination.RecordCount).Error
+		if err != nil {
+			return []entity.APIKey{}, err
+		}
+
+		connection = connection.Limit(pagination.Limit).Offset(pagination.Offset)
+	}
+
+	if sortOrder != nil {
+		connection = connection.Order(fmt.Sprintf("%s %s", sortOrder.Sort, strings.ToUpper(sortOrder.Order)))
+	}
+
+	apiKeys := []entity.APIKey{}
+	err := connection.Where(&apiKeyFilter.APIKey).Find(&apiKeys).Error
+	if err != nil {
+		return []entity.APIKey{}, err
+	}
+
+	return apiKeys, nil
+}
+
+// description: record not found means the key does not exist or was already revoked
+func (repository *apiKeyRepository) Revoke(ctx context.Context, apiKey entity.APIKey, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		result := transaction.
+			Model(&entity.APIKey{}).
+			Where("revoke_at IS NULL").
+			Where(&apiKey).
+			Update("revoke_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return createAuditLogs(transaction, apiKey.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *apiKeyRepository) UpdateLastUse(ctx context.Context, apiKey entity.APIKey) error {
+	err := bindTransaction(ctx, repository.postgresql).
+		Model(&entity.APIKey{ID: apiKey.ID}).
+		Update("last_use_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=repositorymock -destination=../../mock/repository/audit_log.go . AuditLog
+
+type (
+	AuditLog interface {
+		Create(ctx context.Context, auditLog entity.AuditLog) error
+		GetAll(ctx context.Context, auditLogFilter *entity.AuditLogFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.AuditLog, error)
+	}
+	auditLogRepository struct {
+		postgresql *gorm.DB
+	}
+)
+
+func NewAuditLogRepository(postgresql *gorm.DB) AuditLog {
+	return &auditLogRepository{postgresql: postgresql}
+}
+
+func (repository *auditLogRepository) Create(ctx context.Context, auditLog entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Create(&auditLog).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *auditLogRepository) GetAll(ctx context.Context, auditLogFilter *entity.AuditLogFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.AuditLog, error) {
+	connection := bindTransaction(ctx, repository.postgresql)
+
+	if auditLogFilter.CreateAtAfter != nil {
+		connection = connection.Where("create_at > ?", *auditLogFilter.CreateAtAfter)
+	}
+	if auditLogFilter.CreateAtBefore != nil {
+		connection = connection.Where("create_at < ?", *auditLogFilter.CreateAtBefore)
+	}
+
+	if pagination != nil {
+		pagination.RecordCount = new(int64)
+		err := connection.Model(&entity.AuditLog{}).Where(&auditLogFilter.AuditLog).Count(pagination.RecordCount).Error
+		if err != nil {
+			return []entity.AuditLog{}, err
+		}
+
+		connection = connection.Limit(pagination.Limit).Offset(pagination.Offset)
+	}
+
+	if sortOrder != nil {
+		connection = connection.Order(fmt.Sprintf("%s %s", sortOrder.Sort, strings.ToUpper(sortOrder.Order)))
+	}
+
+	auditLogs := []entity.AuditLog{}
+	err := connection.Where(&auditLogFilter.AuditLog).Find(&auditLogs).Error
+	if err != nil {
+		return []entity.AuditLog{}, err
+	}
+
+	return auditLogs, nil
+}
+
+// description: written with the transaction of the audited change, entity id of a create is taken from the created record
+func createAuditLogs(transaction *gorm.DB, entityID *uint64, auditLogs []entity.AuditLog) error {
+	for _, auditLog := range auditLogs {
+		if auditLog.EntityID == nil {
+			auditLog.EntityID = entityID
+		}
+
+		err := transaction.Create(&auditLog).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"gorm.io/gorm"
+)
+
+// description: soft deleted rows of table are only queried when deleted filter asks for them
+func scopeDeleted(connection *gorm.DB, table string, deletedFilter entity.DeletedFilter) *gorm.DB {
+	if deletedFilter.Deleted != nil {
+		switch *deletedFilter.Deleted {
+		case entity.DeletedInclude:
+			connection = connection.Unscoped()
+		case entity.DeletedOnly:
+			connection = connection.Unscoped().Where(fmt.Sprintf("%s.delete_at IS NOT NULL", table))
+		}
+	}
+	if deletedFilter.DeleteAtBefore != nil {
+		connection = connection.Where(fmt.Sprintf("%s.delete_at < ?", table), *deletedFilter.DeleteAtBefore)
+	}
+
+	return connection
+}
+
+// description: increase version of model row, expected version guards against overwriting a change made meanwhile
+func bumpVersion(transaction *gorm.DB, model interface{}, version *uint64) error {
+	connection := transaction.Model(model)
+	if version != nil {
+		connection = connection.Where("version = ?", *version)
+	}
+
+	result := connection.Update("version", gorm.Expr("version + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// description: remove rows referencing the purged account by column, audit logs are kept as history
+func purgeDependents(transaction *gorm.DB, column string, id *uint64, models ...interface{}) error {
+	for _, model := range models {
+		err := transaction.Where(fmt.Sprintf("%s = ?", column), id).Delete(model).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=repositorymock -destination=../../mock/repository/oidc_state.go . OIDCState
+
+type (
+	OIDCState interface {
+		Create(ctx context.Context, oidcState entity.OIDCState) error
+		Get(ctx context.Context, oidcState entity.OIDCState) (entity.OIDCState, error)
+		Use(ctx context.Context, oidcState entity.OIDCState) error
+	}
+	oidcStateRepository struct {
+		postgresql *gorm.DB
+	}
+)
+
+func NewOIDCStateRepository(postgresql *gorm.DB) OIDCState {
+	return &oidcStateRepository{postgresql: postgresql}
+}
+
+func (repository *oidcStateRepository) Create(ctx context.Context, oidcState entity.OIDCState) error {
+	err := bindTransaction(ctx, repository.postgresql).Create(&oidcState).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *oidcStateRepository) Get(ctx context.Context, oidcState entity.OIDCState) (entity.OIDCState, error) {
+	err := bindTransaction(ctx, repository.postgresql).Where(&oidcState).First(&oidcState).Error
+	if err != nil {
+		return entity.OIDCState{}, err
+	}
+
+	return oidcState, nil
+}
+
+// description: mark unused state as used, record not found means the callback was replayed concurrently
+func (repository *oidcStateRepository) Use(ctx context.Context, oidcState entity.OIDCState) error {
+	result := bindTransaction(ctx, repository.postgresql).
+		Model(&entity.OIDCState{}).
+		Where("use_at IS NULL").
+		Where(&oidcState).
+		Update("use_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=repositorymock -destination=../../mock/repository/password_history.go . PasswordHistory
+
+type (
+	PasswordHistory interface {
+		Create(ctx context.Context, passwordHistory entity.PasswordHistory) error
+		GetAll(ctx context.Context, passwordHistory entity.PasswordHistory, limit int) ([]entity.PasswordHistory, error)
+	}
+	passwordHistoryRepository struct {
+		postgresql *gorm.DB
+	}
+)
+
+func NewPasswordHistoryRepository(postgresql *gorm.DB) PasswordHistory {
+	return &passwordHistoryRepository{postgresql: postgresql}
+}
+
+func (repository *passwordHistoryRepository) Create(ctx context.Context, passwordHistory entity.PasswordHistory) error {
+	err := bindTransaction(ctx, repository.postgresql).Create(&passwordHistory).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// description: latest password hashes of admin id or user id, newest first
+func (repository *passwordHistoryRepository) GetAll(ctx context.Context, passwordHistory entity.PasswordHistory, limit int) ([]entity.PasswordHistory, error) {
+	passwordHistories := []entity.PasswordHistory{}
+	err := bindTransaction(ctx, repository.postgresql).
+		Where(&passwordHistory).
+		Order("create_at DESC").
+		Order("id DESC").
+		Limit(limit).
+		Find(&passwordHistories).Error
+	if err != nil {
+		return []entity.PasswordHistory{}, err
+	}
+
+	return passwordHistories, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=repositorymock -destination=../../mock/repository/password_reset_token.go . PasswordResetToken
+
+type (
+	PasswordResetToken interface {
+		Create(ctx context.Context, passwordResetToken entity.PasswordResetToken) error
+		Get(ctx context.Context, passwordResetToken entity.PasswordResetToken) (entity.PasswordResetToken, error)
+		Use(ctx context.Context, passwordResetToken entity.PasswordResetToken) error
+		UseAll(ctx context.Context, passwordResetToken entity.PasswordResetToken) error
+	}
+	passwordResetTokenRepository struct {
+		postgresql *gorm.DB
+	}
+)
+
+func NewPasswordResetTokenRepository(postgresql *gorm.DB) PasswordResetToken {
+	return &passwordResetTokenRepository{postgresql: postgresql}
+}
+
+func (repository *passwordResetTokenRepository) Create(ctx context.Context, passwordResetToken entity.PasswordResetToken) error {
+	err := bindTransaction(ctx, repository.postgresql).Create(&passwordResetToken).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *passwordResetTokenRepository) Get(ctx context.Context, passwordResetToken entity.PasswordResetToken) (entity.PasswordResetToken, error) {
+	err := bindTransaction(ctx, repository.postgresql).Where(&passwordResetToken).First(&passwordResetToken).Error
+	if err != nil {
+		return entity.PasswordResetToken{}, err
+	}
+
+	return passwordResetToken, nil
+}
+
+// description: mark unused token as used, record not found means the token was used concurrently
+func (repository *passwordResetTokenRepository) Use(ctx context.Context, passwordResetToken entity.PasswordResetToken) error {
+	result := bindTransaction(ctx, repository.postgresql).
+		Model(&entity.PasswordResetToken{}).
+		Where("use_at IS NULL").
+		Where(&passwordResetToken).
+		Update("use_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// description: invalidate every unused token of user so only the latest requested token works
+func (repository *passwordResetTokenRepository) UseAll(ctx context.Context, passwordResetToken entity.PasswordResetToken) error {
+	err := bindTransaction(ctx, repository.postgresql).
+		Model(&entity.PasswordResetToken{}).
+		Where("use_at IS NULL").
+		Where(&passwordResetToken).
+		Update("use_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=repositorymock -destination=../../mock/repository/permission.go . Permission
+
+type (
+	Permission interface {
+		GetAll(ctx context.Context) ([]entity.Permission, error)
+	}
+	permissionRepository struct {
+		postgresql *gorm.DB
+	}
+)
+
+func NewPermissionRepository(postgresql *gorm.DB) Permission {
+	return &permissionRepository{postgresql: postgresql}
+}
+
+func (repository *permissionRepository) GetAll(ctx context.Context) ([]entity.Permission, error) {
+	permissions := []entity.Permission{}
+	err := bindTransaction(ctx, repository.postgresql).Order("name").Find(&permissions).Error
+	if err != nil {
+		return []entity.Permission{}, err
+	}
+
+	return permissions, nil
+}
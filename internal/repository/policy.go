@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=repositorymock -destination=../../mock/repository/policy.go . Policy
+
+const policyID = uint64(1)
+
+type (
+	Policy interface {
+		Get(ctx context.Context) (entity.Policy, error)
+		Update(ctx context.Context, policy entity.Policy) error
+	}
+	policyRepository struct {
+		postgresql *gorm.DB
+	}
+)
+
+func NewPolicyRepository(postgresql *gorm.DB) Policy {
+	return &policyRepository{postgresql: postgresql}
+}
+
+// description: policy is a single row, create with default values when not exist
+func (repository *policyRepository) Get(ctx context.Context) (entity.Policy, error) {
+	id := policyID
+	policy := entity.Policy{}
+	err := bindTransaction(ctx, repository.postgresql).FirstOrCreate(&policy, entity.Policy{ID: &id}).Error
+	if err != nil {
+		return entity.Policy{}, err
+	}
+
+	return policy, nil
+}
+
+func (repository *policyRepository) Update(ctx context.Context, policy entity.Policy) error {
+	_, err := repository.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	id := policyID
+	policy.ID = &id
+	err = bindTransaction(ctx, repository.postgresql).Updates(&policy).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
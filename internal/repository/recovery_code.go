@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=repositorymock -destination=../../mock/repository/recovery_code.go . RecoveryCode
+
+type (
+	RecoveryCode interface {
+		Create(ctx context.Context, recoveryCode entity.RecoveryCode) error
+		DeleteAll(ctx context.Context, recoveryCode entity.RecoveryCode) error
+		Use(ctx context.Context, recoveryCode entity.RecoveryCode) error
+	}
+	recoveryCodeRepository struct {
+		postgresql *gorm.DB
+	}
+)
+
+func NewRecoveryCodeRepository(postgresql *gorm.DB) RecoveryCode {
+	return &recoveryCodeRepository{postgresql: postgresql}
+}
+
+func (repository *recoveryCodeRepository) Create(ctx context.Context, recoveryCode entity.RecoveryCode) error {
+	err := bindTransaction(ctx, repository.postgresql).Create(&recoveryCode).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// description: delete every recovery code matching admin id or user id
+func (repository *recoveryCodeRepository) DeleteAll(ctx context.Context, recoveryCode entity.RecoveryCode) error {
+	err := bindTransaction(ctx, repository.postgresql).Where(&recoveryCode).Delete(&entity.RecoveryCode{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// description: mark unused recovery code as used, record not found means the code is invalid or already used
+func (repository *recoveryCodeRepository) Use(ctx context.Context, recoveryCode entity.RecoveryCode) error {
+	result := bindTransaction(ctx, repository.postgresql).
+		Model(&entity.RecoveryCode{}).
+		Where("use_at IS NULL").
+		Where(&recoveryCode).
+		Update("use_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=repositorymock -destination=../../mock/repository/refresh_token.go . RefreshToken
+
+type (
+	RefreshToken interface {
+		Create(ctx context.Context, refreshToken entity.RefreshToken) error
+		Get(ctx context.Context, refreshToken entity.RefreshToken) (entity.RefreshToken, error)
+		Revoke(ctx context.Context, refreshToken entity.RefreshToken) error
+		RevokeAll(ctx context.Context, refreshToken entity.RefreshToken) error
+		RevokeFamily(ctx context.Context, familyID string) error
+	}
+	refreshTokenRepository struct {
+		postgresql *gorm.DB
+	}
+)
+
+func NewRefreshTokenRepository(postgresql *gorm.DB) RefreshToken {
+	return &refreshTokenRepository{postgresql: postgresql}
+}
+
+func (repository *refreshTokenRepository) Create(ctx context.Context, refreshToken entity.RefreshToken) error {
+	err := bindTransaction(ctx, repository.postgresql).Create(&refreshToken).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *refreshTokenRepository) Get(ctx context.Context, refreshToken entity.RefreshToken) (entity.RefreshToken, error) {
+	err := bindTransaction(ctx, repository.postgresql).First(&refreshToken, refreshToken).Error
+	if err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	return refreshToken, nil
+}
+
+// description: revoke only when not revoked yet, record not found means the token was already used
+func (repository *refreshTokenRepository) Revoke(ctx context.Context, refreshToken entity.RefreshToken) error {
+	result := bindTransaction(ctx, repository.postgresql).
+		Model(&entity.RefreshToken{}).
+		Where("id = ? AND revoke_at IS NULL", refreshToken.ID).
+		Update("revoke_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// description: revoke every refresh token matching admin id or user id
+func (repository *refreshTokenRepository) RevokeAll(ctx context.Context, refreshToken entity.RefreshToken) error {
+	err := bindTransaction(ctx, repository.postgresql).
+		Model(&entity.RefreshToken{}).
+		Where("revoke_at IS NULL").
+		Where(&refreshToken).
+		Update("revoke_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	err := bindTransaction(ctx, repository.postgresql).
+		Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoke_at IS NULL", familyID).
+		Update("revoke_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=repositorymock -destination=../../mock/repository/revoked_token.go . RevokedToken
+
+const (
+	revokedTokenSyncInterval = 30 * time.Second
+	// description: create at is when inserting transaction started, rows created this long before the latest one seen are read again so late commits are not missed
+	revokedTokenSyncOverlap = 5 * time.Minute
+)
+
+type (
+	RevokedToken interface {
+		Create(ctx context.Context, revokedToken entity.RevokedToken) error
+		IsRevoked(ctx context.Context, revokedToken entity.RevokedToken, issueAt time.Time) (bool, error)
+	}
+	revokedTokenRepository struct {
+		postgresql   *gorm.DB
+		mutex        sync.RWMutex
+		syncAt       time.Time
+		syncCreateAt time.Time
+		jtis         map[string]time.Time
+		sessions     map[uint64]time.Time
+		admins       map[uint64]subjectRevocation
+		users        map[uint64]subjectRevocation
+	}
+	// description: every token of admin or user issued before revoke at is revoked until expire at
+	subjectRevocation struct {
+		revokeAt time.Time
+		expireAt time.Time
+	}
+)
+
+func NewRevokedTokenRepository(postgresql *gorm.DB) RevokedToken {
+	return &revokedTokenRepository{
+		postgresql: postgresql,
+		jtis:       map[string]time.Time{},
+		sessions:   map[uint64]time.Time{},
+		admins:     map[uint64]subjectRevocation{},
+		users:      map[uint64]subjectRevocation{},
+	}
+}
+
+// description: cached once transaction in context commits, so rolled back revocation never rejects tokens
+func (repository *revokedTokenRepository) Create(ctx context.Context, revokedToken entity.RevokedToken) error {
+	err := bindTransaction(ctx, repository.postgresql).Create(&revokedToken).Error
+	if err != nil {
+		return err
+	}
+
+	afterCommit(ctx, func() {
+		repository.mutex.Lock()
+		defer repository.mutex.Unlock()
+		repository.cache(revokedToken)
+	})
+
+	return nil
+}
+
+// description: check against in-memory cache, cache pulls new revocations from other instances every sync interval,
+// iat has whole second precision so token issued in the second of revocation is revoked too, it cannot be told apart from one issued just before
+func (repository *revokedTokenRepository) IsRevoked(ctx context.Context, revokedToken entity.RevokedToken, issueAt time.Time) (bool, error) {
+	err := repository.sync(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+
+	if revokedToken.JTI != nil {
+		if _, isExist := repository.jtis[*revokedToken.JTI]; isExist {
+			return true, nil
+		}
+	}
+	if revokedToken.SessionID != nil {
+		if _, isExist := repository.sessions[*revokedToken.SessionID]; isExist {
+			return true, nil
+		}
+	}
+	if revokedToken.AdminID != nil {
+		if revocation, isExist := repository.admins[*revokedToken.AdminID]; isExist && !issueAt.After(revocation.revokeAt.Truncate(time.Second)) {
+			return true, nil
+		}
+	}
+	if revokedToken.UserID != nil {
+		if revocation, isExist := repository.users[*revokedToken.UserID]; isExist && !issueAt.After(revocation.revokeAt.Truncate(time.Second)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (repository *revokedTokenRepository) sync(ctx context.Context) error {
+	repository.mutex.RLock()
+	isFresh := time.Since(repository.syncAt) < revokedTokenSyncInterval
+	repository.mutex.RUnlock()
+	if isFresh {
+		return nil
+	}
+
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	currentTime := time.Now()
+	connection := bindTransaction(ctx, repository.postgresql).Where("expire_at > ?", currentTime)
+	if !repository.syncCreateAt.IsZero() {
+		connection = connection.Where("create_at > ?", repository.syncCreateAt.Add(-revokedTokenSyncOverlap))
+	}
+
+	revokedTokens := []entity.RevokedToken{}
+	err := connection.Order("create_at ASC").Find(&revokedTokens).Error
+	if err != nil {
+		return err
+	}
+
+	for _, revokedToken := range revokedTokens {
+		repository.cache(revokedToken)
+		if revokedToken.CreateAt != nil && revokedToken.CreateAt.After(repository.syncCreateAt) {
+			repository.syncCreateAt = *revokedToken.CreateAt
+		}
+	}
+
+	// description: drop expired entries, expired tokens are rejected by signature validation anyway
+	for jti, expireAt := range repository.jtis {
+		if currentTime.After(expireAt) {
+			delete(repository.jtis, jti)
+		}
+	}
+	for sessionID, expireAt := range repository.sessions {
+		if currentTime.After(expireAt) {
+			delete(repository.sessions, sessionID)
+		}
+	}
+	for adminID, revocation := range repository.admins {
+		if currentTime.After(revocation.expireAt) {
+			delete(repository.admins, adminID)
+		}
+	}
+	for userID, revocation := range repository.users {
+		if currentTime.After(revocation.expireAt) {
+			delete(repository.users, userID)
+		}
+	}
+	repository.syncAt = currentTime
+
+	return nil
+}
+
+func (repository *revokedTokenRepository) cache(revokedToken entity.RevokedToken) {
+	createAt := time.Now()
+	if revokedToken.CreateAt != nil {
+		createAt = *revokedToken.CreateAt
+	}
+
+	switch {
+	case revokedToken.JTI != nil:
+		repository.jtis[*revokedToken.JTI] = *revokedToken.ExpireAt
+	case revokedToken.SessionID != nil:
+		repository.sessions[*revokedToken.SessionID] = *revokedToken.ExpireAt
+	case revokedToken.AdminID != nil:
+		repository.admins[*revokedToken.AdminID] = mergeSubjectRevocation(repository.admins[*revokedToken.AdminID], createAt, *revokedToken.ExpireAt)
+	case revokedToken.UserID != nil:
+		repository.users[*revokedToken.UserID] = mergeSubjectRevocation(repository.users[*revokedToken.UserID], createAt, *revokedToken.ExpireAt)
+	}
+}
+
+// description: latest revocation wins, it is kept as long as the longest lived one
+func mergeSubjectRevocation(revocation subjectRevocation, revokeAt time.Time, expireAt time.Time) subjectRevocation {
+	if revokeAt.After(revocation.revokeAt) {
+		revocation.revokeAt = revokeAt
+	}
+	if expireAt.After(revocation.expireAt) {
+		revocation.expireAt = expireAt
+	}
+
+	return revocation
+}
@@ -0,0 +1,58 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRevokedTokenIsRevoked(test *testing.T) {
+	postgresql, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DisableAutomaticPing: true, DryRun: true, SkipDefaultTransaction: true})
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	id := uint64(1)
+	revokeAt := time.Now().Truncate(time.Second).Add(500 * time.Millisecond)
+	expireAt := revokeAt.Add(time.Hour)
+
+	testCases := []struct {
+		name       string
+		issueAt    time.Time
+		expectedOk bool
+	}{
+		{
+			name:       "Revoked/Before",
+			issueAt:    revokeAt.Add(-time.Second).Truncate(time.Second),
+			expectedOk: true,
+		},
+		{
+			name:       "Revoked/SameSecond",
+			issueAt:    revokeAt.Truncate(time.Second),
+			expectedOk: true,
+		},
+		{
+			name:       "NotRevoked/After",
+			issueAt:    revokeAt.Add(time.Second).Truncate(time.Second),
+			expectedOk: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			revokedTokenRepository := repository.NewRevokedTokenRepository(postgresql)
+			err := revokedTokenRepository.Create(context.Background(), entity.RevokedToken{UserID: &id, ExpireAt: &expireAt, CreateAt: &revokeAt})
+			assert.NoError(test, err)
+
+			isRevoked, err := revokedTokenRepository.IsRevoked(context.Background(), entity.RevokedToken{UserID: &id}, testCase.issueAt)
+			assert.NoError(test, err)
+			assert.Equal(test, testCase.expectedOk, isRevoked)
+		})
+	}
+}
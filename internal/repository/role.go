@@ -1,15 +1,23 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/sndzhng/gin-template/internal/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //go:generate mockgen -package=repositorymock -destination=../../mock/repository/role.go . Role
 
 type (
 	Role interface {
-		Create(role entity.Role) error
+		Create(ctx context.Context, role entity.Role, auditLogs ...entity.AuditLog) error
+		Delete(ctx context.Context, role entity.Role, auditLogs ...entity.AuditLog) error
+		Get(ctx context.Context, role entity.Role) (entity.Role, error)
+		GetAll(ctx context.Context) ([]entity.Role, error)
+		Update(ctx context.Context, role entity.Role, auditLogs ...entity.AuditLog) error
+		UpdatePermissions(ctx context.Context, role entity.Role, permissionNames []entity.PermissionName, auditLogs ...entity.AuditLog) error
 	}
 	roleRepository struct {
 		postgresql *gorm.DB
@@ -20,8 +28,93 @@ func NewRoleRepository(postgresql *gorm.DB) Role {
 	return &roleRepository{postgresql: postgresql}
 }
 
-func (repository *roleRepository) Create(role entity.Role) error {
-	err := repository.postgresql.Create(&role).Error
+func (repository *roleRepository) Create(ctx context.Context, role entity.Role, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := transaction.Omit(clause.Associations).Create(&role).Error
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, role.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// description: permission assignments are removed with the role
+func (repository *roleRepository) Delete(ctx context.Context, role entity.Role, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := transaction.Select("Permissions").Delete(&role).Error
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, role.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *roleRepository) Get(ctx context.Context, role entity.Role) (entity.Role, error) {
+	err := bindTransaction(ctx, repository.postgresql).Preload("Permissions").Where(&role).First(&role).Error
+	if err != nil {
+		return entity.Role{}, err
+	}
+
+	return role, nil
+}
+
+func (repository *roleRepository) GetAll(ctx context.Context) ([]entity.Role, error) {
+	roles := []entity.Role{}
+	err := bindTransaction(ctx, repository.postgresql).Preload("Permissions").Order("id").Find(&roles).Error
+	if err != nil {
+		return []entity.Role{}, err
+	}
+
+	return roles, nil
+}
+
+func (repository *roleRepository) Update(ctx context.Context, role entity.Role, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := transaction.Omit(clause.Associations).Updates(&role).Error
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, role.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// description: replace assignments, record not found when a permission is missing from catalogue
+func (repository *roleRepository) UpdatePermissions(ctx context.Context, role entity.Role, permissionNames []entity.PermissionName, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		permissions := []entity.Permission{}
+		err := transaction.Where("name IN ?", permissionNames).Find(&permissions).Error
+		if err != nil {
+			return err
+		}
+		if len(permissions) != len(permissionNames) {
+			return gorm.ErrRecordNotFound
+		}
+
+		err = transaction.Model(&entity.Role{ID: role.ID}).Association("Permissions").Replace(permissions)
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, role.ID, auditLogs)
+	})
 	if err != nil {
 		return err
 	}
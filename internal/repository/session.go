@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=repositorymock -destination=../../mock/repository/session.go . Session
+
+type (
+	Session interface {
+		Create(ctx context.Context, session entity.Session) error
+		Get(ctx context.Context, session entity.Session) (entity.Session, error)
+		GetAll(ctx context.Context, session entity.Session) ([]entity.Session, error)
+		UpdateLastSeen(ctx context.Context, session entity.Session) error
+	}
+	sessionRepository struct {
+		postgresql *gorm.DB
+	}
+)
+
+func NewSessionRepository(postgresql *gorm.DB) Session {
+	return &sessionRepository{postgresql: postgresql}
+}
+
+func (repository *sessionRepository) Create(ctx context.Context, session entity.Session) error {
+	err := bindTransaction(ctx, repository.postgresql).Create(&session).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *sessionRepository) Get(ctx context.Context, session entity.Session) (entity.Session, error) {
+	err := bindTransaction(ctx, repository.postgresql).Where(&session).First(&session).Error
+	if err != nil {
+		return entity.Session{}, err
+	}
+
+	return session, nil
+}
+
+// description: active sessions only, a session ends when its refresh token family has no usable token left
+func (repository *sessionRepository) GetAll(ctx context.Context, session entity.Session) ([]entity.Session, error) {
+	sessions := []entity.Session{}
+	err := bindTransaction(ctx, repository.postgresql).
+		Where(&session).
+		Where(
+			"EXISTS (SELECT 1 FROM refresh_tokens WHERE refresh_tokens.family_id = sessions.family_id AND refresh_tokens.revoke_at IS NULL AND refresh_tokens.expire_at > ?)",
+			time.Now(),
+		).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return []entity.Session{}, err
+	}
+
+	return sessions, nil
+}
+
+func (repository *sessionRepository) UpdateLastSeen(ctx context.Context, session entity.Session) error {
+	err := bindTransaction(ctx, repository.postgresql).
+		Model(&entity.Session{ID: session.ID}).
+		Updates(entity.Session{
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			LastSeenAt: session.LastSeenAt,
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=repositorymock -destination=../../mock/repository/transaction.go . Transaction
+
+type (
+	Transaction interface {
+		Do(ctx context.Context, fc func(ctx context.Context) error) error
+	}
+	transactionRepository struct {
+		postgresql *gorm.DB
+	}
+	transactionKey   struct{}
+	transactionScope struct {
+		transaction *gorm.DB
+		commitHooks []func()
+	}
+)
+
+func NewTransactionRepository(postgresql *gorm.DB) Transaction {
+	return &transactionRepository{postgresql: postgresql}
+}
+
+// description: repository calls made with context passed to fc share one transaction, committed when fc returns nil and rolled back on error or panic, nested call is a savepoint
+func (repository *transactionRepository) Do(ctx context.Context, fc func(ctx context.Context) error) error {
+	scope := &transactionScope{}
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		scope.transaction = transaction
+		return fc(context.WithValue(ctx, transactionKey{}, scope))
+	})
+	if err != nil {
+		return err
+	}
+
+	// description: savepoint hands its hooks to outer transaction, they run only when outermost one commits
+	parentScope, ok := ctx.Value(transactionKey{}).(*transactionScope)
+	if ok {
+		parentScope.commitHooks = append(parentScope.commitHooks, scope.commitHooks...)
+		return nil
+	}
+	for _, commitHook := range scope.commitHooks {
+		commitHook()
+	}
+
+	return nil
+}
+
+// description: connection of repository bound to transaction carried in context, if any
+func bindTransaction(ctx context.Context, postgresql *gorm.DB) *gorm.DB {
+	scope, ok := ctx.Value(transactionKey{}).(*transactionScope)
+	if ok {
+		return scope.transaction.WithContext(ctx)
+	}
+
+	return postgresql.WithContext(ctx)
+}
+
+// description: run fc once transaction carried in context commits, dropped on rollback, run at once without transaction
+func afterCommit(ctx context.Context, fc func()) {
+	scope, ok := ctx.Value(transactionKey{}).(*transactionScope)
+	if !ok {
+		fc()
+		return
+	}
+
+	scope.commitHooks = append(scope.commitHooks, fc)
+}
@@ -1,22 +1,32 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/sndzhng/gin-template/internal/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //go:generate mockgen -package=repositorymock -destination=../../mock/repository/user.go . User
 
 type (
 	User interface {
-		Create(user entity.User) error
-		Delete(user entity.User) error
-		Get(user entity.User) (entity.User, error)
-		GetAll(userFilter *entity.UserFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.User, error)
-		Update(user entity.User) error
+		Create(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error
+		Delete(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error
+		Get(ctx context.Context, user entity.User) (entity.User, error)
+		GetAll(ctx context.Context, userFilter *entity.UserFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.User, error)
+		GetDeleted(ctx context.Context, user entity.User) (entity.User, error)
+		IncreaseFailedLogin(ctx context.Context, user entity.User) (int, error)
+		Purge(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error
+		Restore(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error
+		Update(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error
+		UpdateLock(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error
+		UpdateMFA(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error
+		UpdateMFAStep(ctx context.Context, user entity.User) error
 	}
 	userRepository struct {
 		postgresql *gorm.DB
@@ -27,8 +37,15 @@ func NewUserRepository(postgresql *gorm.DB) User {
 	return &userRepository{postgresql: postgresql}
 }
 
-func (repository *userRepository) Create(user entity.User) error {
-	err := repository.postgresql.Create(&user).Error
+func (repository *userRepository) Create(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := transaction.Create(&user).Error
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, user.ID, auditLogs)
+	})
 	if err != nil {
 		return err
 	}
@@ -36,8 +53,24 @@ func (repository *userRepository) Create(user entity.User) error {
 	return nil
 }
 
-func (repository *userRepository) Delete(user entity.User) error {
-	err := repository.postgresql.Delete(&user).Error
+func (repository *userRepository) Delete(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		connection := transaction
+		if user.Version != nil {
+			connection = connection.Where("version = ?", *user.Version)
+			user.Version = nil
+		}
+
+		result := connection.Delete(&user)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return createAuditLogs(transaction, user.ID, auditLogs)
+	})
 	if err != nil {
 		return err
 	}
@@ -45,8 +78,8 @@ func (repository *userRepository) Delete(user entity.User) error {
 	return nil
 }
 
-func (repository *userRepository) Get(user entity.User) (entity.User, error) {
-	err := repository.postgresql.Joins("Admin").First(&user, user).Error
+func (repository *userRepository) Get(ctx context.Context, user entity.User) (entity.User, error) {
+	err := bindTransaction(ctx, repository.postgresql).Joins("Admin").First(&user, user).Error
 	if err != nil {
 		return entity.User{}, err
 	}
@@ -54,8 +87,8 @@ func (repository *userRepository) Get(user entity.User) (entity.User, error) {
 	return user, nil
 }
 
-func (repository *userRepository) GetAll(userFilter *entity.UserFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.User, error) {
-	connection := repository.postgresql
+func (repository *userRepository) GetAll(ctx context.Context, userFilter *entity.UserFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.User, error) {
+	connection := scopeDeleted(bindTransaction(ctx, repository.postgresql), "users", userFilter.DeletedFilter)
 
 	if userFilter.CreateAtAfter != nil {
 		connection = connection.Where("users.create_at > ?", *userFilter.CreateAtAfter)
@@ -63,6 +96,13 @@ func (repository *userRepository) GetAll(userFilter *entity.UserFilter, sortOrde
 	if userFilter.CreateAtBefore != nil {
 		connection = connection.Where("users.create_at < ?", *userFilter.CreateAtBefore)
 	}
+	if userFilter.IsLocked != nil {
+		if *userFilter.IsLocked {
+			connection = connection.Where("users.lock_until > ?", time.Now())
+		} else {
+			connection = connection.Where("users.lock_until IS NULL OR users.lock_until <= ?", time.Now())
+		}
+	}
 	if userFilter.Search != nil {
 		search := fmt.Sprintf("%%%s%%", *userFilter.Search)
 		connection = connection.Where(
@@ -98,8 +138,177 @@ func (repository *userRepository) GetAll(userFilter *entity.UserFilter, sortOrde
 	return users, nil
 }
 
-func (repository *userRepository) Update(user entity.User) error {
-	err := repository.postgresql.Updates(&user).Error
+func (repository *userRepository) GetDeleted(ctx context.Context, user entity.User) (entity.User, error) {
+	err := bindTransaction(ctx, repository.postgresql).Unscoped().Joins("Admin").Where("users.delete_at IS NOT NULL").First(&user, user).Error
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
+
+// description: only soft deleted user is purged, dependent rows are removed in the same transaction
+func (repository *userRepository) Purge(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := purgeDependents(
+			transaction,
+			"user_id",
+			user.ID,
+			&entity.PasswordHistory{},
+			&entity.PasswordResetToken{},
+			&entity.RecoveryCode{},
+			&entity.RefreshToken{},
+			&entity.Session{},
+		)
+		if err != nil {
+			return err
+		}
+
+		result := transaction.Unscoped().Where("delete_at IS NOT NULL").Delete(&entity.User{ID: user.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return createAuditLogs(transaction, user.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *userRepository) Restore(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		result := transaction.
+			Unscoped().
+			Model(&entity.User{ID: user.ID}).
+			Where("delete_at IS NOT NULL").
+			Updates(map[string]interface{}{"delete_at": nil, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return createAuditLogs(transaction, user.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *userRepository) Update(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := bumpVersion(transaction, &entity.User{ID: user.ID}, user.Version)
+		if err != nil {
+			return err
+		}
+		user.Version = nil
+
+		err = transaction.Updates(&user).Error
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, user.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// description: increase in one statement so concurrent failures are all counted, returns the increased count
+func (repository *userRepository) IncreaseFailedLogin(ctx context.Context, user entity.User) (int, error) {
+	updatedUser := entity.User{ID: user.ID}
+	result := bindTransaction(ctx, repository.postgresql).
+		Model(&updatedUser).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "failed_login"}}}).
+		Updates(map[string]interface{}{"failed_login": gorm.Expr("failed_login + 1"), "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 || updatedUser.FailedLogin == nil {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	return *updatedUser.FailedLogin, nil
+}
+
+// description: update lock fields including zero value to reset failed login and unlock
+func (repository *userRepository) UpdateLock(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := transaction.
+			Model(&entity.User{ID: user.ID}).
+			Select("failed_login", "lock_until").
+			Updates(&user).Error
+		if err != nil {
+			return err
+		}
+
+		err = bumpVersion(transaction, &entity.User{ID: user.ID}, nil)
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, user.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// description: update mfa fields including zero value to disable mfa
+func (repository *userRepository) UpdateMFA(ctx context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		err := transaction.
+			Model(&entity.User{ID: user.ID}).
+			Select("mfa_secret", "is_mfa_enabled").
+			Updates(&user).Error
+		if err != nil {
+			return err
+		}
+
+		err = bumpVersion(transaction, &entity.User{ID: user.ID}, nil)
+		if err != nil {
+			return err
+		}
+
+		return createAuditLogs(transaction, user.ID, auditLogs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// description: record not found when step is not after the last accepted one, so concurrent replay of a code fails
+func (repository *userRepository) UpdateMFAStep(ctx context.Context, user entity.User) error {
+	err := bindTransaction(ctx, repository.postgresql).Transaction(func(transaction *gorm.DB) error {
+		result := transaction.
+			Model(&entity.User{ID: user.ID}).
+			Where("mfa_last_step IS NULL OR mfa_last_step < ?", *user.MFALastStep).
+			Update("mfa_last_step", *user.MFALastStep)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return bumpVersion(transaction, &entity.User{ID: user.ID}, nil)
+	})
 	if err != nil {
 		return err
 	}
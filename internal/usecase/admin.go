@@ -1,8 +1,10 @@
 package usecase
 
 import (
-	"net/http"
+	"context"
+	"fmt"
 
+	"github.com/sndzhng/gin-template/internal/common"
 	"github.com/sndzhng/gin-template/internal/entity"
 	"github.com/sndzhng/gin-template/internal/repository"
 	"github.com/sndzhng/gin-template/internal/util"
@@ -12,78 +14,189 @@ import (
 
 //go:generate mockgen -package=usecasemock -destination=../../mock/usecase/admin.go . Admin
 
+var (
+	errorBootstrapDone       = util.NewError(common.ErrorCode.BootstrapDone, "admin already exists, bootstrap is done")
+	errorOwnRoleChange       = util.NewError(common.ErrorCode.Forbidden, "own role cannot be changed")
+	errorSuperAdminRoleGrant = util.NewError(common.ErrorCode.Forbidden, "super admin role can only be given by super admin")
+)
+
 type (
 	Admin interface {
-		Create(admin entity.Admin) error
-		Delete(admin entity.Admin) error
-		Get(admin entity.Admin) (entity.Admin, error)
-		GetAll(adminFilter *entity.AdminFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.Admin, error)
-		Initial() error
-		Update(admin entity.Admin) error
+		Bootstrap(ctx context.Context, admin entity.Admin, audit entity.Audit) error
+		Create(ctx context.Context, admin entity.Admin, audit entity.Audit) error
+		Delete(ctx context.Context, admin entity.Admin, audit entity.Audit) error
+		Get(ctx context.Context, admin entity.Admin) (entity.Admin, error)
+		GetAll(ctx context.Context, adminFilter *entity.AdminFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.Admin, error)
+		Purge(ctx context.Context, admin entity.Admin, audit entity.Audit) error
+		Restore(ctx context.Context, admin entity.Admin, audit entity.Audit) error
+		Unlock(ctx context.Context, admin entity.Admin, audit entity.Audit) error
+		Update(ctx context.Context, admin entity.Admin, audit entity.Audit) error
 	}
 
 	adminUsecase struct {
-		adminRepository repository.Admin
-		roleRepository  repository.Role
+		adminRepository           repository.Admin
+		passwordHistoryRepository repository.PasswordHistory
+		refreshTokenRepository    repository.RefreshToken
+		revokedTokenRepository    repository.RevokedToken
+		roleRepository            repository.Role
+		transactionRepository     repository.Transaction
+		userRepository            repository.User
 	}
 )
 
 func NewAdminUsecase(
 	adminRepository repository.Admin,
+	passwordHistoryRepository repository.PasswordHistory,
+	refreshTokenRepository repository.RefreshToken,
+	revokedTokenRepository repository.RevokedToken,
 	roleRepository repository.Role,
+	transactionRepository repository.Transaction,
+	userRepository repository.User,
 ) Admin {
 	return &adminUsecase{
-		adminRepository: adminRepository,
-		roleRepository:  roleRepository,
+		adminRepository:           adminRepository,
+		passwordHistoryRepository: passwordHistoryRepository,
+		refreshTokenRepository:    refreshTokenRepository,
+		revokedTokenRepository:    revokedTokenRepository,
+		roleRepository:            roleRepository,
+		transactionRepository:     transactionRepository,
+		userRepository:            userRepository,
 	}
 }
 
-func (usecase *adminUsecase) Create(admin entity.Admin) error {
+// description: one shot creation of first super admin, refused once any admin exists, built in roles are seeded by datastore before
+func (usecase *adminUsecase) Bootstrap(ctx context.Context, admin entity.Admin, audit entity.Audit) error {
 	if admin.Password == nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: "password is nil"}
+		return util.NewError(common.ErrorCode.Internal, "password is nil")
+	}
+
+	isExist, err := isAdminExist(ctx, usecase.adminRepository)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	if isExist {
+		return errorBootstrapDone
+	}
+
+	details, err := checkPassword(ctx, usecase.passwordHistoryRepository, passwordAccount{username: admin.Username}, *admin.Password)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	err = newValidationError(append(checkUsername(admin.Username), details...))
+	if err != nil {
+		return err
 	}
 
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(*admin.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	superAdminRoleName := string(entity.SuperAdminRoleName)
+	superAdminRole, err := usecase.roleRepository.Get(ctx, entity.Role{Name: &superAdminRoleName})
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
 	}
 
+	isResetPassword := true
+
 	admin.PasswordHash = &passwordHash
-	err = usecase.adminRepository.Create(admin)
+	admin.IsResetPassword = &isResetPassword
+	admin.RoleID = superAdminRole.ID
+
+	auditLog := entity.NewAuditLog(audit, entity.CreateAuditAction, entity.AdminAuditEntityType, nil).WithChange(nil, admin)
+	isCreated, err := usecase.adminRepository.CreateFirst(ctx, admin, auditLog)
 	if err != nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	if !isCreated {
+		return errorBootstrapDone
 	}
 
 	return nil
 }
 
-func (usecase *adminUsecase) Delete(admin entity.Admin) error {
-	err := usecase.adminRepository.Delete(admin)
+func (usecase *adminUsecase) Create(ctx context.Context, admin entity.Admin, audit entity.Audit) error {
+	if admin.Password == nil {
+		return util.NewError(common.ErrorCode.Internal, "password is nil")
+	}
+
+	err := usecase.checkAssignableRole(ctx, admin.RoleID, audit)
+	if err != nil {
+		return err
+	}
+
+	details, err := checkPassword(ctx, usecase.passwordHistoryRepository, passwordAccount{username: admin.Username}, *admin.Password)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	err = newValidationError(append(checkUsername(admin.Username), details...))
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(*admin.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	isResetPassword := true
+
+	admin.PasswordHash = &passwordHash
+	admin.IsResetPassword = &isResetPassword
+	auditLog := entity.NewAuditLog(audit, entity.CreateAuditAction, entity.AdminAuditEntityType, nil).WithChange(nil, admin)
+	err = usecase.adminRepository.Create(ctx, admin, auditLog)
+	if err != nil {
+		return newRepositoryError(err)
 	}
 
 	return nil
 }
 
-func (usecase *adminUsecase) Get(admin entity.Admin) (entity.Admin, error) {
-	admin, err := usecase.adminRepository.Get(admin)
+func (usecase *adminUsecase) Delete(ctx context.Context, admin entity.Admin, audit entity.Audit) error {
+	currentAdmin, err := usecase.Get(ctx, entity.Admin{ID: admin.ID})
+	if err != nil {
+		return err
+	}
+	err = checkVersion(admin.Version, currentAdmin.Version)
+	if err != nil {
+		return err
+	}
+
+	auditLog := entity.NewAuditLog(audit, entity.DeleteAuditAction, entity.AdminAuditEntityType, admin.ID).WithChange(currentAdmin, nil)
+	return usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := usecase.adminRepository.Delete(ctx, admin, auditLog)
+		if err != nil {
+			switch err {
+			case gorm.ErrRecordNotFound:
+				return util.NewError(common.ErrorCode.VersionMismatch, "version does not match")
+			default:
+				return util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+		}
+
+		return revokeAllTokens(ctx, usecase.refreshTokenRepository, usecase.revokedTokenRepository, admin.ID, nil)
+	})
+}
+
+func (usecase *adminUsecase) Get(ctx context.Context, admin entity.Admin) (entity.Admin, error) {
+	admin, err := usecase.adminRepository.Get(ctx, admin)
 	if err != nil {
 		switch err {
 		case gorm.ErrRecordNotFound:
-			return entity.Admin{}, util.Error{Code: http.StatusNotFound, Message: err.Error()}
+			return entity.Admin{}, util.NewError(common.ErrorCode.NotFound, err.Error())
 		default:
-			return entity.Admin{}, util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+			return entity.Admin{}, util.NewError(common.ErrorCode.Internal, err.Error())
 		}
 	}
 
 	return admin, nil
 }
 
-func (usecase *adminUsecase) GetAll(adminFilter *entity.AdminFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.Admin, error) {
-	admins, err := usecase.adminRepository.GetAll(adminFilter, sortOrder, pagination)
+func (usecase *adminUsecase) GetAll(ctx context.Context, adminFilter *entity.AdminFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.Admin, error) {
+	admins, err := usecase.adminRepository.GetAll(ctx, adminFilter, sortOrder, pagination)
 	if err != nil {
-		return []entity.Admin{}, util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		return []entity.Admin{}, util.NewError(common.ErrorCode.Internal, err.Error())
 	}
 
 	if pagination != nil {
@@ -93,48 +206,244 @@ func (usecase *adminUsecase) GetAll(adminFilter *entity.AdminFilter, sortOrder *
 	return admins, nil
 }
 
-func (usecase *adminUsecase) Initial() error {
-	initialID := uint64(1)
-	initialRoleName := string(entity.SuperAdminRoleName)
-	role := entity.Role{
-		ID:   &initialID,
-		Name: &initialRoleName,
+// description: admin must be soft deleted first and own no user, including soft deleted one
+func (usecase *adminUsecase) Purge(ctx context.Context, admin entity.Admin, audit entity.Audit) error {
+	deletedAdmin, err := usecase.getDeleted(ctx, entity.Admin{ID: admin.ID})
+	if err != nil {
+		return err
 	}
-	_ = usecase.roleRepository.Create(role)
 
-	initialValue := "superadmin"
-	passwordHash, err := bcrypt.GenerateFromPassword([]byte(initialValue), bcrypt.DefaultCost)
+	isOwner, err := isUserOwner(ctx, usecase.userRepository, deletedAdmin.ID)
 	if err != nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		return util.NewError(common.ErrorCode.Internal, err.Error())
 	}
-	admin := entity.Admin{
-		ID:           &initialID,
-		RoleID:       &initialID,
-		Username:     &initialValue,
-		PasswordHash: &passwordHash,
+	if isOwner {
+		return util.NewError(common.ErrorCode.AdminOwnsUser, "admin still owns user")
 	}
-	err = usecase.adminRepository.Create(admin)
+
+	auditLog := entity.NewAuditLog(audit, entity.PurgeAuditAction, entity.AdminAuditEntityType, deletedAdmin.ID).WithChange(deletedAdmin, nil)
+	err = usecase.adminRepository.Purge(ctx, entity.Admin{ID: deletedAdmin.ID}, auditLog)
 	if err != nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return util.NewError(common.ErrorCode.NotFound, err.Error())
+		default:
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
 	}
 
 	return nil
 }
 
-func (usecase *adminUsecase) Update(admin entity.Admin) error {
+// description: username and oidc subject of deleted admin may have been reused by an active admin meanwhile
+func (usecase *adminUsecase) Restore(ctx context.Context, admin entity.Admin, audit entity.Audit) error {
+	deletedAdmin, err := usecase.getDeleted(ctx, entity.Admin{ID: admin.ID})
+	if err != nil {
+		return err
+	}
+
+	err = usecase.checkRestoreConflict(ctx, entity.Admin{Username: deletedAdmin.Username}, fmt.Sprintf("username %s is already used by another admin", *deletedAdmin.Username))
+	if err != nil {
+		return err
+	}
+	if deletedAdmin.OIDCSubject != nil {
+		err = usecase.checkRestoreConflict(ctx, entity.Admin{OIDCSubject: deletedAdmin.OIDCSubject}, "oidc subject is already linked to another admin")
+		if err != nil {
+			return err
+		}
+	}
+
+	auditLog := entity.NewAuditLog(audit, entity.RestoreAuditAction, entity.AdminAuditEntityType, deletedAdmin.ID)
+	err = usecase.adminRepository.Restore(ctx, entity.Admin{ID: deletedAdmin.ID}, auditLog)
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return util.NewError(common.ErrorCode.NotFound, err.Error())
+		default:
+			return newRepositoryError(err)
+		}
+	}
+
+	return nil
+}
+
+func (usecase *adminUsecase) Unlock(ctx context.Context, admin entity.Admin, audit entity.Audit) error {
+	currentAdmin, err := usecase.Get(ctx, entity.Admin{ID: admin.ID})
+	if err != nil {
+		return err
+	}
+
+	admin.FailedLogin = new(int)
+	admin.LockUntil = nil
+	auditLog := entity.NewAuditLog(audit, entity.UnlockAuditAction, entity.AdminAuditEntityType, admin.ID).WithChange(currentAdmin, admin)
+	err = usecase.adminRepository.UpdateLock(ctx, admin, auditLog)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (usecase *adminUsecase) Update(ctx context.Context, admin entity.Admin, audit entity.Audit) error {
+	currentAdmin, err := usecase.Get(ctx, entity.Admin{ID: admin.ID})
+	if err != nil {
+		return err
+	}
+	err = checkVersion(admin.Version, currentAdmin.Version)
+	if err != nil {
+		return err
+	}
+
+	// description: admin holding a role the caller could not give is out of reach too, e.g. its password cannot be taken over
+	err = usecase.checkAssignableRole(ctx, currentAdmin.RoleID, audit)
+	if err != nil {
+		return err
+	}
+	if admin.RoleID != nil && (currentAdmin.RoleID == nil || *admin.RoleID != *currentAdmin.RoleID) {
+		if audit.ActorID != nil && *audit.ActorID == *currentAdmin.ID {
+			return errorOwnRoleChange
+		}
+
+		err = usecase.checkAssignableRole(ctx, admin.RoleID, audit)
+		if err != nil {
+			return err
+		}
+	}
+
+	account := passwordAccount{}
 	if admin.Password != nil {
+		account = passwordAccount{adminID: currentAdmin.ID, username: currentAdmin.Username, passwordHash: currentAdmin.PasswordHash}
+		if admin.Username != nil {
+			account.username = admin.Username
+		}
+
+		details, err := checkPassword(ctx, usecase.passwordHistoryRepository, account, *admin.Password)
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+		err = newValidationError(append(checkUsername(admin.Username), details...))
+		if err != nil {
+			return err
+		}
+
 		passwordHash, err := bcrypt.GenerateFromPassword([]byte(*admin.Password), bcrypt.DefaultCost)
 		if err != nil {
-			return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+			return util.NewError(common.ErrorCode.Internal, err.Error())
 		}
+		isResetPassword := true
 
 		admin.PasswordHash = &passwordHash
+		admin.IsResetPassword = &isResetPassword
+	} else {
+		err := newValidationError(checkUsername(admin.Username))
+		if err != nil {
+			return err
+		}
+	}
+
+	auditLog := entity.NewAuditLog(audit, entity.UpdateAuditAction, entity.AdminAuditEntityType, admin.ID).WithChange(currentAdmin, admin)
+	return usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := usecase.adminRepository.Update(ctx, admin, auditLog)
+		if err != nil {
+			switch err {
+			case gorm.ErrRecordNotFound:
+				return util.NewError(common.ErrorCode.VersionMismatch, "version does not match")
+			default:
+				return newRepositoryError(err)
+			}
+		}
+		if admin.Password == nil {
+			return nil
+		}
+
+		err = savePasswordHistory(ctx, usecase.passwordHistoryRepository, account)
+		if err != nil {
+			return err
+		}
+
+		// description: password set by another admin signs out every session held with the previous one
+		return revokeAllTokens(ctx, usecase.refreshTokenRepository, usecase.revokedTokenRepository, admin.ID, nil)
+	})
+}
+
+// description: caller may only give a role whose permissions it holds itself, super admin role only by super admin, nil role gives nothing
+func (usecase *adminUsecase) checkAssignableRole(ctx context.Context, roleID *uint64, audit entity.Audit) error {
+	if roleID == nil {
+		return nil
 	}
 
-	err := usecase.adminRepository.Update(admin)
+	role, err := usecase.roleRepository.Get(ctx, entity.Role{ID: roleID})
 	if err != nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return newValidationError([]util.ErrorDetail{{Field: "role_id", Rule: "exists", Message: "role does not exist"}})
+		default:
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+	if role.Name != nil && entity.RoleName(*role.Name) == entity.SuperAdminRoleName && !audit.HasActorRole(entity.SuperAdminRoleName) {
+		return errorSuperAdminRoleGrant
+	}
+	for _, permissionName := range role.PermissionNames() {
+		if !audit.HasActorPermission(permissionName) {
+			return util.NewError(common.ErrorCode.Forbidden, fmt.Sprintf("%s permission of role is not held by caller", permissionName))
+		}
 	}
 
 	return nil
 }
+
+// description: conflict is an active admin holding a unique value of the deleted one
+func (usecase *adminUsecase) checkRestoreConflict(ctx context.Context, conflict entity.Admin, message string) error {
+	_, err := usecase.adminRepository.Get(ctx, conflict)
+	switch err {
+	case nil:
+		return util.NewError(common.ErrorCode.RestoreConflict, message)
+	case gorm.ErrRecordNotFound:
+		return nil
+	default:
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+}
+
+func (usecase *adminUsecase) getDeleted(ctx context.Context, admin entity.Admin) (entity.Admin, error) {
+	admin, err := usecase.adminRepository.GetDeleted(ctx, admin)
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return entity.Admin{}, util.NewError(common.ErrorCode.NotFound, err.Error())
+		default:
+			return entity.Admin{}, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	return admin, nil
+}
+
+// description: soft deleted admin is counted too so bootstrap cannot be repeated by deleting every admin
+func isAdminExist(ctx context.Context, adminRepository repository.Admin) (bool, error) {
+	deleted := entity.DeletedInclude
+	pagination := entity.Pagination{Limit: 1}
+	_, err := adminRepository.GetAll(ctx, &entity.AdminFilter{DeletedFilter: entity.DeletedFilter{Deleted: &deleted}}, nil, &pagination)
+	if err != nil {
+		return false, err
+	}
+
+	return pagination.RecordCount != nil && *pagination.RecordCount > 0, nil
+}
+
+// description: soft deleted user still references its admin so it is counted too
+func isUserOwner(ctx context.Context, userRepository repository.User, adminID *uint64) (bool, error) {
+	deleted := entity.DeletedInclude
+	pagination := entity.Pagination{Limit: 1}
+	_, err := userRepository.GetAll(ctx,
+		&entity.UserFilter{User: entity.User{AdminID: adminID}, DeletedFilter: entity.DeletedFilter{Deleted: &deleted}},
+		nil,
+		&pagination,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return pagination.RecordCount != nil && *pagination.RecordCount > 0, nil
+}
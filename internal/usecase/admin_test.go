@@ -1,12 +1,14 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/config"
 	"github.com/sndzhng/gin-template/internal/entity"
 	"github.com/sndzhng/gin-template/internal/usecase"
 	"github.com/sndzhng/gin-template/internal/util"
@@ -17,78 +19,277 @@ import (
 
 func beforeTestAdmin(test *testing.T) (
 	*repositorymock.MockAdmin,
+	*repositorymock.MockPasswordHistory,
+	*repositorymock.MockRefreshToken,
+	*repositorymock.MockRevokedToken,
 	*repositorymock.MockRole,
+	*repositorymock.MockUser,
 	usecase.Admin,
 ) {
 	controller := gomock.NewController(test)
 	defer controller.Finish()
 
+	config.JWT.ExpireMinute = "15m"
+
 	mockAdminRepository := repositorymock.NewMockAdmin(controller)
+	mockPasswordHistoryRepository := repositorymock.NewMockPasswordHistory(controller)
+	mockRefreshTokenRepository := repositorymock.NewMockRefreshToken(controller)
+	mockRevokedTokenRepository := repositorymock.NewMockRevokedToken(controller)
 	mockRoleRepository := repositorymock.NewMockRole(controller)
-	adminUsecase := usecase.NewAdminUsecase(mockAdminRepository, mockRoleRepository)
+	mockUserRepository := repositorymock.NewMockUser(controller)
+	adminUsecase := usecase.NewAdminUsecase(mockAdminRepository, mockPasswordHistoryRepository, mockRefreshTokenRepository, mockRevokedTokenRepository, mockRoleRepository, newMockTransaction(controller), mockUserRepository)
+
+	return mockAdminRepository, mockPasswordHistoryRepository, mockRefreshTokenRepository, mockRevokedTokenRepository, mockRoleRepository, mockUserRepository, adminUsecase
+}
+
+// description: run function directly, rollback is left to database so only returned error is asserted
+func newMockTransaction(controller *gomock.Controller) *repositorymock.MockTransaction {
+	mockTransactionRepository := repositorymock.NewMockTransaction(controller)
+	mockTransactionRepository.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fc func(ctx context.Context) error) error {
+			return fc(ctx)
+		},
+	).AnyTimes()
+
+	return mockTransactionRepository
+}
+
+// description: request metadata passed by handler on every audited change
+func testAudit() entity.Audit {
+	actorID := uint64(99)
+	requestID := "request"
+
+	return entity.Audit{
+		ActorID:          &actorID,
+		ActorRoles:       []entity.RoleName{entity.SuperAdminRoleName},
+		ActorPermissions: entity.AllPermissionNames(),
+		RequestID:        &requestID,
+	}
+}
+
+func TestAdminBootstrap(test *testing.T) {
+	mockAdminRepository, _, _, _, mockRoleRepository, _, adminUsecase := beforeTestAdmin(test)
+
+	audit := testAudit()
+	superAdminRoleID := uint64(1)
+	superAdminRoleName := string(entity.SuperAdminRoleName)
+	password := "Correct.Horse42"
+	username := "superadmin01"
+	admin := entity.Admin{Username: &username, Password: &password}
+	recordCount := int64(1)
+
+	expectNoAdmin := func() {
+		mockAdminRepository.EXPECT().GetAll(gomock.Any(), gomock.Any(), nil, gomock.Any()).DoAndReturn(
+			func(_ context.Context, adminFilter *entity.AdminFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.Admin, error) {
+				assert.Equal(test, entity.DeletedInclude, *adminFilter.Deleted)
+				pagination.RecordCount = new(int64)
+				return []entity.Admin{}, nil
+			},
+		)
+	}
+	expectRole := func() {
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{Name: &superAdminRoleName}).Return(entity.Role{ID: &superAdminRoleID, Name: &superAdminRoleName}, nil)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		expectNoAdmin()
+		expectRole()
+		mockAdminRepository.EXPECT().CreateFirst(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) (bool, error) {
+				assert.Equal(test, superAdminRoleID, *admin.RoleID)
+				assert.True(test, *admin.IsResetPassword)
+				assert.Equal(test, entity.CreateAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, "[REDACTED]", auditLogs[0].After["password_hash"])
+				return true, nil
+			},
+		)
+
+		err := adminUsecase.Bootstrap(context.Background(), admin, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Conflict/AdminExist", func(test *testing.T) {
+		mockAdminRepository.EXPECT().GetAll(gomock.Any(), gomock.Any(), nil, gomock.Any()).DoAndReturn(
+			func(_ context.Context, adminFilter *entity.AdminFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.Admin, error) {
+				pagination.RecordCount = &recordCount
+				return []entity.Admin{{Username: &username}}, nil
+			},
+		)
+
+		err := adminUsecase.Bootstrap(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusConflict, err.(util.Error).Status)
+	})
+
+	test.Run("Conflict/Concurrent", func(test *testing.T) {
+		expectNoAdmin()
+		expectRole()
+		mockAdminRepository.EXPECT().CreateFirst(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
+		err := adminUsecase.Bootstrap(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusConflict, err.(util.Error).Status)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		invalidUsername := "admin"
+		expectNoAdmin()
+
+		err := adminUsecase.Bootstrap(context.Background(), entity.Admin{Username: &invalidUsername, Password: &password}, audit)
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+		assert.Equal(test, "username", err.(util.Error).Details[0].Field)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		expectNoAdmin()
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{Name: &superAdminRoleName}).Return(entity.Role{}, gorm.ErrRecordNotFound)
 
-	return mockAdminRepository, mockRoleRepository, adminUsecase
+		err := adminUsecase.Bootstrap(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
 }
 
 func TestAdminCreate(test *testing.T) {
-	mockAdminRepository, _, adminUsecase := beforeTestAdmin(test)
+	mockAdminRepository, _, _, _, mockRoleRepository, _, adminUsecase := beforeTestAdmin(test)
 
+	audit := testAudit()
 	roleID := uint64(1)
-	password := "password"
+	roleName := "SUPPORT"
+	superAdminRoleName := string(entity.SuperAdminRoleName)
+	password := "Correct.Horse42"
 	username := "username"
 	admin := entity.Admin{
 		RoleID:   &roleID,
 		Username: &username,
 		Password: &password,
 	}
+	permissionName := entity.UserWritePermissionName
+	role := entity.Role{ID: &roleID, Name: &roleName, Permissions: []entity.Permission{{Name: &permissionName}}}
+	limitedAudit := audit
+	limitedAudit.ActorRoles = []entity.RoleName{"SUPPORT"}
+	limitedAudit.ActorPermissions = []entity.PermissionName{entity.AdminWritePermissionName}
 
 	test.Run("Success", func(test *testing.T) {
-		mockAdminRepository.EXPECT().Create(gomock.Any()).Return(nil)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &roleID}).Return(role, nil)
+		mockAdminRepository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+				assert.True(test, *admin.IsResetPassword)
+				assert.Equal(test, entity.CreateAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, *audit.ActorID, *auditLogs[0].ActorID)
+				assert.Equal(test, "[REDACTED]", auditLogs[0].After["password_hash"])
+				assert.NotContains(test, auditLogs[0].After, "password")
+				return nil
+			},
+		)
 
-		err := adminUsecase.Create(admin)
+		err := adminUsecase.Create(context.Background(), admin, audit)
 		assert.NoError(test, err)
 	})
 
 	test.Run("InternalError", func(test *testing.T) {
-		mockAdminRepository.EXPECT().Create(gomock.Any()).Return(errors.New("internal error"))
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &roleID}).Return(role, nil)
+		mockAdminRepository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := adminUsecase.Create(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("BadRequest/RoleNotFound", func(test *testing.T) {
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &roleID}).Return(entity.Role{}, gorm.ErrRecordNotFound)
+
+		err := adminUsecase.Create(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+		assert.Equal(test, "role_id", err.(util.Error).Details[0].Field)
+	})
+
+	test.Run("Forbidden/SuperAdminRole", func(test *testing.T) {
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &roleID}).Return(entity.Role{ID: &roleID, Name: &superAdminRoleName}, nil)
 
-		err := adminUsecase.Create(admin)
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		err := adminUsecase.Create(context.Background(), admin, limitedAudit)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
+	})
+
+	test.Run("Forbidden/PermissionNotHeld", func(test *testing.T) {
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &roleID}).Return(role, nil)
+
+		err := adminUsecase.Create(context.Background(), admin, limitedAudit)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
 	})
 
 	test.Run("PasswordIsNil", func(test *testing.T) {
 		admin.Password = nil
 
-		err := adminUsecase.Create(admin)
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		err := adminUsecase.Create(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
 	})
 }
 
 func TestAdminDelete(test *testing.T) {
-	mockAdminRepository, _, adminUsecase := beforeTestAdmin(test)
+	mockAdminRepository, _, mockRefreshTokenRepository, mockRevokedTokenRepository, _, _, adminUsecase := beforeTestAdmin(test)
 
+	audit := testAudit()
 	id := uint64(1)
 	admin := entity.Admin{
 		ID: &id,
 	}
 
 	test.Run("Success", func(test *testing.T) {
-		mockAdminRepository.EXPECT().Delete(admin).Return(nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{ID: &id}, nil)
+		mockAdminRepository.EXPECT().Delete(gomock.Any(), admin, gomock.Any()).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, revokedToken entity.RevokedToken) error {
+				assert.Nil(test, revokedToken.JTI)
+				assert.Equal(test, id, *revokedToken.AdminID)
+				return nil
+			},
+		)
+		mockRefreshTokenRepository.EXPECT().RevokeAll(gomock.Any(), entity.RefreshToken{AdminID: &id}).Return(nil)
 
-		err := adminUsecase.Delete(admin)
+		err := adminUsecase.Delete(context.Background(), admin, audit)
 		assert.NoError(test, err)
 	})
 
+	test.Run("InternalError/RefreshToken", func(test *testing.T) {
+		mockAdminRepository.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{ID: &id}, nil)
+		mockAdminRepository.EXPECT().Delete(gomock.Any(), admin, gomock.Any()).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockRefreshTokenRepository.EXPECT().RevokeAll(gomock.Any(), entity.RefreshToken{AdminID: &id}).Return(errors.New("internal error"))
+
+		err := adminUsecase.Delete(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("InternalError/Transaction", func(test *testing.T) {
+		controller := gomock.NewController(test)
+		mockTransactionRepository := repositorymock.NewMockTransaction(controller)
+		adminUsecase := usecase.NewAdminUsecase(mockAdminRepository, nil, mockRefreshTokenRepository, mockRevokedTokenRepository, nil, mockTransactionRepository, nil)
+
+		mockAdminRepository.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{ID: &id}, nil)
+		mockTransactionRepository.EXPECT().Do(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := adminUsecase.Delete(context.Background(), admin, audit)
+		assert.Error(test, err)
+	})
+
+	test.Run("PreconditionFailed", func(test *testing.T) {
+		version := uint64(2)
+
+		mockAdminRepository.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{ID: &id, Version: &version}, nil)
+		mockAdminRepository.EXPECT().Delete(gomock.Any(), entity.Admin{ID: &id, Version: &version}, gomock.Any()).Return(gorm.ErrRecordNotFound)
+
+		err := adminUsecase.Delete(context.Background(), entity.Admin{ID: &id, Version: &version}, audit)
+		assert.Equal(test, http.StatusPreconditionFailed, err.(util.Error).Status)
+	})
+
 	test.Run("InternalError", func(test *testing.T) {
-		mockAdminRepository.EXPECT().Delete(admin).Return(errors.New("internal error"))
+		mockAdminRepository.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{ID: &id}, nil)
+		mockAdminRepository.EXPECT().Delete(gomock.Any(), admin, gomock.Any()).Return(errors.New("internal error"))
 
-		err := adminUsecase.Delete(admin)
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		err := adminUsecase.Delete(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
 	})
 }
 
 func TestAdminGet(test *testing.T) {
-	mockAdminRepository, _, adminUsecase := beforeTestAdmin(test)
+	mockAdminRepository, _, _, _, _, _, adminUsecase := beforeTestAdmin(test)
 
 	id := uint64(1)
 	username := "username"
@@ -97,7 +298,7 @@ func TestAdminGet(test *testing.T) {
 	}
 
 	test.Run("Success", func(test *testing.T) {
-		mockAdminRepository.EXPECT().Get(admin).Return(
+		mockAdminRepository.EXPECT().Get(gomock.Any(), admin).Return(
 			entity.Admin{
 				ID:       &id,
 				RoleID:   &id,
@@ -106,30 +307,30 @@ func TestAdminGet(test *testing.T) {
 			nil,
 		)
 
-		result, err := adminUsecase.Get(admin)
+		result, err := adminUsecase.Get(context.Background(), admin)
 		assert.NoError(test, err)
 		assert.Equal(test, *result.ID, *admin.ID)
 	})
 
 	test.Run("InternalError", func(test *testing.T) {
-		mockAdminRepository.EXPECT().Get(admin).Return(entity.Admin{}, errors.New("internal error"))
+		mockAdminRepository.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{}, errors.New("internal error"))
 
-		result, err := adminUsecase.Get(admin)
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		result, err := adminUsecase.Get(context.Background(), admin)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
 		assert.Equal(test, result, entity.Admin{})
 	})
 
 	test.Run("RecordNotFound", func(test *testing.T) {
-		mockAdminRepository.EXPECT().Get(admin).Return(entity.Admin{}, gorm.ErrRecordNotFound)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{}, gorm.ErrRecordNotFound)
 
-		result, err := adminUsecase.Get(admin)
-		assert.Equal(test, http.StatusNotFound, err.(util.Error).Code)
+		result, err := adminUsecase.Get(context.Background(), admin)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
 		assert.Equal(test, result, entity.Admin{})
 	})
 }
 
 func TestAdminGetAll(test *testing.T) {
-	mockAdminRepository, _, adminUsecase := beforeTestAdmin(test)
+	mockAdminRepository, _, _, _, _, _, adminUsecase := beforeTestAdmin(test)
 
 	id := uint64(1)
 	username := "username"
@@ -153,47 +354,176 @@ func TestAdminGetAll(test *testing.T) {
 			Offset: 0,
 		}
 
-		mockAdminRepository.EXPECT().GetAll(&adminFilter, &sortOrder, &pagination).Return(admins, nil)
+		mockAdminRepository.EXPECT().GetAll(gomock.Any(), &adminFilter, &sortOrder, &pagination).Return(admins, nil)
 
-		result, err := adminUsecase.GetAll(&adminFilter, &sortOrder, &pagination)
+		result, err := adminUsecase.GetAll(context.Background(), &adminFilter, &sortOrder, &pagination)
 		assert.NoError(test, err)
 		assert.Len(test, result, len(admins))
 	})
 
 	test.Run("InternalError", func(test *testing.T) {
-		mockAdminRepository.EXPECT().GetAll(nil, nil, nil).Return([]entity.Admin{}, errors.New("internal error"))
+		mockAdminRepository.EXPECT().GetAll(gomock.Any(), nil, nil, nil).Return([]entity.Admin{}, errors.New("internal error"))
 
-		result, err := adminUsecase.GetAll(nil, nil, nil)
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		result, err := adminUsecase.GetAll(context.Background(), nil, nil, nil)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
 		assert.Len(test, result, 0)
 	})
 }
 
-func TestAdminInitial(test *testing.T) {
-	mockAdminRepository, mockRoleRepository, adminUsecase := beforeTestAdmin(test)
+func TestAdminPurge(test *testing.T) {
+	mockAdminRepository, _, _, _, _, mockUserRepository, adminUsecase := beforeTestAdmin(test)
+
+	audit := testAudit()
+	id := uint64(1)
+	username := "username"
+	admin := entity.Admin{ID: &id}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAdminRepository.EXPECT().GetDeleted(gomock.Any(), admin).Return(entity.Admin{ID: &id, Username: &username}, nil)
+		mockUserRepository.EXPECT().GetAll(gomock.Any(), gomock.Any(), nil, gomock.Any()).DoAndReturn(
+			func(_ context.Context, userFilter *entity.UserFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.User, error) {
+				assert.Equal(test, id, *userFilter.AdminID)
+				assert.Equal(test, entity.DeletedInclude, *userFilter.Deleted)
+				pagination.RecordCount = new(int64)
+				return []entity.User{}, nil
+			},
+		)
+		mockAdminRepository.EXPECT().Purge(gomock.Any(), admin, gomock.Any()).DoAndReturn(
+			func(_ context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, entity.PurgeAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, username, auditLogs[0].Before["username"])
+				return nil
+			},
+		)
+
+		err := adminUsecase.Purge(context.Background(), admin, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Conflict", func(test *testing.T) {
+		recordCount := int64(1)
+
+		mockAdminRepository.EXPECT().GetDeleted(gomock.Any(), admin).Return(entity.Admin{ID: &id, Username: &username}, nil)
+		mockUserRepository.EXPECT().GetAll(gomock.Any(), gomock.Any(), nil, gomock.Any()).DoAndReturn(
+			func(_ context.Context, userFilter *entity.UserFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.User, error) {
+				pagination.RecordCount = &recordCount
+				return []entity.User{{AdminID: &id}}, nil
+			},
+		)
+
+		err := adminUsecase.Purge(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusConflict, err.(util.Error).Status)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockAdminRepository.EXPECT().GetDeleted(gomock.Any(), admin).Return(entity.Admin{}, gorm.ErrRecordNotFound)
+
+		err := adminUsecase.Purge(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAdminRepository.EXPECT().GetDeleted(gomock.Any(), admin).Return(entity.Admin{ID: &id, Username: &username}, nil)
+		mockUserRepository.EXPECT().GetAll(gomock.Any(), gomock.Any(), nil, gomock.Any()).Return([]entity.User{}, nil)
+		mockAdminRepository.EXPECT().Purge(gomock.Any(), admin, gomock.Any()).Return(errors.New("internal error"))
+
+		err := adminUsecase.Purge(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+}
+
+func TestAdminRestore(test *testing.T) {
+	mockAdminRepository, _, _, _, _, _, adminUsecase := beforeTestAdmin(test)
+
+	audit := testAudit()
+	id := uint64(1)
+	username := "username"
+	oidcSubject := "subject"
+	admin := entity.Admin{ID: &id}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAdminRepository.EXPECT().GetDeleted(gomock.Any(), admin).Return(entity.Admin{ID: &id, Username: &username}, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{Username: &username}).Return(entity.Admin{}, gorm.ErrRecordNotFound)
+		mockAdminRepository.EXPECT().Restore(gomock.Any(), admin, gomock.Any()).DoAndReturn(
+			func(_ context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, entity.RestoreAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, id, *auditLogs[0].EntityID)
+				return nil
+			},
+		)
+
+		err := adminUsecase.Restore(context.Background(), admin, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Conflict", func(test *testing.T) {
+		otherID := uint64(2)
+
+		mockAdminRepository.EXPECT().GetDeleted(gomock.Any(), admin).Return(entity.Admin{ID: &id, Username: &username}, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{Username: &username}).Return(entity.Admin{ID: &otherID, Username: &username}, nil)
+
+		err := adminUsecase.Restore(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusConflict, err.(util.Error).Status)
+	})
+
+	test.Run("Conflict/OIDCSubject", func(test *testing.T) {
+		otherID := uint64(2)
+
+		mockAdminRepository.EXPECT().GetDeleted(gomock.Any(), admin).Return(entity.Admin{ID: &id, Username: &username, OIDCSubject: &oidcSubject}, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{Username: &username}).Return(entity.Admin{}, gorm.ErrRecordNotFound)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{OIDCSubject: &oidcSubject}).Return(entity.Admin{ID: &otherID, OIDCSubject: &oidcSubject}, nil)
+
+		err := adminUsecase.Restore(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusConflict, err.(util.Error).Status)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockAdminRepository.EXPECT().GetDeleted(gomock.Any(), admin).Return(entity.Admin{}, gorm.ErrRecordNotFound)
+
+		err := adminUsecase.Restore(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAdminRepository.EXPECT().GetDeleted(gomock.Any(), admin).Return(entity.Admin{ID: &id, Username: &username}, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{Username: &username}).Return(entity.Admin{}, gorm.ErrRecordNotFound)
+		mockAdminRepository.EXPECT().Restore(gomock.Any(), admin, gomock.Any()).Return(errors.New("internal error"))
+
+		err := adminUsecase.Restore(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+}
+
+func TestAdminUnlock(test *testing.T) {
+	mockAdminRepository, _, _, _, _, _, adminUsecase := beforeTestAdmin(test)
+
+	audit := testAudit()
+	id := uint64(1)
+	admin := entity.Admin{ID: &id}
 
 	test.Run("Success", func(test *testing.T) {
-		mockRoleRepository.EXPECT().Create(gomock.Any()).Return(nil)
-		mockAdminRepository.EXPECT().Create(gomock.Any()).Return(nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{ID: &id}, nil)
+		mockAdminRepository.EXPECT().UpdateLock(gomock.Any(), entity.Admin{ID: &id, FailedLogin: new(int)}, gomock.Any()).Return(nil)
 
-		err := adminUsecase.Initial()
+		err := adminUsecase.Unlock(context.Background(), admin, audit)
 		assert.NoError(test, err)
 	})
 
 	test.Run("InternalError", func(test *testing.T) {
-		mockRoleRepository.EXPECT().Create(gomock.Any()).Return(nil)
-		mockAdminRepository.EXPECT().Create(gomock.Any()).Return(errors.New("internal error"))
+		mockAdminRepository.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{ID: &id}, nil)
+		mockAdminRepository.EXPECT().UpdateLock(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
 
-		err := adminUsecase.Initial()
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		err := adminUsecase.Unlock(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
 	})
 }
 
 func TestAdminUpdate(test *testing.T) {
-	mockAdminRepository, _, adminUsecase := beforeTestAdmin(test)
+	mockAdminRepository, _, mockRefreshTokenRepository, mockRevokedTokenRepository, mockRoleRepository, _, adminUsecase := beforeTestAdmin(test)
 
+	audit := testAudit()
 	id := uint64(1)
-	password := "password"
+	password := "Correct.Horse42"
 	username := "username"
 	admin := entity.Admin{
 		ID:       &id,
@@ -201,18 +531,109 @@ func TestAdminUpdate(test *testing.T) {
 		Username: &username,
 		Password: &password,
 	}
+	roleName := "SUPPORT"
+	role := entity.Role{ID: &id, Name: &roleName}
 
 	test.Run("Success", func(test *testing.T) {
-		mockAdminRepository.EXPECT().Update(gomock.Any()).Return(nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{ID: &id, Username: &username}, nil)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &id}).Return(role, nil)
+		mockAdminRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, entity.UpdateAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, id, *auditLogs[0].EntityID)
+				assert.Equal(test, entity.AuditChange{"role_id": nil, "password_hash": nil, "is_reset_password": nil}, auditLogs[0].Before)
+				assert.Equal(test, entity.AuditChange{"role_id": id, "password_hash": "[REDACTED]", "is_reset_password": true}, auditLogs[0].After)
+				return nil
+			},
+		)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, revokedToken entity.RevokedToken) error {
+				assert.Equal(test, id, *revokedToken.AdminID)
+				return nil
+			},
+		)
+		mockRefreshTokenRepository.EXPECT().RevokeAll(gomock.Any(), entity.RefreshToken{AdminID: &id}).Return(nil)
 
-		err := adminUsecase.Update(admin)
+		err := adminUsecase.Update(context.Background(), admin, audit)
 		assert.NoError(test, err)
 	})
 
+	test.Run("Success/WithoutPassword", func(test *testing.T) {
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{ID: &id, RoleID: &id, Username: &username}, nil)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &id}).Return(role, nil)
+		mockAdminRepository.EXPECT().Update(gomock.Any(), entity.Admin{ID: &id, RoleID: &id, Username: &username}, gomock.Any()).Return(nil)
+
+		err := adminUsecase.Update(context.Background(), entity.Admin{ID: &id, RoleID: &id, Username: &username}, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("PreconditionFailed", func(test *testing.T) {
+		version, currentVersion := uint64(1), uint64(2)
+		versionedAdmin := admin
+		versionedAdmin.Version = &version
+
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{ID: &id, Username: &username, Version: &currentVersion}, nil)
+
+		err := adminUsecase.Update(context.Background(), versionedAdmin, audit)
+		assert.Equal(test, http.StatusPreconditionFailed, err.(util.Error).Status)
+	})
+
+	test.Run("BadRequest/PasswordPolicy", func(test *testing.T) {
+		config.Password = config.PasswordConfig{MinLength: "20"}
+		usecase.InitialPasswordPolicy()
+		defer func() {
+			config.Password = config.PasswordConfig{}
+			usecase.InitialPasswordPolicy()
+		}()
+
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{ID: &id, Username: &username}, nil)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &id}).Return(role, nil)
+
+		err := adminUsecase.Update(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+		assert.Equal(test, "min_length", err.(util.Error).Details[0].Rule)
+	})
+
 	test.Run("InternalError", func(test *testing.T) {
-		mockAdminRepository.EXPECT().Update(gomock.Any()).Return(errors.New("internal error"))
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{ID: &id, Username: &username}, nil)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &id}).Return(role, nil)
+		mockAdminRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := adminUsecase.Update(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("InternalError/RevokedToken", func(test *testing.T) {
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{ID: &id, Username: &username}, nil)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &id}).Return(role, nil)
+		mockAdminRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := adminUsecase.Update(context.Background(), admin, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("Forbidden/OwnRole", func(test *testing.T) {
+		ownAudit := audit
+		ownAudit.ActorID = &id
+
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{ID: &id, Username: &username}, nil)
+
+		err := adminUsecase.Update(context.Background(), admin, ownAudit)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
+	})
+
+	test.Run("Forbidden/SuperAdminTarget", func(test *testing.T) {
+		superAdminRoleName := string(entity.SuperAdminRoleName)
+		limitedAudit := audit
+		limitedAudit.ActorRoles = []entity.RoleName{"SUPPORT"}
+		limitedAudit.ActorPermissions = []entity.PermissionName{entity.AdminWritePermissionName}
+		superAdminID := uint64(2)
+
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{ID: &id, RoleID: &superAdminID, Username: &username}, nil)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &superAdminID}).Return(entity.Role{ID: &superAdminID, Name: &superAdminRoleName}, nil)
 
-		err := adminUsecase.Update(admin)
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		err := adminUsecase.Update(context.Background(), entity.Admin{ID: &id, Password: &password}, limitedAudit)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
 	})
 }
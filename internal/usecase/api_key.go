@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/util"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=usecasemock -destination=../../mock/usecase/api_key.go . APIKey
+
+const apiKeyPrefix = "gtk"
+
+type (
+	APIKey interface {
+		Create(ctx context.Context, apiKey entity.APIKey, audit entity.Audit) (entity.APIKey, error)
+		Get(ctx context.Context, apiKey entity.APIKey) (entity.APIKey, error)
+		GetAll(ctx context.Context, apiKeyFilter *entity.APIKeyFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.APIKey, error)
+		Revoke(ctx context.Context, apiKey entity.APIKey, audit entity.Audit) error
+	}
+	apiKeyUsecase struct {
+		apiKeyRepository repository.APIKey
+	}
+)
+
+func NewAPIKeyUsecase(apiKeyRepository repository.APIKey) APIKey {
+	return &apiKeyUsecase{apiKeyRepository: apiKeyRepository}
+}
+
+// description: key is prefix and random secret, plain key is only present in the create response
+func (usecase *apiKeyUsecase) Create(ctx context.Context, apiKey entity.APIKey, audit entity.Audit) (entity.APIKey, error) {
+	if apiKey.ExpireAt != nil && !apiKey.ExpireAt.After(time.Now()) {
+		return entity.APIKey{}, newValidationError([]util.ErrorDetail{{Field: "expire_at", Rule: "future", Message: "expire at must be in the future"}})
+	}
+
+	prefixBytes := make([]byte, 4)
+	_, err := rand.Read(prefixBytes)
+	if err != nil {
+		return entity.APIKey{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	secret, err := generateRandomString(32)
+	if err != nil {
+		return entity.APIKey{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	prefix := fmt.Sprintf("%s_%s", apiKeyPrefix, hex.EncodeToString(prefixBytes))
+	key := fmt.Sprintf("%s_%s", prefix, secret)
+	keyHash := middleware.HashAPIKey(key)
+
+	apiKey.Prefix = &prefix
+	apiKey.KeyHash = &keyHash
+	auditLog := entity.NewAuditLog(audit, entity.CreateAuditAction, entity.APIKeyAuditEntityType, nil).WithChange(nil, apiKey)
+	auditLog.After["scopes"] = apiKey.Scopes
+	err = usecase.apiKeyRepository.Create(ctx, apiKey, auditLog)
+	if err != nil {
+		return entity.APIKey{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	apiKey, err = usecase.apiKeyRepository.Get(ctx, entity.APIKey{KeyHash: &keyHash})
+	if err != nil {
+		return entity.APIKey{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	apiKey.Key = &key
+
+	return apiKey, nil
+}
+
+func (usecase *apiKeyUsecase) Get(ctx context.Context, apiKey entity.APIKey) (entity.APIKey, error) {
+	apiKey, err := usecase.apiKeyRepository.Get(ctx, apiKey)
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return entity.APIKey{}, util.NewError(common.ErrorCode.NotFound, err.Error())
+		default:
+			return entity.APIKey{}, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	return apiKey, nil
+}
+
+func (usecase *apiKeyUsecase) GetAll(ctx context.Context, apiKeyFilter *entity.APIKeyFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.APIKey, error) {
+	apiKeys, err := usecase.apiKeyRepository.GetAll(ctx, apiKeyFilter, sortOrder, pagination)
+	if err != nil {
+		return []entity.APIKey{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	if pagination != nil {
+		pagination.CalculateTotal()
+	}
+
+	return apiKeys, nil
+}
+
+func (usecase *apiKeyUsecase) Revoke(ctx context.Context, apiKey entity.APIKey, audit entity.Audit) error {
+	auditLog := entity.NewAuditLog(audit, entity.RevokeAuditAction, entity.APIKeyAuditEntityType, apiKey.ID)
+	err := usecase.apiKeyRepository.Revoke(ctx, apiKey, auditLog)
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return util.NewError(common.ErrorCode.NotFound, err.Error())
+		default:
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	return nil
+}
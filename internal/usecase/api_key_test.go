@@ -0,0 +1,189 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+	repositorymock "github.com/sndzhng/gin-template/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func beforeTestAPIKey(test *testing.T) (
+	*repositorymock.MockAPIKey,
+	usecase.APIKey,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	mockAPIKeyRepository := repositorymock.NewMockAPIKey(controller)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(mockAPIKeyRepository)
+
+	return mockAPIKeyRepository, apiKeyUsecase
+}
+
+func TestAPIKeyCreate(test *testing.T) {
+	mockAPIKeyRepository, apiKeyUsecase := beforeTestAPIKey(test)
+
+	audit := testAudit()
+	id := uint64(1)
+	name := "batch"
+	apiKey := entity.APIKey{
+		AdminID: &id,
+		Name:    &name,
+		Scopes:  []entity.APIKeyScope{entity.ReadAPIKeyScope},
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		prefix, keyHash := "", ""
+
+		mockAPIKeyRepository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, apiKey entity.APIKey, auditLogs ...entity.AuditLog) error {
+				prefix, keyHash = *apiKey.Prefix, *apiKey.KeyHash
+				assert.Nil(test, apiKey.Key)
+				assert.Equal(test, entity.CreateAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, entity.APIKeyAuditEntityType, *auditLogs[0].EntityType)
+				assert.Equal(test, "[REDACTED]", auditLogs[0].After["key_hash"])
+				return nil
+			},
+		)
+		mockAPIKeyRepository.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, apiKey entity.APIKey) (entity.APIKey, error) {
+				assert.Equal(test, keyHash, *apiKey.KeyHash)
+				return entity.APIKey{ID: &id, AdminID: &id, Name: &name, Prefix: &prefix, KeyHash: &keyHash}, nil
+			},
+		)
+
+		result, err := apiKeyUsecase.Create(context.Background(), apiKey, audit)
+		assert.NoError(test, err)
+		assert.Equal(test, id, *result.ID)
+		assert.True(test, strings.HasPrefix(prefix, "gtk_"))
+		assert.True(test, strings.HasPrefix(*result.Key, prefix+"_"))
+		assert.Equal(test, keyHash, middleware.HashAPIKey(*result.Key))
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAPIKeyRepository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		_, err := apiKeyUsecase.Create(context.Background(), apiKey, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("BadRequest/ExpireAt", func(test *testing.T) {
+		expireAt := time.Now().Add(-time.Minute)
+		apiKey := apiKey
+		apiKey.ExpireAt = &expireAt
+
+		_, err := apiKeyUsecase.Create(context.Background(), apiKey, audit)
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+		assert.Equal(test, "future", err.(util.Error).Details[0].Rule)
+	})
+}
+
+func TestAPIKeyGet(test *testing.T) {
+	mockAPIKeyRepository, apiKeyUsecase := beforeTestAPIKey(test)
+
+	id := uint64(1)
+	apiKey := entity.APIKey{ID: &id}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAPIKeyRepository.EXPECT().Get(gomock.Any(), apiKey).Return(apiKey, nil)
+
+		result, err := apiKeyUsecase.Get(context.Background(), apiKey)
+		assert.NoError(test, err)
+		assert.Equal(test, apiKey, result)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAPIKeyRepository.EXPECT().Get(gomock.Any(), apiKey).Return(entity.APIKey{}, errors.New("internal error"))
+
+		_, err := apiKeyUsecase.Get(context.Background(), apiKey)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("RecordNotFound", func(test *testing.T) {
+		mockAPIKeyRepository.EXPECT().Get(gomock.Any(), apiKey).Return(entity.APIKey{}, gorm.ErrRecordNotFound)
+
+		_, err := apiKeyUsecase.Get(context.Background(), apiKey)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+}
+
+func TestAPIKeyGetAll(test *testing.T) {
+	mockAPIKeyRepository, apiKeyUsecase := beforeTestAPIKey(test)
+
+	id := uint64(1)
+	apiKeys := []entity.APIKey{{ID: &id, AdminID: &id}}
+
+	test.Run("Success", func(test *testing.T) {
+		isActive := true
+		apiKeyFilter := entity.APIKeyFilter{
+			APIKey:   entity.APIKey{AdminID: &id},
+			IsActive: &isActive,
+		}
+		sortOrder := entity.InitialSortOrder()
+		pagination := entity.Pagination{
+			Limit:  1,
+			Offset: 0,
+		}
+
+		mockAPIKeyRepository.EXPECT().GetAll(gomock.Any(), &apiKeyFilter, &sortOrder, &pagination).Return(apiKeys, nil)
+
+		result, err := apiKeyUsecase.GetAll(context.Background(), &apiKeyFilter, &sortOrder, &pagination)
+		assert.NoError(test, err)
+		assert.Len(test, result, len(apiKeys))
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAPIKeyRepository.EXPECT().GetAll(gomock.Any(), nil, nil, nil).Return([]entity.APIKey{}, errors.New("internal error"))
+
+		result, err := apiKeyUsecase.GetAll(context.Background(), nil, nil, nil)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+		assert.Len(test, result, 0)
+	})
+}
+
+func TestAPIKeyRevoke(test *testing.T) {
+	mockAPIKeyRepository, apiKeyUsecase := beforeTestAPIKey(test)
+
+	audit := testAudit()
+	id := uint64(1)
+	apiKey := entity.APIKey{ID: &id}
+
+	test.Run("Success", func(test *testing.T) {
+		mockAPIKeyRepository.EXPECT().Revoke(gomock.Any(), apiKey, gomock.Any()).DoAndReturn(
+			func(_ context.Context, apiKey entity.APIKey, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, entity.RevokeAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, entity.APIKeyAuditEntityType, *auditLogs[0].EntityType)
+				assert.Equal(test, id, *auditLogs[0].EntityID)
+				return nil
+			},
+		)
+
+		err := apiKeyUsecase.Revoke(context.Background(), apiKey, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAPIKeyRepository.EXPECT().Revoke(gomock.Any(), apiKey, gomock.Any()).Return(errors.New("internal error"))
+
+		err := apiKeyUsecase.Revoke(context.Background(), apiKey, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("RecordNotFound", func(test *testing.T) {
+		mockAPIKeyRepository.EXPECT().Revoke(gomock.Any(), apiKey, gomock.Any()).Return(gorm.ErrRecordNotFound)
+
+		err := apiKeyUsecase.Revoke(context.Background(), apiKey, audit)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+//go:generate mockgen -package=usecasemock -destination=../../mock/usecase/audit_log.go . AuditLog
+
+type (
+	AuditLog interface {
+		GetAll(ctx context.Context, auditLogFilter *entity.AuditLogFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.AuditLog, error)
+	}
+	auditLogUsecase struct {
+		auditLogRepository repository.AuditLog
+	}
+)
+
+func NewAuditLogUsecase(auditLogRepository repository.AuditLog) AuditLog {
+	return &auditLogUsecase{auditLogRepository: auditLogRepository}
+}
+
+func (usecase *auditLogUsecase) GetAll(ctx context.Context, auditLogFilter *entity.AuditLogFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.AuditLog, error) {
+	auditLogs, err := usecase.auditLogRepository.GetAll(ctx, auditLogFilter, sortOrder, pagination)
+	if err != nil {
+		return []entity.AuditLog{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	if pagination != nil {
+		pagination.CalculateTotal()
+	}
+
+	return auditLogs, nil
+}
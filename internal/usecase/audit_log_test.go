@@ -0,0 +1,63 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+	repositorymock "github.com/sndzhng/gin-template/mock/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func beforeTestAuditLog(test *testing.T) (
+	*repositorymock.MockAuditLog,
+	usecase.AuditLog,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	mockAuditLogRepository := repositorymock.NewMockAuditLog(controller)
+	auditLogUsecase := usecase.NewAuditLogUsecase(mockAuditLogRepository)
+
+	return mockAuditLogRepository, auditLogUsecase
+}
+
+func TestAuditLogGetAll(test *testing.T) {
+	mockAuditLogRepository, auditLogUsecase := beforeTestAuditLog(test)
+
+	id := uint64(1)
+	action := entity.DeleteAuditAction
+	auditLogs := []entity.AuditLog{{ID: &id, ActorID: &id, Action: &action}}
+
+	test.Run("Success", func(test *testing.T) {
+		auditLogFilter := entity.AuditLogFilter{AuditLog: entity.AuditLog{ActorID: &id}}
+		sortOrder := entity.InitialSortOrder()
+		pagination := entity.Pagination{Limit: 1}
+
+		mockAuditLogRepository.EXPECT().GetAll(gomock.Any(), &auditLogFilter, &sortOrder, &pagination).DoAndReturn(
+			func(_ context.Context, auditLogFilter *entity.AuditLogFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.AuditLog, error) {
+				recordCount := int64(3)
+				pagination.RecordCount = &recordCount
+				return auditLogs, nil
+			},
+		)
+
+		result, err := auditLogUsecase.GetAll(context.Background(), &auditLogFilter, &sortOrder, &pagination)
+		assert.NoError(test, err)
+		assert.Len(test, result, len(auditLogs))
+		assert.Equal(test, 3, *pagination.Total)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAuditLogRepository.EXPECT().GetAll(gomock.Any(), nil, nil, nil).Return([]entity.AuditLog{}, errors.New("internal error"))
+
+		result, err := auditLogUsecase.GetAll(context.Background(), nil, nil, nil)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+		assert.Len(test, result, 0)
+	})
+}
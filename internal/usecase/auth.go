@@ -1,11 +1,23 @@
 package usecase
 
 import (
-	"net/http"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"time"
 
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/config"
 	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/identity"
 	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/sndzhng/gin-template/internal/notifier"
 	"github.com/sndzhng/gin-template/internal/repository"
 	"github.com/sndzhng/gin-template/internal/util"
 	"golang.org/x/crypto/bcrypt"
@@ -14,117 +26,1084 @@ import (
 
 //go:generate mockgen -package=usecasemock -destination=../../mock/usecase/auth.go . Auth
 
+const (
+	loginLockAttempt  = 5
+	loginLockDuration = time.Minute
+	loginLockMaximum  = 24 * time.Hour
+	oidcStateDuration = 10 * time.Minute
+)
+
+var (
+	// description: compared when username not found so response time does not reveal existing usernames
+	dummyPasswordHash = []byte("$2a$10$AqAqCfuVE99k3HvwngaPuubxVN0u3CDg3drWreYc8xfiKEaj268B2")
+	errorLoginFailed  = util.NewError(common.ErrorCode.LoginFailed, "invalid username or password")
+	errorMFATokenUsed = util.NewError(common.ErrorCode.InvalidMFAToken, "mfa token is invalid or already used")
+	errorOIDCDisabled = util.NewError(common.ErrorCode.OIDCDisabled, "oidc login is not configured")
+	errorOIDCState    = util.NewError(common.ErrorCode.InvalidOIDCState, "invalid oidc state")
+)
+
 type (
 	Auth interface {
-		AdminLogin(login entity.Login) (entity.AccessToken, error)
-		UserLogin(login entity.Login) (entity.AccessToken, error)
-		UserReset(reset entity.Reset) error
+		AdminLogin(ctx context.Context, login entity.Login) (entity.AccessToken, error)
+		AdminOIDCCallback(ctx context.Context, oidcCallback entity.OIDCCallback) (entity.AccessToken, error)
+		AdminOIDCLogin(ctx context.Context) (string, error)
+		AdminRefresh(ctx context.Context, refresh entity.Refresh) (entity.AccessToken, error)
+		AdminReset(ctx context.Context, reset entity.Reset, audit entity.Audit) error
+		AdminVerifyMFA(ctx context.Context, mfa entity.MFA, audit entity.Audit) (entity.AccessToken, error)
+		Logout(ctx context.Context, logout entity.Logout) error
+		LogoutAll(ctx context.Context, logout entity.Logout) error
+		UserForgot(ctx context.Context, forgot entity.Forgot) error
+		UserForgotConfirm(ctx context.Context, forgotConfirm entity.ForgotConfirm, audit entity.Audit) error
+		UserLogin(ctx context.Context, login entity.Login) (entity.AccessToken, error)
+		UserRefresh(ctx context.Context, refresh entity.Refresh) (entity.AccessToken, error)
+		UserReset(ctx context.Context, reset entity.Reset, audit entity.Audit) error
+		UserVerifyMFA(ctx context.Context, mfa entity.MFA, audit entity.Audit) (entity.AccessToken, error)
 	}
 	authUsecase struct {
-		adminRepository repository.Admin
-		userRepository  repository.User
+		adminRepository              repository.Admin
+		oidcStateRepository          repository.OIDCState
+		passwordHistoryRepository    repository.PasswordHistory
+		passwordResetTokenRepository repository.PasswordResetToken
+		policyRepository             repository.Policy
+		recoveryCodeRepository       repository.RecoveryCode
+		refreshTokenRepository       repository.RefreshToken
+		revokedTokenRepository       repository.RevokedToken
+		roleRepository               repository.Role
+		sessionRepository            repository.Session
+		transactionRepository        repository.Transaction
+		userRepository               repository.User
+		notifier                     notifier.Notifier
+		provider                     identity.Provider
 	}
 )
 
 func NewAuthUsecase(
 	adminRepository repository.Admin,
+	oidcStateRepository repository.OIDCState,
+	passwordHistoryRepository repository.PasswordHistory,
+	passwordResetTokenRepository repository.PasswordResetToken,
+	policyRepository repository.Policy,
+	recoveryCodeRepository repository.RecoveryCode,
+	refreshTokenRepository repository.RefreshToken,
+	revokedTokenRepository repository.RevokedToken,
+	roleRepository repository.Role,
+	sessionRepository repository.Session,
+	transactionRepository repository.Transaction,
 	userRepository repository.User,
+	notifier notifier.Notifier,
+	provider identity.Provider,
 ) Auth {
 	return &authUsecase{
-		adminRepository: adminRepository,
-		userRepository:  userRepository,
+		adminRepository:              adminRepository,
+		oidcStateRepository:          oidcStateRepository,
+		passwordHistoryRepository:    passwordHistoryRepository,
+		passwordResetTokenRepository: passwordResetTokenRepository,
+		policyRepository:             policyRepository,
+		recoveryCodeRepository:       recoveryCodeRepository,
+		refreshTokenRepository:       refreshTokenRepository,
+		revokedTokenRepository:       revokedTokenRepository,
+		roleRepository:               roleRepository,
+		sessionRepository:            sessionRepository,
+		transactionRepository:        transactionRepository,
+		userRepository:               userRepository,
+		notifier:                     notifier,
+		provider:                     provider,
 	}
 }
 
-func (usecase *authUsecase) AdminLogin(login entity.Login) (entity.AccessToken, error) {
+func (usecase *authUsecase) AdminLogin(ctx context.Context, login entity.Login) (entity.AccessToken, error) {
 	admin := entity.Admin{Username: login.Username}
-	admin, err := usecase.adminRepository.Get(admin)
+	admin, err := usecase.adminRepository.Get(ctx, admin)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return entity.AccessToken{}, util.Error{Code: http.StatusNotFound, Message: err.Error()}
+			_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(*login.Password))
+			return entity.AccessToken{}, errorLoginFailed
 		} else {
-			return entity.AccessToken{}, util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+			return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
 		}
 	}
+	if admin.IsLocked() {
+		return entity.AccessToken{}, errorLoginFailed
+	}
 
 	err = bcrypt.CompareHashAndPassword(*admin.PasswordHash, []byte(*login.Password))
 	if err != nil {
-		return entity.AccessToken{}, util.Error{Code: http.StatusUnauthorized}
+		err = increaseFailedLogin(ctx, usecase.adminRepository, usecase.transactionRepository, usecase.userRepository, admin.ID, nil)
+		if err != nil {
+			return entity.AccessToken{}, err
+		}
+
+		return entity.AccessToken{}, errorLoginFailed
+	}
+	if isFailedLogin(admin.FailedLogin, admin.LockUntil) {
+		admin.FailedLogin, admin.LockUntil = new(int), nil
+		err = usecase.adminRepository.UpdateLock(ctx, admin)
+		if err != nil {
+			return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	return usecase.loginAdmin(ctx, admin, login.Device)
+}
+
+// description: consume state once, provision admin on first login and sync role from identity provider groups
+func (usecase *authUsecase) AdminOIDCCallback(ctx context.Context, oidcCallback entity.OIDCCallback) (entity.AccessToken, error) {
+	if usecase.provider == nil {
+		return entity.AccessToken{}, errorOIDCDisabled
+	}
+	if oidcCallback.Error != nil {
+		message := *oidcCallback.Error
+		if oidcCallback.ErrorDescription != nil {
+			message = fmt.Sprintf("%s: %s", message, *oidcCallback.ErrorDescription)
+		}
+
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.OIDCLoginFailed, message)
+	}
+	if oidcCallback.Code == nil || oidcCallback.State == nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.OIDCCodeRequired, "code and state are required")
+	}
+
+	stateHash := hashToken(*oidcCallback.State)
+	oidcState := entity.OIDCState{StateHash: &stateHash}
+	oidcState, err := usecase.oidcStateRepository.Get(ctx, oidcState)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.AccessToken{}, errorOIDCState
+		} else {
+			return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+	if oidcState.IsUsed() || oidcState.IsExpired() {
+		return entity.AccessToken{}, errorOIDCState
+	}
+
+	err = usecase.oidcStateRepository.Use(ctx, entity.OIDCState{ID: oidcState.ID})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.AccessToken{}, errorOIDCState
+		} else {
+			return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	oidcIdentity, err := usecase.provider.Exchange(*oidcCallback.Code, *oidcState.Nonce, *oidcState.CodeVerifier)
+	if err != nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.Unauthorized, err.Error())
+	}
+
+	roleName, err := mapOIDCRole(config.OIDC.RoleMapping, oidcIdentity.Groups)
+	if err != nil {
+		return entity.AccessToken{}, err
+	}
+	roleNameString := string(roleName)
+	role := entity.Role{Name: &roleNameString}
+	role, err = usecase.roleRepository.Get(ctx, role)
+	if err != nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	admin, err := usecase.provisionOIDCAdmin(ctx, oidcIdentity, role)
+	if err != nil {
+		return entity.AccessToken{}, err
+	}
+	if admin.IsLocked() {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.AccountLocked, "admin is locked")
+	}
+
+	return usecase.loginAdmin(ctx, admin, oidcCallback.Device)
+}
+
+// description: return authorization url, state, nonce and pkce verifier are kept server side until callback
+func (usecase *authUsecase) AdminOIDCLogin(ctx context.Context) (string, error) {
+	if usecase.provider == nil {
+		return "", errorOIDCDisabled
+	}
+
+	state, err := generateRandomString(32)
+	if err != nil {
+		return "", util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	nonce, err := generateRandomString(32)
+	if err != nil {
+		return "", util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	codeVerifier, err := generateRandomString(32)
+	if err != nil {
+		return "", util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	stateHash := hashToken(state)
+	expireAt := time.Now().Add(oidcStateDuration)
+	oidcState := entity.OIDCState{
+		StateHash:    &stateHash,
+		CodeVerifier: &codeVerifier,
+		Nonce:        &nonce,
+		ExpireAt:     &expireAt,
+	}
+	err = usecase.oidcStateRepository.Create(ctx, oidcState)
+	if err != nil {
+		return "", util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return usecase.provider.AuthCodeURL(state, nonce, codeVerifier), nil
+}
+
+func (usecase *authUsecase) AdminRefresh(ctx context.Context, refresh entity.Refresh) (entity.AccessToken, error) {
+	admin := entity.Admin{}
+	checkAccount := func(ctx context.Context, refreshToken entity.RefreshToken) error {
+		if refreshToken.AdminID == nil {
+			return util.NewError(common.ErrorCode.InvalidRefreshToken, "invalid refresh token")
+		}
+
+		err := error(nil)
+		admin, err = usecase.adminRepository.Get(ctx, entity.Admin{ID: refreshToken.AdminID})
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return util.NewError(common.ErrorCode.Unauthorized, err.Error())
+			} else {
+				return util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+		}
+		if admin.IsLocked() {
+			return util.NewError(common.ErrorCode.AccountLocked, "admin is locked")
+		}
+
+		return nil
+	}
+
+	return usecase.refreshToken(ctx, refresh, checkAccount, func(ctx context.Context, refreshToken entity.RefreshToken) (entity.AccessToken, error) {
+		roles, permissions, err := getAdminRoles(admin)
+		if err != nil {
+			return entity.AccessToken{}, err
+		}
+
+		return usecase.issueToken(ctx, *admin.ID, isResetPassword(admin.IsResetPassword), entity.RefreshToken{AdminID: admin.ID, FamilyID: refreshToken.FamilyID}, refresh.Device, roles, permissions)
+	})
+}
+
+func (usecase *authUsecase) AdminReset(ctx context.Context, reset entity.Reset, audit entity.Audit) error {
+	admin := entity.Admin{ID: reset.ID}
+	admin, err := usecase.adminRepository.Get(ctx, admin)
+	if err != nil {
+		return util.NewError(common.ErrorCode.NotFound, err.Error())
+	}
+
+	account := passwordAccount{adminID: admin.ID, username: admin.Username, passwordHash: admin.PasswordHash}
+	details, err := checkPassword(ctx, usecase.passwordHistoryRepository, account, *reset.Password)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	err = newValidationError(details)
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(*reset.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	isResetPassword := false
+
+	resetAdmin := entity.Admin{ID: admin.ID, PasswordHash: &passwordHash, IsResetPassword: &isResetPassword}
+	auditLog := entity.NewAuditLog(audit, entity.ResetPasswordAuditAction, entity.AdminAuditEntityType, admin.ID).WithChange(admin, resetAdmin)
+	return usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := usecase.adminRepository.Update(ctx, resetAdmin, auditLog)
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+
+		return savePasswordHistory(ctx, usecase.passwordHistoryRepository, account)
+	})
+}
+
+func (usecase *authUsecase) AdminVerifyMFA(ctx context.Context, mfa entity.MFA, audit entity.Audit) (entity.AccessToken, error) {
+	mfa.AdminID, mfa.UserID = nil, nil
+	account, err := getMFAAccount(ctx, usecase.adminRepository, usecase.userRepository, mfa)
+	if err != nil {
+		return entity.AccessToken{}, err
+	}
+	if account.adminID == nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.InvalidMFAToken, "invalid mfa token")
+	}
+
+	recoveryCodes, err := usecase.verifyMFA(ctx, account, mfa, audit)
+	if err != nil {
+		return entity.AccessToken{}, err
 	}
 
-	roles := []entity.RoleName{}
-	switch *admin.RoleID {
-	case 1:
-		roles = append(roles, entity.SuperAdminRoleName)
+	admin := entity.Admin{ID: account.adminID}
+	admin, err = usecase.adminRepository.Get(ctx, admin)
+	if err != nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	roles, permissions, err := getAdminRoles(admin)
+	if err != nil {
+		return entity.AccessToken{}, err
+	}
+
+	return usecase.completeAdminLogin(ctx, admin, roles, permissions, recoveryCodes, mfa.Device)
+}
+
+func (usecase *authUsecase) Logout(ctx context.Context, logout entity.Logout) error {
+	if logout.JTI == nil || logout.ExpireAt == nil {
+		return util.NewError(common.ErrorCode.Unauthorized, "token without jti")
+	}
+
+	revokedToken := entity.RevokedToken{
+		JTI:      logout.JTI,
+		AdminID:  logout.AdminID,
+		UserID:   logout.UserID,
+		ExpireAt: logout.ExpireAt,
+	}
+	err := usecase.revokedTokenRepository.Create(ctx, revokedToken)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	if logout.SessionID != nil {
+		session := entity.Session{ID: logout.SessionID, AdminID: logout.AdminID, UserID: logout.UserID}
+		session, err = usecase.sessionRepository.Get(ctx, session)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+		if err == nil {
+			err = usecase.refreshTokenRepository.RevokeFamily(ctx, *session.FamilyID)
+			if err != nil {
+				return util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+		}
+	}
+
+	if logout.RefreshToken != nil {
+		tokenHash := hashToken(*logout.RefreshToken)
+		refreshToken := entity.RefreshToken{
+			AdminID:   logout.AdminID,
+			UserID:    logout.UserID,
+			TokenHash: &tokenHash,
+		}
+		refreshToken, err = usecase.refreshTokenRepository.Get(ctx, refreshToken)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			} else {
+				return util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+		}
+
+		err = usecase.refreshTokenRepository.RevokeFamily(ctx, *refreshToken.FamilyID)
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (usecase *authUsecase) LogoutAll(ctx context.Context, logout entity.Logout) error {
+	return revokeAllTokens(ctx, usecase.refreshTokenRepository, usecase.revokedTokenRepository, logout.AdminID, logout.UserID)
+}
+
+// description: always succeed for unknown username so response does not reveal existing accounts, failure after account is found is only logged for the same reason
+func (usecase *authUsecase) UserForgot(ctx context.Context, forgot entity.Forgot) error {
+	resetExpireMinute, err := time.ParseDuration(config.Password.ResetExpireMinute)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	user := entity.User{Username: forgot.Username}
+	user, err = usecase.userRepository.Get(ctx, user)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		} else {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	notification := entity.Notification{Subject: "Reset password"}
+	switch {
+	case user.Email != nil && *user.Email != "":
+		notification.Channel = entity.EmailNotificationChannel
+		notification.Recipient = *user.Email
+	case user.Phone != nil && *user.Phone != "":
+		notification.Channel = entity.SMSNotificationChannel
+		notification.Recipient = *user.Phone
 	default:
-		return entity.AccessToken{}, util.Error{Code: http.StatusInternalServerError, Message: "invalid role"}
+		return nil
 	}
 
-	accessToken, err := middleware.GenerateJWT(*admin.ID, roles...)
+	token, err := generateRandomString(32)
 	if err != nil {
-		return entity.AccessToken{}, util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		log.Printf("Error forgot password of user %d: %s", *user.ID, err)
+		return nil
 	}
+	tokenHash := hashToken(token)
+	expireAt := time.Now().Add(resetExpireMinute)
 
-	currentTime := time.Now()
-	admin.LastLoginAt = &currentTime
-	err = usecase.adminRepository.Update(admin)
+	// description: earlier tokens stay usable when the new one is not stored
+	err = usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := usecase.passwordResetTokenRepository.UseAll(ctx, entity.PasswordResetToken{UserID: user.ID})
+		if err != nil {
+			return err
+		}
+
+		return usecase.passwordResetTokenRepository.Create(ctx, entity.PasswordResetToken{
+			UserID:    user.ID,
+			TokenHash: &tokenHash,
+			ExpireAt:  &expireAt,
+		})
+	})
 	if err != nil {
-		return entity.AccessToken{}, util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		log.Printf("Error forgot password of user %d: %s", *user.ID, err)
+		return nil
 	}
 
-	return entity.AccessToken{AccessToken: &accessToken}, nil
+	notification.Body = fmt.Sprintf("Your password reset token is %s, it expires in %s.", token, resetExpireMinute)
+	err = usecase.notifier.Send(notification)
+	if err != nil {
+		log.Printf("Error forgot password of user %d: %s", *user.ID, err)
+	}
+
+	return nil
 }
 
-func (usecase *authUsecase) UserLogin(login entity.Login) (entity.AccessToken, error) {
+// description: token stays valid when new password violates policy, all sessions are revoked after reset
+func (usecase *authUsecase) UserForgotConfirm(ctx context.Context, forgotConfirm entity.ForgotConfirm, audit entity.Audit) error {
+	tokenHash := hashToken(*forgotConfirm.Token)
+	passwordResetToken := entity.PasswordResetToken{TokenHash: &tokenHash}
+	passwordResetToken, err := usecase.passwordResetTokenRepository.Get(ctx, passwordResetToken)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return util.NewError(common.ErrorCode.InvalidPasswordResetToken, "invalid password reset token")
+		} else {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+	if passwordResetToken.IsUsed() || passwordResetToken.IsExpired() {
+		return util.NewError(common.ErrorCode.InvalidPasswordResetToken, "invalid password reset token")
+	}
+
+	// description: token stays usable when password is not changed, so the user can retry with the same link
+	return usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := usecase.resetUserPassword(ctx, entity.Reset{ID: passwordResetToken.UserID, Password: forgotConfirm.Password}, audit, entity.ForgotPasswordAuditAction)
+		if err != nil {
+			return err
+		}
+
+		// description: concurrent confirm of the same token that used it first wins, this one is rolled back
+		err = usecase.passwordResetTokenRepository.Use(ctx, entity.PasswordResetToken{ID: passwordResetToken.ID})
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return util.NewError(common.ErrorCode.InvalidPasswordResetToken, "invalid password reset token")
+			} else {
+				return util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+		}
+
+		return revokeAllTokens(ctx, usecase.refreshTokenRepository, usecase.revokedTokenRepository, nil, passwordResetToken.UserID)
+	})
+}
+
+func (usecase *authUsecase) UserLogin(ctx context.Context, login entity.Login) (entity.AccessToken, error) {
 	user := entity.User{Username: login.Username}
-	user, err := usecase.userRepository.Get(user)
+	user, err := usecase.userRepository.Get(ctx, user)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return entity.AccessToken{}, util.Error{Code: http.StatusNotFound, Message: err.Error()}
+			_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(*login.Password))
+			return entity.AccessToken{}, errorLoginFailed
 		} else {
-			return entity.AccessToken{}, util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+			return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
 		}
 	}
+	if user.IsLocked() {
+		return entity.AccessToken{}, errorLoginFailed
+	}
 
 	err = bcrypt.CompareHashAndPassword(*user.PasswordHash, []byte(*login.Password))
 	if err != nil {
-		return entity.AccessToken{}, util.Error{Code: http.StatusUnauthorized}
+		err = increaseFailedLogin(ctx, usecase.adminRepository, usecase.transactionRepository, usecase.userRepository, nil, user.ID)
+		if err != nil {
+			return entity.AccessToken{}, err
+		}
+
+		return entity.AccessToken{}, errorLoginFailed
+	}
+	if isFailedLogin(user.FailedLogin, user.LockUntil) {
+		user.FailedLogin, user.LockUntil = new(int), nil
+		err = usecase.userRepository.UpdateLock(ctx, user)
+		if err != nil {
+			return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
 	}
 
-	accessToken, err := middleware.GenerateJWT(*user.ID, entity.UserRoleName)
-	if err != nil {
-		return entity.AccessToken{}, util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+	if user.IsMFAEnabled != nil && *user.IsMFAEnabled {
+		return requireMFA(*user.ID, entity.UserRoleName)
 	}
 
-	currentTime := time.Now()
-	user.LastLoginAt = &currentTime
-	err = usecase.userRepository.Update(user)
-	if err != nil {
-		return entity.AccessToken{}, util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+	return usecase.completeUserLogin(ctx, user, nil, login.Device)
+}
+
+func (usecase *authUsecase) UserRefresh(ctx context.Context, refresh entity.Refresh) (entity.AccessToken, error) {
+	user := entity.User{}
+	checkAccount := func(ctx context.Context, refreshToken entity.RefreshToken) error {
+		if refreshToken.UserID == nil {
+			return util.NewError(common.ErrorCode.InvalidRefreshToken, "invalid refresh token")
+		}
+
+		err := error(nil)
+		user, err = usecase.userRepository.Get(ctx, entity.User{ID: refreshToken.UserID})
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return util.NewError(common.ErrorCode.Unauthorized, err.Error())
+			} else {
+				return util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+		}
+		if user.IsLocked() {
+			return util.NewError(common.ErrorCode.AccountLocked, "user is locked")
+		}
+
+		return nil
 	}
 
-	return entity.AccessToken{AccessToken: &accessToken}, nil
+	return usecase.refreshToken(ctx, refresh, checkAccount, func(ctx context.Context, refreshToken entity.RefreshToken) (entity.AccessToken, error) {
+		return usecase.issueToken(ctx, *user.ID, isResetPassword(user.IsResetPassword), entity.RefreshToken{UserID: user.ID, FamilyID: refreshToken.FamilyID}, refresh.Device, []entity.RoleName{entity.UserRoleName}, nil)
+	})
 }
 
-func (usecase *authUsecase) UserReset(reset entity.Reset) error {
+func (usecase *authUsecase) UserReset(ctx context.Context, reset entity.Reset, audit entity.Audit) error {
+	return usecase.resetUserPassword(ctx, reset, audit, entity.ResetPasswordAuditAction)
+}
+
+// description: action tells reset by signed in user apart from confirm of forgot password link
+func (usecase *authUsecase) resetUserPassword(ctx context.Context, reset entity.Reset, audit entity.Audit, action entity.AuditAction) error {
 	user := entity.User{ID: reset.ID}
-	user, err := usecase.userRepository.Get(user)
+	user, err := usecase.userRepository.Get(ctx, user)
+	if err != nil {
+		return util.NewError(common.ErrorCode.NotFound, err.Error())
+	}
+
+	account := passwordAccount{userID: user.ID, username: user.Username, passwordHash: user.PasswordHash}
+	details, err := checkPassword(ctx, usecase.passwordHistoryRepository, account, *reset.Password)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	err = newValidationError(details)
 	if err != nil {
-		return util.Error{Code: http.StatusNotFound, Message: err.Error()}
+		return err
 	}
 
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(*reset.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	isResetPassword := false
+
+	resetUser := entity.User{ID: user.ID, PasswordHash: &passwordHash, IsResetPassword: &isResetPassword}
+	auditLog := entity.NewAuditLog(audit, action, entity.UserAuditEntityType, user.ID).WithChange(user, resetUser)
+	return usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := usecase.userRepository.Update(ctx, resetUser, auditLog)
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+
+		return savePasswordHistory(ctx, usecase.passwordHistoryRepository, account)
+	})
+}
+
+func (usecase *authUsecase) UserVerifyMFA(ctx context.Context, mfa entity.MFA, audit entity.Audit) (entity.AccessToken, error) {
+	mfa.AdminID, mfa.UserID = nil, nil
+	account, err := getMFAAccount(ctx, usecase.adminRepository, usecase.userRepository, mfa)
+	if err != nil {
+		return entity.AccessToken{}, err
+	}
+	if account.userID == nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.InvalidMFAToken, "invalid mfa token")
+	}
+
+	recoveryCodes, err := usecase.verifyMFA(ctx, account, mfa, audit)
+	if err != nil {
+		return entity.AccessToken{}, err
+	}
+
+	user := entity.User{ID: account.userID}
+	user, err = usecase.userRepository.Get(ctx, user)
+	if err != nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return usecase.completeUserLogin(ctx, user, recoveryCodes, mfa.Device)
+}
+
+// description: require mfa when admin enabled it or policy enforces it, otherwise issue token
+func (usecase *authUsecase) loginAdmin(ctx context.Context, admin entity.Admin, device entity.Device) (entity.AccessToken, error) {
+	roles, permissions, err := getAdminRoles(admin)
+	if err != nil {
+		return entity.AccessToken{}, err
+	}
+
+	policy, err := usecase.policyRepository.Get(ctx)
+	if err != nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	if (admin.IsMFAEnabled != nil && *admin.IsMFAEnabled) || (policy.IsAdminMFARequired != nil && *policy.IsAdminMFARequired) {
+		return requireMFA(*admin.ID, roles...)
+	}
+
+	return usecase.completeAdminLogin(ctx, admin, roles, permissions, nil, device)
+}
+
+// description: session, refresh token and last login are written together so a failed login leaves no usable session
+func (usecase *authUsecase) completeAdminLogin(ctx context.Context, admin entity.Admin, roles []entity.RoleName, permissions []entity.PermissionName, recoveryCodes []string, device entity.Device) (entity.AccessToken, error) {
+	accessToken := entity.AccessToken{}
+	err := usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := error(nil)
+		accessToken, err = usecase.issueToken(ctx, *admin.ID, isResetPassword(admin.IsResetPassword), entity.RefreshToken{AdminID: admin.ID}, device, roles, permissions)
+		if err != nil {
+			return err
+		}
+
+		currentTime := time.Now()
+		err = usecase.adminRepository.Update(ctx, entity.Admin{ID: admin.ID, LastLoginAt: &currentTime})
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.AccessToken{}, err
+	}
+	accessToken.RecoveryCodes = recoveryCodes
+
+	return accessToken, nil
+}
+
+func (usecase *authUsecase) completeUserLogin(ctx context.Context, user entity.User, recoveryCodes []string, device entity.Device) (entity.AccessToken, error) {
+	accessToken := entity.AccessToken{}
+	err := usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := error(nil)
+		accessToken, err = usecase.issueToken(ctx, *user.ID, isResetPassword(user.IsResetPassword), entity.RefreshToken{UserID: user.ID}, device, []entity.RoleName{entity.UserRoleName}, nil)
+		if err != nil {
+			return err
+		}
+
+		currentTime := time.Now()
+		err = usecase.userRepository.Update(ctx, entity.User{ID: user.ID, LastLoginAt: &currentTime})
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.AccessToken{}, err
+	}
+	accessToken.RecoveryCodes = recoveryCodes
+
+	return accessToken, nil
+}
+
+// description: admin is linked by oidc subject, local admin with same username is never taken over
+func (usecase *authUsecase) provisionOIDCAdmin(ctx context.Context, oidcIdentity entity.OIDCIdentity, role entity.Role) (entity.Admin, error) {
+	admin := entity.Admin{OIDCSubject: &oidcIdentity.Subject}
+	admin, err := usecase.adminRepository.Get(ctx, admin)
+	if err == nil {
+		if *admin.RoleID != *role.ID {
+			admin.RoleID = role.ID
+			admin.Role = &role
+			err = usecase.adminRepository.Update(ctx, entity.Admin{ID: admin.ID, RoleID: role.ID})
+			if err != nil {
+				return entity.Admin{}, util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+		}
+
+		return admin, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return entity.Admin{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	username := oidcIdentity.Username
+	if username == "" {
+		username = oidcIdentity.Email
+	}
+	if username == "" {
+		username = oidcIdentity.Subject
+	}
+
+	_, err = usecase.adminRepository.Get(ctx, entity.Admin{Username: &username})
+	if err == nil {
+		return entity.Admin{}, util.NewError(common.ErrorCode.UsernameTaken, fmt.Sprintf("username %s is already used by another admin", username))
+	}
+	if err != gorm.ErrRecordNotFound {
+		return entity.Admin{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	// description: random password keeps password login unusable until an admin sets one
+	password, err := generateRandomString(32)
+	if err != nil {
+		return entity.Admin{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return entity.Admin{}, util.NewError(common.ErrorCode.Internal, err.Error())
 	}
 	isResetPassword := false
 
-	user.PasswordHash = &passwordHash
-	user.IsResetPassword = &isResetPassword
-	err = usecase.userRepository.Update(user)
+	admin = entity.Admin{
+		RoleID:          role.ID,
+		Username:        &username,
+		PasswordHash:    &passwordHash,
+		IsResetPassword: &isResetPassword,
+		OIDCSubject:     &oidcIdentity.Subject,
+	}
+	err = usecase.adminRepository.Create(ctx, admin)
+	if err != nil {
+		return entity.Admin{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	admin, err = usecase.adminRepository.Get(ctx, entity.Admin{OIDCSubject: &oidcIdentity.Subject})
+	if err != nil {
+		return entity.Admin{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return admin, nil
+}
+
+// description: verify code of enabled mfa, or confirm pending enrollment required by policy and return recovery codes,
+// jti of mfa token is recorded as revoked on success so the token completes one login only
+func (usecase *authUsecase) verifyMFA(ctx context.Context, account mfaAccount, mfa entity.MFA, audit entity.Audit) ([]string, error) {
+	if account.mfaToken == nil {
+		return nil, errorMFATokenUsed
+	}
+	isRevoked, err := usecase.revokedTokenRepository.IsRevoked(ctx, *account.mfaToken, account.mfaTokenIssueAt)
+	if err != nil {
+		return nil, util.NewError(common.ErrorCode.Internal, err.Error())
+	} else if isRevoked {
+		return nil, errorMFATokenUsed
+	}
+
+	recoveryCodes := []string(nil)
+	if !account.isEnabled {
+		recoveryCodes, err = confirmMFAAccount(ctx, usecase.adminRepository, usecase.recoveryCodeRepository, usecase.transactionRepository, usecase.userRepository, account, mfa, audit)
+	} else {
+		err = verifyMFAAccount(ctx, usecase.adminRepository, usecase.recoveryCodeRepository, usecase.transactionRepository, usecase.userRepository, account, mfa)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// description: unique jti makes concurrent use of the same token fail on every instance but one
+	err = usecase.revokedTokenRepository.Create(ctx, *account.mfaToken)
+	if err != nil {
+		constraintError := entity.ConstraintError{}
+		if errors.As(err, &constraintError) && constraintError.Type == entity.UniqueConstraintType {
+			return nil, errorMFATokenUsed
+		}
+
+		return nil, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return recoveryCodes, nil
+}
+
+// description: sign access token and persist a new refresh token, start a new family and session when family id is nil
+func (usecase *authUsecase) issueToken(ctx context.Context, subject uint64, isResetPassword bool, refreshToken entity.RefreshToken, device entity.Device, roles []entity.RoleName, permissions []entity.PermissionName) (entity.AccessToken, error) {
+	refreshExpireMinute, err := time.ParseDuration(config.JWT.RefreshExpireMinute)
 	if err != nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	if refreshToken.FamilyID == nil {
+		familyID, err := generateRandomString(16)
+		if err != nil {
+			return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+		refreshToken.FamilyID = &familyID
+	}
+
+	session, err := usecase.touchSession(ctx, refreshToken, device)
+	if err != nil {
+		return entity.AccessToken{}, err
+	}
+
+	accessToken, err := middleware.GenerateJWT(subject, session.ID, isResetPassword, roles, permissions)
+	if err != nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	refreshTokenString, err := generateRandomString(32)
+	if err != nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	tokenHash := hashToken(refreshTokenString)
+	expireAt := time.Now().Add(refreshExpireMinute)
+
+	refreshToken.TokenHash = &tokenHash
+	refreshToken.ExpireAt = &expireAt
+	err = usecase.refreshTokenRepository.Create(ctx, refreshToken)
+	if err != nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return entity.AccessToken{AccessToken: &accessToken, RefreshToken: &refreshTokenString}, nil
+}
+
+// description: record device of the login, refresh of an existing family updates last seen and device
+func (usecase *authUsecase) touchSession(ctx context.Context, refreshToken entity.RefreshToken, device entity.Device) (entity.Session, error) {
+	currentTime := time.Now()
+	session, err := usecase.sessionRepository.Get(ctx, entity.Session{FamilyID: refreshToken.FamilyID})
+	if err == nil {
+		session.UserAgent = device.UserAgent
+		session.IPAddress = device.IPAddress
+		session.LastSeenAt = &currentTime
+		err = usecase.sessionRepository.UpdateLastSeen(ctx, session)
+		if err != nil {
+			return entity.Session{}, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+
+		return session, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return entity.Session{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	session = entity.Session{
+		AdminID:    refreshToken.AdminID,
+		UserID:     refreshToken.UserID,
+		FamilyID:   refreshToken.FamilyID,
+		UserAgent:  device.UserAgent,
+		IPAddress:  device.IPAddress,
+		LastSeenAt: &currentTime,
+	}
+	err = usecase.sessionRepository.Create(ctx, session)
+	if err != nil {
+		return entity.Session{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	session, err = usecase.sessionRepository.Get(ctx, entity.Session{FamilyID: refreshToken.FamilyID})
+	if err != nil {
+		return entity.Session{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return session, nil
+}
+
+// description: owning account is checked before the presented token is rotated, so a token of a missing, locked or other type of account is left untouched,
+// rotation and the new refresh token are committed together, family of a reused token is revoked after rollback so the revocation is kept
+func (usecase *authUsecase) refreshToken(ctx context.Context,
+	refresh entity.Refresh,
+	checkAccount func(ctx context.Context, refreshToken entity.RefreshToken) error,
+	issueToken func(ctx context.Context, refreshToken entity.RefreshToken) (entity.AccessToken, error),
+) (entity.AccessToken, error) {
+	accessToken, refreshToken := entity.AccessToken{}, entity.RefreshToken{}
+	err := usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := error(nil)
+		refreshToken, err = usecase.getRefreshToken(ctx, refresh)
+		if err != nil {
+			return err
+		}
+		err = checkAccount(ctx, refreshToken)
+		if err != nil {
+			return err
+		}
+		err = usecase.rotateRefreshToken(ctx, refreshToken)
+		if err != nil {
+			return err
+		}
+
+		accessToken, err = issueToken(ctx, refreshToken)
+		return err
+	})
+	e := util.Error{}
+	if errors.As(err, &e) && e.Code == common.ErrorCode.RefreshTokenReused {
+		revokeErr := usecase.refreshTokenRepository.RevokeFamily(ctx, *refreshToken.FamilyID)
+		if revokeErr != nil {
+			return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, revokeErr.Error())
+		}
+	}
+	if err != nil {
+		return entity.AccessToken{}, err
+	}
+
+	return accessToken, nil
+}
+
+func (usecase *authUsecase) getRefreshToken(ctx context.Context, refresh entity.Refresh) (entity.RefreshToken, error) {
+	tokenHash := hashToken(*refresh.RefreshToken)
+	refreshToken := entity.RefreshToken{TokenHash: &tokenHash}
+	refreshToken, err := usecase.refreshTokenRepository.Get(ctx, refreshToken)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.RefreshToken{}, util.NewError(common.ErrorCode.Unauthorized, err.Error())
+		} else {
+			return entity.RefreshToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	return refreshToken, nil
+}
+
+// description: revoke presented refresh token, reuse of a revoked token returns reused error so its family is revoked
+func (usecase *authUsecase) rotateRefreshToken(ctx context.Context, refreshToken entity.RefreshToken) error {
+	err := error(nil)
+	if !refreshToken.IsRevoked() {
+		err = usecase.refreshTokenRepository.Revoke(ctx, refreshToken)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+	if refreshToken.IsRevoked() || err == gorm.ErrRecordNotFound {
+		return util.NewError(common.ErrorCode.RefreshTokenReused, "refresh token reused")
+	}
+
+	if refreshToken.IsExpired() {
+		return util.NewError(common.ErrorCode.RefreshTokenExpired, "refresh token expired")
 	}
 
 	return nil
 }
+
+// description: revoke every access token issued so far and every refresh token of admin or user
+func revokeAllTokens(ctx context.Context,
+	refreshTokenRepository repository.RefreshToken,
+	revokedTokenRepository repository.RevokedToken,
+	adminID, userID *uint64,
+) error {
+	expireMinute, err := time.ParseDuration(config.JWT.ExpireMinute)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	expireAt := time.Now().Add(expireMinute)
+
+	revokedToken := entity.RevokedToken{
+		AdminID:  adminID,
+		UserID:   userID,
+		ExpireAt: &expireAt,
+	}
+	err = revokedTokenRepository.Create(ctx, revokedToken)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	refreshToken := entity.RefreshToken{
+		AdminID: adminID,
+		UserID:  userID,
+	}
+	err = refreshTokenRepository.RevokeAll(ctx, refreshToken)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return nil
+}
+
+// description: count is increased in database so concurrent failures are all counted, lock after login lock attempt,
+// lock duration doubles on every further failure, row stays locked by the increase until lock is written
+func increaseFailedLogin(ctx context.Context,
+	adminRepository repository.Admin,
+	transactionRepository repository.Transaction,
+	userRepository repository.User,
+	adminID *uint64,
+	userID *uint64,
+) error {
+	return transactionRepository.Do(ctx, func(ctx context.Context) error {
+		count, err := 0, error(nil)
+		if adminID != nil {
+			count, err = adminRepository.IncreaseFailedLogin(ctx, entity.Admin{ID: adminID})
+		} else {
+			count, err = userRepository.IncreaseFailedLogin(ctx, entity.User{ID: userID})
+		}
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+		if count < loginLockAttempt {
+			return nil
+		}
+
+		lockDuration := loginLockMaximum
+		if exponent := count - loginLockAttempt; exponent < 16 && loginLockDuration<<exponent < loginLockMaximum {
+			lockDuration = loginLockDuration << exponent
+		}
+		lockUntil := time.Now().Add(lockDuration)
+
+		if adminID != nil {
+			err = adminRepository.UpdateLock(ctx, entity.Admin{ID: adminID, FailedLogin: &count, LockUntil: &lockUntil})
+		} else {
+			err = userRepository.UpdateLock(ctx, entity.User{ID: userID, FailedLogin: &count, LockUntil: &lockUntil})
+		}
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+
+		return nil
+	})
+}
+
+// description: mapping is comma separated group:ROLE_NAME, first mapped group in configured order wins
+func mapOIDCRole(roleMapping string, groups []string) (entity.RoleName, error) {
+	isMember := map[string]bool{}
+	for _, group := range groups {
+		isMember[group] = true
+	}
+
+	for _, mapping := range strings.Split(roleMapping, ",") {
+		separatorIndex := strings.LastIndex(mapping, ":")
+		if separatorIndex < 0 {
+			continue
+		}
+
+		group := strings.TrimSpace(mapping[:separatorIndex])
+		roleName := entity.RoleName(strings.TrimSpace(mapping[separatorIndex+1:]))
+		if isMember[group] && roleName != entity.UserRoleName {
+			return roleName, nil
+		}
+	}
+
+	return "", util.NewError(common.ErrorCode.OIDCRoleNotMapped, "no admin role mapped to identity provider groups")
+}
+
+func isFailedLogin(failedLogin *int, lockUntil *time.Time) bool {
+	return (failedLogin != nil && *failedLogin > 0) || lockUntil != nil
+}
+
+func isResetPassword(isResetPassword *bool) bool {
+	return isResetPassword != nil && *isResetPassword
+}
+
+func requireMFA(subject uint64, roles ...entity.RoleName) (entity.AccessToken, error) {
+	mfaToken, err := middleware.GenerateMFAToken(subject, roles...)
+	if err != nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	mfaRequired := true
+
+	return entity.AccessToken{MFARequired: &mfaRequired, MFAToken: &mfaToken}, nil
+}
+
+// description: role and its permissions come from database, admin must be loaded with role
+func getAdminRoles(admin entity.Admin) ([]entity.RoleName, []entity.PermissionName, error) {
+	if admin.Role == nil || admin.Role.Name == nil || entity.RoleName(*admin.Role.Name) == entity.UserRoleName {
+		return []entity.RoleName{}, []entity.PermissionName{}, util.NewError(common.ErrorCode.Internal, "invalid role")
+	}
+
+	return []entity.RoleName{entity.RoleName(*admin.Role.Name)}, admin.Role.PermissionNames(), nil
+}
+
+func generateRandomString(length int) (string, error) {
+	randomBytes := make([]byte, length)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
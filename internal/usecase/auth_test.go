@@ -1,30 +1,104 @@
 package usecase_test
 
 import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt"
 	"github.com/golang/mock/gomock"
+	"github.com/pquerna/otp/totp"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/middleware"
 	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+	identitymock "github.com/sndzhng/gin-template/mock/identity"
+	notifiermock "github.com/sndzhng/gin-template/mock/notifier"
 	repositorymock "github.com/sndzhng/gin-template/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 func beforeTestAuth(test *testing.T) (
 	*repositorymock.MockAdmin,
+	*repositorymock.MockOIDCState,
+	*repositorymock.MockPasswordHistory,
+	*repositorymock.MockPasswordResetToken,
+	*repositorymock.MockPolicy,
+	*repositorymock.MockRecoveryCode,
+	*repositorymock.MockRefreshToken,
+	*repositorymock.MockRevokedToken,
+	*repositorymock.MockRole,
+	*repositorymock.MockSession,
 	*repositorymock.MockUser,
+	*notifiermock.MockNotifier,
+	*identitymock.MockProvider,
 	usecase.Auth,
 ) {
 	controller := gomock.NewController(test)
 	defer controller.Finish()
 
+	config.JWT = config.JWTConfig{
+		ExpireMinute:        "15m",
+		Key:                 "secret",
+		RefreshExpireMinute: "720h",
+	}
+	config.Password.ResetExpireMinute = "30m"
+
 	mockAdminRepository := repositorymock.NewMockAdmin(controller)
+	mockOIDCStateRepository := repositorymock.NewMockOIDCState(controller)
+	mockPasswordHistoryRepository := repositorymock.NewMockPasswordHistory(controller)
+	mockPasswordResetTokenRepository := repositorymock.NewMockPasswordResetToken(controller)
+	mockPolicyRepository := repositorymock.NewMockPolicy(controller)
+	mockRecoveryCodeRepository := repositorymock.NewMockRecoveryCode(controller)
+	mockRefreshTokenRepository := repositorymock.NewMockRefreshToken(controller)
+	mockRevokedTokenRepository := repositorymock.NewMockRevokedToken(controller)
+	mockRoleRepository := repositorymock.NewMockRole(controller)
+	mockSessionRepository := repositorymock.NewMockSession(controller)
 	mockUserRepository := repositorymock.NewMockUser(controller)
-	authUsecase := usecase.NewAuthUsecase(mockAdminRepository, mockUserRepository)
+	mockNotifier := notifiermock.NewMockNotifier(controller)
+	mockProvider := identitymock.NewMockProvider(controller)
+	authUsecase := usecase.NewAuthUsecase(
+		mockAdminRepository,
+		mockOIDCStateRepository,
+		mockPasswordHistoryRepository,
+		mockPasswordResetTokenRepository,
+		mockPolicyRepository,
+		mockRecoveryCodeRepository,
+		mockRefreshTokenRepository,
+		mockRevokedTokenRepository,
+		mockRoleRepository,
+		mockSessionRepository,
+		newMockTransaction(controller),
+		mockUserRepository,
+		mockNotifier,
+		mockProvider,
+	)
+
+	return mockAdminRepository, mockOIDCStateRepository, mockPasswordHistoryRepository, mockPasswordResetTokenRepository, mockPolicyRepository, mockRecoveryCodeRepository, mockRefreshTokenRepository, mockRevokedTokenRepository, mockRoleRepository, mockSessionRepository, mockUserRepository, mockNotifier, mockProvider, authUsecase
+}
 
-	return mockAdminRepository, mockUserRepository, authUsecase
+func expectNewSession(mockSessionRepository *repositorymock.MockSession) {
+	id := uint64(1)
+	mockSessionRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.Session{}, gorm.ErrRecordNotFound)
+	mockSessionRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockSessionRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.Session{ID: &id}, nil)
+}
+
+func expectExistingSession(mockSessionRepository *repositorymock.MockSession) {
+	id := uint64(1)
+	mockSessionRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.Session{ID: &id}, nil)
+	mockSessionRepository.EXPECT().UpdateLastSeen(gomock.Any(), gomock.Any()).Return(nil)
 }
 
 func TestAuthAdminLogin(test *testing.T) {
-	// mockAdminRepository, _, authUsecase := beforeTestAuth(test)
+	// mockAdminRepository, _, _, _, _, _, _, _, _, _, _, _, _, authUsecase := beforeTestAuth(test)
 
 	// id := uint64(1)
 	// username := "username"
@@ -40,7 +114,7 @@ func TestAuthAdminLogin(test *testing.T) {
 	// }
 
 	// test.Run("Success", func(test *testing.T) {
-	// 	mockAdminRepository.EXPECT().Get(gomock.Any()).Return(
+	// 	mockAdminRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(
 	// 		entity.Admin{
 	// 			ID:       &id,
 	// 			RoleID:   &id,
@@ -49,24 +123,1027 @@ func TestAuthAdminLogin(test *testing.T) {
 	// 		nil,
 	// 	)
 
-	// 	token, err := authUsecase.AdminLogin(login)
+	// 	token, err := authUsecase.AdminLogin(context.Background(), login)
 	// 	assert.NoError(test, err)
 	// 	assert.NotEmpty(test, token)
 	// })
 
 	// test.Run("InternalError", func(test *testing.T) {
-	// 	mockAdminRepository.EXPECT().Get(admin).Return(entity.Admin{}, errors.New("internal error"))
+	// 	mockAdminRepository.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{}, errors.New("internal error"))
 
-	// 	token, err := authUsecase.AdminLogin(login)
-	// 	assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+	// 	token, err := authUsecase.AdminLogin(context.Background(), login)
+	// 	assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
 	// 	assert.Empty(test, token)
 	// })
 
 	// test.Run("RecordNotFound", func(test *testing.T) {
-	// 	mockAdminRepository.EXPECT().Get(admin).Return(entity.Admin{}, gorm.ErrRecordNotFound)
+	// 	mockAdminRepository.EXPECT().Get(gomock.Any(), admin).Return(entity.Admin{}, gorm.ErrRecordNotFound)
 
-	// 	token, err := authUsecase.AdminLogin(login)
-	// 	assert.Equal(test, http.StatusNotFound, err.(util.Error).Code)
+	// 	token, err := authUsecase.AdminLogin(context.Background(), login)
+	// 	assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
 	// 	assert.Empty(test, token)
 	// })
 }
+
+func TestAuthAdminOIDCLogin(test *testing.T) {
+	_, mockOIDCStateRepository, _, _, _, _, _, _, _, _, _, _, mockProvider, authUsecase := beforeTestAuth(test)
+
+	test.Run("Success", func(test *testing.T) {
+		state, nonce, codeVerifier := "", "", ""
+
+		mockOIDCStateRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, oidcState entity.OIDCState) error {
+				nonce, codeVerifier = *oidcState.Nonce, *oidcState.CodeVerifier
+				assert.True(test, oidcState.ExpireAt.After(time.Now()))
+				return nil
+			},
+		)
+		mockProvider.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(stateArgument, nonceArgument, codeVerifierArgument string) string {
+				state = stateArgument
+				assert.Equal(test, nonce, nonceArgument)
+				assert.Equal(test, codeVerifier, codeVerifierArgument)
+				return "https://idp.example.com/authorize"
+			},
+		)
+
+		authorizationURL, err := authUsecase.AdminOIDCLogin(context.Background())
+		assert.NoError(test, err)
+		assert.Equal(test, "https://idp.example.com/authorize", authorizationURL)
+		assert.NotEmpty(test, state)
+		assert.NotEqual(test, state, nonce)
+		assert.NotEqual(test, state, codeVerifier)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockOIDCStateRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		_, err := authUsecase.AdminOIDCLogin(context.Background())
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("NotFound/Disabled", func(test *testing.T) {
+		authUsecase := usecase.NewAuthUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		_, err := authUsecase.AdminOIDCLogin(context.Background())
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+}
+
+func TestAuthAdminOIDCCallback(test *testing.T) {
+	mockAdminRepository, mockOIDCStateRepository, _, _, mockPolicyRepository, _, mockRefreshTokenRepository, _, mockRoleRepository, mockSessionRepository, _, _, mockProvider, authUsecase := beforeTestAuth(test)
+
+	config.OIDC.RoleMapping = "staff:USER,gin-template-admin:SUPER_ADMIN"
+
+	id := uint64(1)
+	anotherID := uint64(2)
+	code := "code"
+	state := "state"
+	stateHash := fmt.Sprintf("%x", sha256.Sum256([]byte(state)))
+	nonce := "nonce"
+	codeVerifier := "codeVerifier"
+	expireAt := time.Now().Add(time.Minute)
+	roleName := string(entity.SuperAdminRoleName)
+	subject := "subject"
+	username := "admin.oidc"
+	oidcCallback := entity.OIDCCallback{Code: &code, State: &state}
+	oidcState := entity.OIDCState{ID: &id, StateHash: &stateHash, CodeVerifier: &codeVerifier, Nonce: &nonce, ExpireAt: &expireAt}
+	oidcIdentity := entity.OIDCIdentity{Subject: subject, Username: username, Groups: []string{"staff", "gin-template-admin"}}
+	permissionName := entity.UserWritePermissionName
+	role := entity.Role{ID: &id, Name: &roleName, Permissions: []entity.Permission{{ID: &id, Name: &permissionName}}}
+
+	expectState := func() {
+		mockOIDCStateRepository.EXPECT().Get(gomock.Any(), entity.OIDCState{StateHash: &stateHash}).Return(oidcState, nil)
+		mockOIDCStateRepository.EXPECT().Use(gomock.Any(), entity.OIDCState{ID: &id}).Return(nil)
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		expectState()
+		mockProvider.EXPECT().Exchange(code, nonce, codeVerifier).Return(oidcIdentity, nil)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{Name: &roleName}).Return(role, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{OIDCSubject: &subject}).Return(entity.Admin{ID: &id, Role: &role, RoleID: &id, Username: &username, OIDCSubject: &subject}, nil)
+		mockPolicyRepository.EXPECT().Get(gomock.Any()).Return(entity.Policy{}, nil)
+		expectNewSession(mockSessionRepository)
+		mockRefreshTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockAdminRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		accessToken, err := authUsecase.AdminOIDCCallback(context.Background(), oidcCallback)
+		assert.NoError(test, err)
+		assert.NotNil(test, accessToken.AccessToken)
+		assert.NotNil(test, accessToken.RefreshToken)
+
+		claims := entity.Claims{}
+		_, _, err = new(jwt.Parser).ParseUnverified(*accessToken.AccessToken, &claims)
+		assert.NoError(test, err)
+		assert.Equal(test, []entity.RoleName{entity.SuperAdminRoleName}, claims.Roles)
+		assert.Equal(test, []entity.PermissionName{permissionName}, claims.Permissions)
+	})
+
+	test.Run("Success/Provision", func(test *testing.T) {
+		expectState()
+		mockProvider.EXPECT().Exchange(code, nonce, codeVerifier).Return(oidcIdentity, nil)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{Name: &roleName}).Return(role, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{OIDCSubject: &subject}).Return(entity.Admin{}, gorm.ErrRecordNotFound)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{Username: &username}).Return(entity.Admin{}, gorm.ErrRecordNotFound)
+		mockAdminRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, id, *admin.RoleID)
+				assert.Equal(test, username, *admin.Username)
+				assert.Equal(test, subject, *admin.OIDCSubject)
+				assert.False(test, *admin.IsResetPassword)
+				assert.NotNil(test, admin.PasswordHash)
+				return nil
+			},
+		)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{OIDCSubject: &subject}).Return(entity.Admin{ID: &anotherID, Role: &role, RoleID: &id, Username: &username, OIDCSubject: &subject}, nil)
+		mockPolicyRepository.EXPECT().Get(gomock.Any()).Return(entity.Policy{}, nil)
+		expectNewSession(mockSessionRepository)
+		mockRefreshTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockAdminRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		accessToken, err := authUsecase.AdminOIDCCallback(context.Background(), oidcCallback)
+		assert.NoError(test, err)
+		assert.NotNil(test, accessToken.AccessToken)
+	})
+
+	test.Run("Success/SyncRole", func(test *testing.T) {
+		expectState()
+		mockProvider.EXPECT().Exchange(code, nonce, codeVerifier).Return(oidcIdentity, nil)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{Name: &roleName}).Return(role, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{OIDCSubject: &subject}).Return(entity.Admin{ID: &id, RoleID: &anotherID, OIDCSubject: &subject}, nil)
+		mockAdminRepository.EXPECT().Update(gomock.Any(), entity.Admin{ID: &id, RoleID: &id}).Return(nil)
+		mockPolicyRepository.EXPECT().Get(gomock.Any()).Return(entity.Policy{}, nil)
+		expectNewSession(mockSessionRepository)
+		mockRefreshTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockAdminRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := authUsecase.AdminOIDCCallback(context.Background(), oidcCallback)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Success/MFARequired", func(test *testing.T) {
+		isAdminMFARequired := true
+
+		expectState()
+		mockProvider.EXPECT().Exchange(code, nonce, codeVerifier).Return(oidcIdentity, nil)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{Name: &roleName}).Return(role, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{OIDCSubject: &subject}).Return(entity.Admin{ID: &id, Role: &role, RoleID: &id, OIDCSubject: &subject}, nil)
+		mockPolicyRepository.EXPECT().Get(gomock.Any()).Return(entity.Policy{IsAdminMFARequired: &isAdminMFARequired}, nil)
+
+		accessToken, err := authUsecase.AdminOIDCCallback(context.Background(), oidcCallback)
+		assert.NoError(test, err)
+		assert.True(test, *accessToken.MFARequired)
+		assert.Nil(test, accessToken.AccessToken)
+	})
+
+	test.Run("Conflict/Username", func(test *testing.T) {
+		expectState()
+		mockProvider.EXPECT().Exchange(code, nonce, codeVerifier).Return(oidcIdentity, nil)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{Name: &roleName}).Return(role, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{OIDCSubject: &subject}).Return(entity.Admin{}, gorm.ErrRecordNotFound)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{Username: &username}).Return(entity.Admin{ID: &anotherID, Username: &username}, nil)
+
+		_, err := authUsecase.AdminOIDCCallback(context.Background(), oidcCallback)
+		assert.Equal(test, http.StatusConflict, err.(util.Error).Status)
+	})
+
+	test.Run("Forbidden/Group", func(test *testing.T) {
+		expectState()
+		mockProvider.EXPECT().Exchange(code, nonce, codeVerifier).Return(entity.OIDCIdentity{Subject: subject, Groups: []string{"staff"}}, nil)
+
+		_, err := authUsecase.AdminOIDCCallback(context.Background(), oidcCallback)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
+	})
+
+	test.Run("Unauthorized/Exchange", func(test *testing.T) {
+		expectState()
+		mockProvider.EXPECT().Exchange(code, nonce, codeVerifier).Return(entity.OIDCIdentity{}, errors.New("invalid nonce"))
+
+		_, err := authUsecase.AdminOIDCCallback(context.Background(), oidcCallback)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+
+	test.Run("Unauthorized/ProviderError", func(test *testing.T) {
+		providerError := "access_denied"
+
+		_, err := authUsecase.AdminOIDCCallback(context.Background(), entity.OIDCCallback{Error: &providerError})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+
+	test.Run("Unauthorized/StateNotFound", func(test *testing.T) {
+		mockOIDCStateRepository.EXPECT().Get(gomock.Any(), entity.OIDCState{StateHash: &stateHash}).Return(entity.OIDCState{}, gorm.ErrRecordNotFound)
+
+		_, err := authUsecase.AdminOIDCCallback(context.Background(), oidcCallback)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+
+	test.Run("Unauthorized/StateUsed", func(test *testing.T) {
+		useAt := time.Now()
+
+		mockOIDCStateRepository.EXPECT().Get(gomock.Any(), entity.OIDCState{StateHash: &stateHash}).Return(entity.OIDCState{ID: &id, ExpireAt: &expireAt, UseAt: &useAt}, nil)
+
+		_, err := authUsecase.AdminOIDCCallback(context.Background(), oidcCallback)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+
+	test.Run("Unauthorized/StateExpired", func(test *testing.T) {
+		expiredAt := time.Now().Add(-time.Minute)
+
+		mockOIDCStateRepository.EXPECT().Get(gomock.Any(), entity.OIDCState{StateHash: &stateHash}).Return(entity.OIDCState{ID: &id, ExpireAt: &expiredAt}, nil)
+
+		_, err := authUsecase.AdminOIDCCallback(context.Background(), oidcCallback)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+
+	test.Run("Unauthorized/StateReplayed", func(test *testing.T) {
+		mockOIDCStateRepository.EXPECT().Get(gomock.Any(), entity.OIDCState{StateHash: &stateHash}).Return(oidcState, nil)
+		mockOIDCStateRepository.EXPECT().Use(gomock.Any(), entity.OIDCState{ID: &id}).Return(gorm.ErrRecordNotFound)
+
+		_, err := authUsecase.AdminOIDCCallback(context.Background(), oidcCallback)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+
+	test.Run("BadRequest", func(test *testing.T) {
+		_, err := authUsecase.AdminOIDCCallback(context.Background(), entity.OIDCCallback{State: &state})
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+	})
+}
+
+func TestAuthAdminReset(test *testing.T) {
+	mockAdminRepository, _, _, _, _, _, _, _, _, _, _, _, _, authUsecase := beforeTestAuth(test)
+
+	id := uint64(1)
+	password := "Correct.Horse42"
+	reset := entity.Reset{
+		ID:       &id,
+		Password: &password,
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		isResetPassword := true
+
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{ID: &id, IsResetPassword: &isResetPassword}, nil)
+		mockAdminRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+				assert.False(test, *admin.IsResetPassword)
+				assert.NoError(test, bcrypt.CompareHashAndPassword(*admin.PasswordHash, []byte(password)))
+				assert.Equal(test, entity.ResetPasswordAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, entity.AdminAuditEntityType, *auditLogs[0].EntityType)
+				assert.Equal(test, id, *auditLogs[0].EntityID)
+				assert.Equal(test, "[REDACTED]", auditLogs[0].After["password_hash"])
+				return nil
+			},
+		)
+
+		err := authUsecase.AdminReset(context.Background(), reset, entity.Audit{})
+		assert.NoError(test, err)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{ID: &id}, nil)
+		mockAdminRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := authUsecase.AdminReset(context.Background(), reset, entity.Audit{})
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("BadRequest/PasswordPolicy", func(test *testing.T) {
+		commonPassword := "password"
+
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{ID: &id}, nil)
+
+		err := authUsecase.AdminReset(context.Background(), entity.Reset{ID: &id, Password: &commonPassword}, entity.Audit{})
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+		assert.Equal(test, "common", err.(util.Error).Details[0].Rule)
+	})
+
+	test.Run("RecordNotFound", func(test *testing.T) {
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{}, gorm.ErrRecordNotFound)
+
+		err := authUsecase.AdminReset(context.Background(), reset, entity.Audit{})
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+}
+
+func TestAuthLogout(test *testing.T) {
+	_, _, _, _, _, _, mockRefreshTokenRepository, mockRevokedTokenRepository, _, mockSessionRepository, _, _, _, authUsecase := beforeTestAuth(test)
+
+	id := uint64(1)
+	jti := "jti"
+	familyID := "familyID"
+	token := "token"
+	expireAt := time.Now().Add(time.Hour)
+	logout := entity.Logout{
+		UserID:   &id,
+		JTI:      &jti,
+		ExpireAt: &expireAt,
+	}
+	revokedToken := entity.RevokedToken{
+		JTI:      &jti,
+		UserID:   &id,
+		ExpireAt: &expireAt,
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), revokedToken).Return(nil)
+
+		err := authUsecase.Logout(context.Background(), logout)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Success/RefreshToken", func(test *testing.T) {
+		logout := logout
+		logout.RefreshToken = &token
+
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), revokedToken).Return(nil)
+		mockRefreshTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.RefreshToken{FamilyID: &familyID}, nil)
+		mockRefreshTokenRepository.EXPECT().RevokeFamily(gomock.Any(), familyID).Return(nil)
+
+		err := authUsecase.Logout(context.Background(), logout)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Success/Session", func(test *testing.T) {
+		logout := logout
+		logout.SessionID = &id
+
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), revokedToken).Return(nil)
+		mockSessionRepository.EXPECT().Get(gomock.Any(), entity.Session{ID: &id, UserID: &id}).Return(entity.Session{ID: &id, FamilyID: &familyID}, nil)
+		mockRefreshTokenRepository.EXPECT().RevokeFamily(gomock.Any(), familyID).Return(nil)
+
+		err := authUsecase.Logout(context.Background(), logout)
+		assert.NoError(test, err)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), revokedToken).Return(errors.New("internal error"))
+
+		err := authUsecase.Logout(context.Background(), logout)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("Unauthorized", func(test *testing.T) {
+		err := authUsecase.Logout(context.Background(), entity.Logout{UserID: &id})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+}
+
+func TestAuthLogoutAll(test *testing.T) {
+	_, _, _, _, _, _, mockRefreshTokenRepository, mockRevokedTokenRepository, _, _, _, _, _, authUsecase := beforeTestAuth(test)
+
+	id := uint64(1)
+	logout := entity.Logout{AdminID: &id}
+
+	test.Run("Success", func(test *testing.T) {
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, revokedToken entity.RevokedToken) error {
+				assert.Nil(test, revokedToken.JTI)
+				assert.Equal(test, id, *revokedToken.AdminID)
+				assert.NotNil(test, revokedToken.ExpireAt)
+				return nil
+			},
+		)
+		mockRefreshTokenRepository.EXPECT().RevokeAll(gomock.Any(), entity.RefreshToken{AdminID: &id}).Return(nil)
+
+		err := authUsecase.LogoutAll(context.Background(), logout)
+		assert.NoError(test, err)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := authUsecase.LogoutAll(context.Background(), logout)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+}
+
+func TestAuthUserForgot(test *testing.T) {
+	_, _, _, mockPasswordResetTokenRepository, _, _, _, _, _, _, mockUserRepository, mockNotifier, _, authUsecase := beforeTestAuth(test)
+
+	id := uint64(1)
+	username := "username"
+	email := "user@example.com"
+	phone := "0812345678"
+	forgot := entity.Forgot{Username: &username}
+
+	test.Run("Success/Email", func(test *testing.T) {
+		token := ""
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(entity.User{ID: &id, Email: &email, Phone: &phone}, nil)
+		mockPasswordResetTokenRepository.EXPECT().UseAll(gomock.Any(), entity.PasswordResetToken{UserID: &id}).Return(nil)
+		mockPasswordResetTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, passwordResetToken entity.PasswordResetToken) error {
+				assert.Equal(test, id, *passwordResetToken.UserID)
+				assert.False(test, passwordResetToken.IsExpired())
+				token = *passwordResetToken.TokenHash
+				return nil
+			},
+		)
+		mockNotifier.EXPECT().Send(gomock.Any()).DoAndReturn(
+			func(notification entity.Notification) error {
+				assert.Equal(test, entity.EmailNotificationChannel, notification.Channel)
+				assert.Equal(test, email, notification.Recipient)
+				assert.NotContains(test, notification.Body, token)
+				return nil
+			},
+		)
+
+		err := authUsecase.UserForgot(context.Background(), forgot)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Success/SMS", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(entity.User{ID: &id, Phone: &phone}, nil)
+		mockPasswordResetTokenRepository.EXPECT().UseAll(gomock.Any(), entity.PasswordResetToken{UserID: &id}).Return(nil)
+		mockPasswordResetTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockNotifier.EXPECT().Send(gomock.Any()).DoAndReturn(
+			func(notification entity.Notification) error {
+				assert.Equal(test, entity.SMSNotificationChannel, notification.Channel)
+				assert.Equal(test, phone, notification.Recipient)
+				return nil
+			},
+		)
+
+		err := authUsecase.UserForgot(context.Background(), forgot)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Success/RecordNotFound", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(entity.User{}, gorm.ErrRecordNotFound)
+
+		err := authUsecase.UserForgot(context.Background(), forgot)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Success/NotifierError", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(entity.User{ID: &id, Email: &email}, nil)
+		mockPasswordResetTokenRepository.EXPECT().UseAll(gomock.Any(), entity.PasswordResetToken{UserID: &id}).Return(nil)
+		mockPasswordResetTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockNotifier.EXPECT().Send(gomock.Any()).Return(errors.New("internal error"))
+
+		err := authUsecase.UserForgot(context.Background(), forgot)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Success/CreateError", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(entity.User{ID: &id, Email: &email}, nil)
+		mockPasswordResetTokenRepository.EXPECT().UseAll(gomock.Any(), entity.PasswordResetToken{UserID: &id}).Return(nil)
+		mockPasswordResetTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := authUsecase.UserForgot(context.Background(), forgot)
+		assert.NoError(test, err)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(entity.User{}, errors.New("internal error"))
+
+		err := authUsecase.UserForgot(context.Background(), forgot)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+}
+
+func TestAuthUserForgotConfirm(test *testing.T) {
+	_, _, _, mockPasswordResetTokenRepository, _, _, mockRefreshTokenRepository, mockRevokedTokenRepository, _, _, mockUserRepository, _, _, authUsecase := beforeTestAuth(test)
+
+	id := uint64(1)
+	username := "username"
+	token := "token"
+	password := "Correct.Horse42"
+	forgotConfirm := entity.ForgotConfirm{Token: &token, Password: &password}
+	expireAt := time.Now().Add(time.Minute)
+
+	test.Run("Success", func(test *testing.T) {
+		mockPasswordResetTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.PasswordResetToken{ID: &id, UserID: &id, ExpireAt: &expireAt}, nil)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username}, nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+				assert.False(test, *user.IsResetPassword)
+				assert.NoError(test, bcrypt.CompareHashAndPassword(*user.PasswordHash, []byte(password)))
+				assert.Equal(test, entity.ForgotPasswordAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, entity.UserAuditEntityType, *auditLogs[0].EntityType)
+				assert.Nil(test, auditLogs[0].ActorID)
+				return nil
+			},
+		)
+		mockPasswordResetTokenRepository.EXPECT().Use(gomock.Any(), entity.PasswordResetToken{ID: &id}).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockRefreshTokenRepository.EXPECT().RevokeAll(gomock.Any(), entity.RefreshToken{UserID: &id}).Return(nil)
+
+		err := authUsecase.UserForgotConfirm(context.Background(), forgotConfirm, entity.Audit{})
+		assert.NoError(test, err)
+	})
+
+	test.Run("BadRequest/PasswordPolicy", func(test *testing.T) {
+		commonPassword := "password"
+
+		mockPasswordResetTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.PasswordResetToken{ID: &id, UserID: &id, ExpireAt: &expireAt}, nil)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username}, nil)
+
+		err := authUsecase.UserForgotConfirm(context.Background(), entity.ForgotConfirm{Token: &token, Password: &commonPassword}, entity.Audit{})
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+	})
+
+	test.Run("Unauthorized/Used", func(test *testing.T) {
+		useAt := time.Now()
+
+		mockPasswordResetTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.PasswordResetToken{ID: &id, UserID: &id, ExpireAt: &expireAt, UseAt: &useAt}, nil)
+
+		err := authUsecase.UserForgotConfirm(context.Background(), forgotConfirm, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+
+	test.Run("Unauthorized/Expired", func(test *testing.T) {
+		expireAt := time.Now().Add(-time.Minute)
+
+		mockPasswordResetTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.PasswordResetToken{ID: &id, UserID: &id, ExpireAt: &expireAt}, nil)
+
+		err := authUsecase.UserForgotConfirm(context.Background(), forgotConfirm, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+
+	test.Run("Unauthorized/RecordNotFound", func(test *testing.T) {
+		mockPasswordResetTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.PasswordResetToken{}, gorm.ErrRecordNotFound)
+
+		err := authUsecase.UserForgotConfirm(context.Background(), forgotConfirm, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+
+	test.Run("Unauthorized/UsedConcurrently", func(test *testing.T) {
+		mockPasswordResetTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.PasswordResetToken{ID: &id, UserID: &id, ExpireAt: &expireAt}, nil)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username}, nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockPasswordResetTokenRepository.EXPECT().Use(gomock.Any(), entity.PasswordResetToken{ID: &id}).Return(gorm.ErrRecordNotFound)
+
+		err := authUsecase.UserForgotConfirm(context.Background(), forgotConfirm, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+}
+
+func TestAuthUserLogin(test *testing.T) {
+	_, _, _, _, _, _, mockRefreshTokenRepository, _, _, mockSessionRepository, mockUserRepository, _, _, authUsecase := beforeTestAuth(test)
+
+	id := uint64(1)
+	username := "username"
+	password := "password"
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	assert.NoError(test, err)
+	login := entity.Login{
+		Username: &username,
+		Password: &password,
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		failedLogin := 2
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(
+			entity.User{
+				ID:           &id,
+				Username:     &username,
+				PasswordHash: &passwordHash,
+				FailedLogin:  &failedLogin,
+			},
+			nil,
+		)
+		mockUserRepository.EXPECT().UpdateLock(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, 0, *user.FailedLogin)
+				assert.Nil(test, user.LockUntil)
+				return nil
+			},
+		)
+		expectNewSession(mockSessionRepository)
+		mockRefreshTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := authUsecase.UserLogin(context.Background(), login)
+		assert.NoError(test, err)
+		assert.NotEmpty(test, *result.AccessToken)
+		assert.NotEmpty(test, *result.RefreshToken)
+	})
+
+	test.Run("Success/IsResetPassword", func(test *testing.T) {
+		isResetPassword := true
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(
+			entity.User{
+				ID:              &id,
+				Username:        &username,
+				PasswordHash:    &passwordHash,
+				IsResetPassword: &isResetPassword,
+			},
+			nil,
+		)
+		mockUserRepository.EXPECT().UpdateLock(gomock.Any(), gomock.Any()).Return(nil)
+		expectNewSession(mockSessionRepository)
+		mockRefreshTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := authUsecase.UserLogin(context.Background(), login)
+		assert.NoError(test, err)
+
+		claims := entity.Claims{}
+		_, err = jwt.ParseWithClaims(*result.AccessToken, &claims, func(tokenJWT *jwt.Token) (interface{}, error) {
+			return []byte(config.JWT.Key), nil
+		})
+		assert.NoError(test, err)
+		assert.True(test, claims.IsResetPassword)
+	})
+
+	test.Run("Success/MFARequired", func(test *testing.T) {
+		isMFAEnabled := true
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(
+			entity.User{
+				ID:           &id,
+				Username:     &username,
+				PasswordHash: &passwordHash,
+				IsMFAEnabled: &isMFAEnabled,
+			},
+			nil,
+		)
+		mockUserRepository.EXPECT().UpdateLock(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := authUsecase.UserLogin(context.Background(), login)
+		assert.NoError(test, err)
+		assert.True(test, *result.MFARequired)
+		assert.NotEmpty(test, *result.MFAToken)
+		assert.Nil(test, result.AccessToken)
+		assert.Nil(test, result.RefreshToken)
+	})
+
+	test.Run("Unauthorized/Password", func(test *testing.T) {
+		failedLogin := 4
+		wrongPassword := "wrongPassword"
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(
+			entity.User{
+				ID:           &id,
+				Username:     &username,
+				PasswordHash: &passwordHash,
+				FailedLogin:  &failedLogin,
+			},
+			nil,
+		)
+		mockUserRepository.EXPECT().IncreaseFailedLogin(gomock.Any(), entity.User{ID: &id}).Return(5, nil)
+		mockUserRepository.EXPECT().UpdateLock(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, 5, *user.FailedLogin)
+				assert.True(test, user.IsLocked())
+				return nil
+			},
+		)
+
+		result, err := authUsecase.UserLogin(context.Background(), entity.Login{Username: &username, Password: &wrongPassword})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("Unauthorized/Locked", func(test *testing.T) {
+		lockUntil := time.Now().Add(time.Minute)
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(
+			entity.User{
+				ID:           &id,
+				Username:     &username,
+				PasswordHash: &passwordHash,
+				LockUntil:    &lockUntil,
+			},
+			nil,
+		)
+
+		result, err := authUsecase.UserLogin(context.Background(), login)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("Unauthorized/RecordNotFound", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(entity.User{}, gorm.ErrRecordNotFound)
+
+		result, err := authUsecase.UserLogin(context.Background(), login)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(entity.User{}, errors.New("internal error"))
+
+		result, err := authUsecase.UserLogin(context.Background(), login)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+}
+
+func TestAuthUserVerifyMFA(test *testing.T) {
+	_, _, _, _, _, mockRecoveryCodeRepository, mockRefreshTokenRepository, mockRevokedTokenRepository, _, mockSessionRepository, mockUserRepository, _, _, authUsecase := beforeTestAuth(test)
+
+	id := uint64(1)
+	username := "username"
+	isMFAEnabled := true
+	key, err := totp.Generate(totp.GenerateOpts{Issuer: "issuer", AccountName: username})
+	assert.NoError(test, err)
+	secret := key.Secret()
+	user := entity.User{
+		ID:           &id,
+		Username:     &username,
+		MFASecret:    &secret,
+		IsMFAEnabled: &isMFAEnabled,
+	}
+	mfaToken, err := middleware.GenerateMFAToken(id, entity.UserRoleName)
+	assert.NoError(test, err)
+
+	test.Run("Success", func(test *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		assert.NoError(test, err)
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(user, nil).Times(2)
+		mockRevokedTokenRepository.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+		mockUserRepository.EXPECT().UpdateMFAStep(gomock.Any(), gomock.Any()).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, revokedToken entity.RevokedToken) error {
+				assert.NotEmpty(test, *revokedToken.JTI)
+				return nil
+			},
+		)
+		expectNewSession(mockSessionRepository)
+		mockRefreshTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := authUsecase.UserVerifyMFA(context.Background(), entity.MFA{MFAToken: &mfaToken, Code: &code}, entity.Audit{})
+		assert.NoError(test, err)
+		assert.NotEmpty(test, *result.AccessToken)
+		assert.NotEmpty(test, *result.RefreshToken)
+	})
+
+	test.Run("Success/RecoveryCode", func(test *testing.T) {
+		recoveryCode := "ABCDE-12345"
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(user, nil).Times(2)
+		mockRevokedTokenRepository.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+		mockRecoveryCodeRepository.EXPECT().Use(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, recoveryCode entity.RecoveryCode) error {
+				assert.Equal(test, id, *recoveryCode.UserID)
+				assert.NotEmpty(test, *recoveryCode.CodeHash)
+				return nil
+			},
+		)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		expectNewSession(mockSessionRepository)
+		mockRefreshTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := authUsecase.UserVerifyMFA(context.Background(), entity.MFA{MFAToken: &mfaToken, RecoveryCode: &recoveryCode}, entity.Audit{})
+		assert.NoError(test, err)
+		assert.NotEmpty(test, *result.AccessToken)
+	})
+
+	test.Run("Unauthorized/Code", func(test *testing.T) {
+		code := "000000"
+		if validCode, _ := totp.GenerateCode(secret, time.Now()); validCode == code {
+			code = "111111"
+		}
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(user, nil)
+		mockRevokedTokenRepository.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+		mockUserRepository.EXPECT().IncreaseFailedLogin(gomock.Any(), entity.User{ID: &id}).Return(1, nil)
+
+		result, err := authUsecase.UserVerifyMFA(context.Background(), entity.MFA{MFAToken: &mfaToken, Code: &code}, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("Unauthorized/RecoveryCode", func(test *testing.T) {
+		recoveryCode := "ABCDE-12345"
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(user, nil)
+		mockRevokedTokenRepository.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+		mockRecoveryCodeRepository.EXPECT().Use(gomock.Any(), gomock.Any()).Return(gorm.ErrRecordNotFound)
+		mockUserRepository.EXPECT().IncreaseFailedLogin(gomock.Any(), entity.User{ID: &id}).Return(1, nil)
+
+		result, err := authUsecase.UserVerifyMFA(context.Background(), entity.MFA{MFAToken: &mfaToken, RecoveryCode: &recoveryCode}, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("Unauthorized/RecoveryCode/Lock", func(test *testing.T) {
+		recoveryCode := "ABCDE-12345"
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(user, nil)
+		mockRevokedTokenRepository.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+		mockRecoveryCodeRepository.EXPECT().Use(gomock.Any(), gomock.Any()).Return(gorm.ErrRecordNotFound)
+		mockUserRepository.EXPECT().IncreaseFailedLogin(gomock.Any(), entity.User{ID: &id}).Return(5, nil)
+		mockUserRepository.EXPECT().UpdateLock(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, 5, *user.FailedLogin)
+				assert.True(test, user.IsLocked())
+				return nil
+			},
+		)
+
+		result, err := authUsecase.UserVerifyMFA(context.Background(), entity.MFA{MFAToken: &mfaToken, RecoveryCode: &recoveryCode}, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("Unauthorized/Locked", func(test *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		assert.NoError(test, err)
+		lockUntil := time.Now().Add(time.Minute)
+		user := user
+		user.LockUntil = &lockUntil
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(user, nil)
+		mockRevokedTokenRepository.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
+		result, err := authUsecase.UserVerifyMFA(context.Background(), entity.MFA{MFAToken: &mfaToken, Code: &code}, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("Unauthorized/MFATokenUsed", func(test *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		assert.NoError(test, err)
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(user, nil)
+		mockRevokedTokenRepository.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+
+		result, err := authUsecase.UserVerifyMFA(context.Background(), entity.MFA{MFAToken: &mfaToken, Code: &code}, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("Unauthorized/MFATokenUsedConcurrently", func(test *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		assert.NoError(test, err)
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(user, nil)
+		mockRevokedTokenRepository.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+		mockUserRepository.EXPECT().UpdateMFAStep(gomock.Any(), gomock.Any()).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(entity.ConstraintError{Type: entity.UniqueConstraintType, Field: "jti"})
+
+		result, err := authUsecase.UserVerifyMFA(context.Background(), entity.MFA{MFAToken: &mfaToken, Code: &code}, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("Unauthorized/CodeReplayed", func(test *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		assert.NoError(test, err)
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(user, nil)
+		mockRevokedTokenRepository.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+		mockUserRepository.EXPECT().UpdateMFAStep(gomock.Any(), gomock.Any()).Return(gorm.ErrRecordNotFound)
+		mockUserRepository.EXPECT().IncreaseFailedLogin(gomock.Any(), entity.User{ID: &id}).Return(1, nil)
+
+		result, err := authUsecase.UserVerifyMFA(context.Background(), entity.MFA{MFAToken: &mfaToken, Code: &code}, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("Unauthorized/MFAToken", func(test *testing.T) {
+		invalidToken := "invalidToken"
+
+		result, err := authUsecase.UserVerifyMFA(context.Background(), entity.MFA{MFAToken: &invalidToken}, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+}
+
+func TestAuthUserRefresh(test *testing.T) {
+	_, _, _, _, _, _, mockRefreshTokenRepository, _, _, mockSessionRepository, mockUserRepository, _, _, authUsecase := beforeTestAuth(test)
+
+	id := uint64(1)
+	familyID := "familyID"
+	token := "token"
+	refresh := entity.Refresh{RefreshToken: &token}
+	expireAt := time.Now().Add(time.Hour)
+
+	test.Run("Success", func(test *testing.T) {
+		mockRefreshTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(
+			entity.RefreshToken{
+				ID:       &id,
+				UserID:   &id,
+				FamilyID: &familyID,
+				ExpireAt: &expireAt,
+			},
+			nil,
+		)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id}, nil)
+		mockRefreshTokenRepository.EXPECT().Revoke(gomock.Any(), gomock.Any()).Return(nil)
+		expectExistingSession(mockSessionRepository)
+		mockRefreshTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, refreshToken entity.RefreshToken) error {
+				assert.Equal(test, familyID, *refreshToken.FamilyID)
+				assert.Equal(test, id, *refreshToken.UserID)
+				return nil
+			},
+		)
+
+		result, err := authUsecase.UserRefresh(context.Background(), refresh)
+		assert.NoError(test, err)
+		assert.NotEmpty(test, *result.AccessToken)
+		assert.NotEqual(test, token, *result.RefreshToken)
+	})
+
+	test.Run("Reused", func(test *testing.T) {
+		revokeAt := time.Now()
+
+		mockRefreshTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(
+			entity.RefreshToken{
+				ID:       &id,
+				UserID:   &id,
+				FamilyID: &familyID,
+				ExpireAt: &expireAt,
+				RevokeAt: &revokeAt,
+			},
+			nil,
+		)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id}, nil)
+		mockRefreshTokenRepository.EXPECT().RevokeFamily(gomock.Any(), familyID).Return(nil)
+
+		result, err := authUsecase.UserRefresh(context.Background(), refresh)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("ReusedConcurrently", func(test *testing.T) {
+		mockRefreshTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(
+			entity.RefreshToken{
+				ID:       &id,
+				UserID:   &id,
+				FamilyID: &familyID,
+				ExpireAt: &expireAt,
+			},
+			nil,
+		)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id}, nil)
+		mockRefreshTokenRepository.EXPECT().Revoke(gomock.Any(), gomock.Any()).Return(gorm.ErrRecordNotFound)
+		mockRefreshTokenRepository.EXPECT().RevokeFamily(gomock.Any(), familyID).Return(nil)
+
+		result, err := authUsecase.UserRefresh(context.Background(), refresh)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("Expired", func(test *testing.T) {
+		expiredAt := time.Now().Add(-time.Hour)
+
+		mockRefreshTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(
+			entity.RefreshToken{
+				ID:       &id,
+				UserID:   &id,
+				FamilyID: &familyID,
+				ExpireAt: &expiredAt,
+			},
+			nil,
+		)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id}, nil)
+		mockRefreshTokenRepository.EXPECT().Revoke(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := authUsecase.UserRefresh(context.Background(), refresh)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("RecordNotFound", func(test *testing.T) {
+		mockRefreshTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.RefreshToken{}, gorm.ErrRecordNotFound)
+
+		result, err := authUsecase.UserRefresh(context.Background(), refresh)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("AdminToken", func(test *testing.T) {
+		mockRefreshTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(
+			entity.RefreshToken{
+				ID:       &id,
+				AdminID:  &id,
+				FamilyID: &familyID,
+				ExpireAt: &expireAt,
+			},
+			nil,
+		)
+
+		result, err := authUsecase.UserRefresh(context.Background(), refresh)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+
+	test.Run("Locked", func(test *testing.T) {
+		lockUntil := time.Now().Add(time.Minute)
+
+		mockRefreshTokenRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return(
+			entity.RefreshToken{
+				ID:       &id,
+				UserID:   &id,
+				FamilyID: &familyID,
+				ExpireAt: &expireAt,
+			},
+			nil,
+		)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, LockUntil: &lockUntil}, nil)
+
+		result, err := authUsecase.UserRefresh(context.Background(), refresh)
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+		assert.Equal(test, entity.AccessToken{}, result)
+	})
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+// description: constraint error keeps its type so handler answers 409 or 422, anything else is internal error
+func newRepositoryError(err error) error {
+	constraintError := entity.ConstraintError{}
+	if errors.As(err, &constraintError) {
+		return constraintError
+	}
+
+	return util.NewError(common.ErrorCode.Internal, err.Error())
+}
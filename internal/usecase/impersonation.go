@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/util"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=usecasemock -destination=../../mock/usecase/impersonation.go . Impersonation
+
+type (
+	Impersonation interface {
+		Impersonate(ctx context.Context, impersonation entity.Impersonation) (entity.AccessToken, error)
+	}
+	impersonationUsecase struct {
+		auditLogRepository repository.AuditLog
+		userRepository     repository.User
+	}
+)
+
+func NewImpersonationUsecase(auditLogRepository repository.AuditLog, userRepository repository.User) Impersonation {
+	return &impersonationUsecase{
+		auditLogRepository: auditLogRepository,
+		userRepository:     userRepository,
+	}
+}
+
+// description: token is only returned once the impersonation is recorded in audit log
+func (usecase *impersonationUsecase) Impersonate(ctx context.Context, impersonation entity.Impersonation) (entity.AccessToken, error) {
+	user, err := usecase.userRepository.Get(ctx, entity.User{ID: impersonation.UserID})
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return entity.AccessToken{}, util.NewError(common.ErrorCode.NotFound, err.Error())
+		default:
+			return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	accessToken, err := middleware.GenerateImpersonationJWT(*user.ID, *impersonation.Audit.ActorID, isResetPassword(user.IsResetPassword))
+	if err != nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	err = usecase.auditLogRepository.Create(ctx,
+		entity.NewAuditLog(impersonation.Audit, entity.ImpersonateAuditAction, entity.UserAuditEntityType, user.ID),
+	)
+	if err != nil {
+		return entity.AccessToken{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return entity.AccessToken{AccessToken: &accessToken}, nil
+}
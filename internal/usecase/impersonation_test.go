@@ -0,0 +1,83 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+	repositorymock "github.com/sndzhng/gin-template/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func beforeTestImpersonation(test *testing.T) (
+	*repositorymock.MockAuditLog,
+	*repositorymock.MockUser,
+	usecase.Impersonation,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	config.JWT = config.JWTConfig{Key: "secret"}
+
+	mockAuditLogRepository := repositorymock.NewMockAuditLog(controller)
+	mockUserRepository := repositorymock.NewMockUser(controller)
+	impersonationUsecase := usecase.NewImpersonationUsecase(mockAuditLogRepository, mockUserRepository)
+
+	return mockAuditLogRepository, mockUserRepository, impersonationUsecase
+}
+
+func TestImpersonationImpersonate(test *testing.T) {
+	mockAuditLogRepository, mockUserRepository, impersonationUsecase := beforeTestImpersonation(test)
+
+	adminID := uint64(1)
+	userID := uint64(2)
+	impersonation := entity.Impersonation{UserID: &userID, Audit: entity.Audit{ActorID: &adminID}}
+
+	test.Run("Success", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &userID}).Return(entity.User{ID: &userID, IsResetPassword: new(bool)}, nil)
+		mockAuditLogRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, auditLog entity.AuditLog) error {
+				assert.Equal(test, adminID, *auditLog.ActorID)
+				assert.Equal(test, entity.ImpersonateAuditAction, *auditLog.Action)
+				assert.Equal(test, userID, *auditLog.EntityID)
+				return nil
+			},
+		)
+
+		result, err := impersonationUsecase.Impersonate(context.Background(), impersonation)
+		assert.NoError(test, err)
+		assert.Nil(test, result.RefreshToken)
+
+		claims := entity.Claims{}
+		_, err = jwt.ParseWithClaims(*result.AccessToken, &claims, func(tokenJWT *jwt.Token) (interface{}, error) {
+			return []byte(config.JWT.Key), nil
+		})
+		assert.NoError(test, err)
+		assert.Equal(test, "2", claims.Subject)
+		assert.Equal(test, "1", claims.Actor.Subject)
+		assert.Equal(test, []entity.RoleName{entity.UserRoleName}, claims.Roles)
+	})
+
+	test.Run("InternalError/AuditLog", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &userID}).Return(entity.User{ID: &userID}, nil)
+		mockAuditLogRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		_, err := impersonationUsecase.Impersonate(context.Background(), impersonation)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &userID}).Return(entity.User{}, gorm.ErrRecordNotFound)
+
+		_, err := impersonationUsecase.Impersonate(context.Background(), impersonation)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+}
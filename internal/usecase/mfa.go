@@ -0,0 +1,462 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/util"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=usecasemock -destination=../../mock/usecase/mfa.go . MFA
+
+const (
+	recoveryCodeAmount = 10
+	// description: 80 bits per code, single sha256 is enough at that entropy and keeps lookup by hash
+	recoveryCodeSize = 10
+	// description: hex digits per dash separated group of shown recovery code
+	recoveryCodeGroupSize = 5
+	qrCodeSize            = 256
+	// description: default period of totp.Generate and totp.Validate
+	totpPeriod = 30
+)
+
+type (
+	MFA interface {
+		Confirm(ctx context.Context, mfa entity.MFA, audit entity.Audit) (entity.MFARecovery, error)
+		Disable(ctx context.Context, mfa entity.MFA, audit entity.Audit) error
+		Enroll(ctx context.Context, mfa entity.MFA, audit entity.Audit) (entity.MFAEnrollment, error)
+	}
+	mfaUsecase struct {
+		adminRepository        repository.Admin
+		policyRepository       repository.Policy
+		recoveryCodeRepository repository.RecoveryCode
+		transactionRepository  repository.Transaction
+		userRepository         repository.User
+	}
+	mfaAccount struct {
+		adminID     *uint64
+		userID      *uint64
+		username    string
+		secret      *string
+		isEnabled   bool
+		lastStep    *int64
+		failedLogin *int
+		lockUntil   *time.Time
+		// description: set when account is resolved from mfa token, jti is recorded once login completes
+		mfaToken        *entity.RevokedToken
+		mfaTokenIssueAt time.Time
+	}
+)
+
+func NewMFAUsecase(
+	adminRepository repository.Admin,
+	policyRepository repository.Policy,
+	recoveryCodeRepository repository.RecoveryCode,
+	transactionRepository repository.Transaction,
+	userRepository repository.User,
+) MFA {
+	return &mfaUsecase{
+		adminRepository:        adminRepository,
+		policyRepository:       policyRepository,
+		recoveryCodeRepository: recoveryCodeRepository,
+		transactionRepository:  transactionRepository,
+		userRepository:         userRepository,
+	}
+}
+
+// description: enable pending secret after verifying first code, returns recovery codes shown only once
+func (usecase *mfaUsecase) Confirm(ctx context.Context, mfa entity.MFA, audit entity.Audit) (entity.MFARecovery, error) {
+	account, err := getMFAAccount(ctx, usecase.adminRepository, usecase.userRepository, mfa)
+	if err != nil {
+		return entity.MFARecovery{}, err
+	}
+	if account.isEnabled {
+		return entity.MFARecovery{}, util.NewError(common.ErrorCode.MFAAlreadyEnabled, "mfa already enabled")
+	}
+
+	recoveryCodes, err := confirmMFAAccount(ctx, usecase.adminRepository, usecase.recoveryCodeRepository, usecase.transactionRepository, usecase.userRepository, account, mfa, audit)
+	if err != nil {
+		return entity.MFARecovery{}, err
+	}
+
+	return entity.MFARecovery{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (usecase *mfaUsecase) Disable(ctx context.Context, mfa entity.MFA, audit entity.Audit) error {
+	account, err := getMFAAccount(ctx, usecase.adminRepository, usecase.userRepository, mfa)
+	if err != nil {
+		return err
+	}
+	if !account.isEnabled {
+		return util.NewError(common.ErrorCode.MFANotEnabled, "mfa not enabled")
+	}
+
+	if account.adminID != nil {
+		policy, err := usecase.policyRepository.Get(ctx)
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+		if policy.IsAdminMFARequired != nil && *policy.IsAdminMFARequired {
+			return util.NewError(common.ErrorCode.MFARequired, "mfa is required for admin")
+		}
+	}
+
+	err = verifyMFAAccount(ctx, usecase.adminRepository, usecase.recoveryCodeRepository, usecase.transactionRepository, usecase.userRepository, account, mfa)
+	if err != nil {
+		return err
+	}
+
+	// description: code is verified before the transaction so a failed attempt is counted even though nothing is disabled
+	return usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := updateMFAAccount(ctx, usecase.adminRepository, usecase.userRepository, account, nil, false, newMFAAuditLog(audit, entity.DisableMFAAuditAction, account, false))
+		if err != nil {
+			return err
+		}
+
+		err = usecase.recoveryCodeRepository.DeleteAll(ctx, entity.RecoveryCode{AdminID: account.adminID, UserID: account.userID})
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+
+		return nil
+	})
+}
+
+// description: generate pending secret, mfa stays disabled until confirmed with a valid code
+func (usecase *mfaUsecase) Enroll(ctx context.Context, mfa entity.MFA, audit entity.Audit) (entity.MFAEnrollment, error) {
+	account, err := getMFAAccount(ctx, usecase.adminRepository, usecase.userRepository, mfa)
+	if err != nil {
+		return entity.MFAEnrollment{}, err
+	}
+	if account.isEnabled {
+		return entity.MFAEnrollment{}, util.NewError(common.ErrorCode.MFAAlreadyEnabled, "mfa already enabled")
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      config.MFA.Issuer,
+		AccountName: account.username,
+	})
+	if err != nil {
+		return entity.MFAEnrollment{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	qrCodeImage, err := key.Image(qrCodeSize, qrCodeSize)
+	if err != nil {
+		return entity.MFAEnrollment{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	qrCodeBuffer := bytes.Buffer{}
+	err = png.Encode(&qrCodeBuffer, qrCodeImage)
+	if err != nil {
+		return entity.MFAEnrollment{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	secret := key.Secret()
+	err = updateMFAAccount(ctx, usecase.adminRepository, usecase.userRepository, account, &secret, false, newMFAAuditLog(audit, entity.EnrollMFAAuditAction, account, false))
+	if err != nil {
+		return entity.MFAEnrollment{}, err
+	}
+
+	uri := key.URL()
+	qrCode := fmt.Sprintf("data:image/png;base64,%s", base64.StdEncoding.EncodeToString(qrCodeBuffer.Bytes()))
+
+	return entity.MFAEnrollment{Secret: &secret, URI: &uri, QRCode: &qrCode}, nil
+}
+
+// description: resolve account from mfa token when given, otherwise from admin id or user id set by handler
+func getMFAAccount(ctx context.Context, adminRepository repository.Admin, userRepository repository.User, mfa entity.MFA) (mfaAccount, error) {
+	account := mfaAccount{}
+	if mfa.MFAToken != nil {
+		claims, err := middleware.ParseMFAToken(*mfa.MFAToken)
+		if err != nil {
+			return mfaAccount{}, util.NewError(common.ErrorCode.Unauthorized, err.Error())
+		}
+		mfa.AdminID, mfa.UserID, err = claims.Account()
+		if err != nil {
+			return mfaAccount{}, util.NewError(common.ErrorCode.Unauthorized, err.Error())
+		}
+		mfaToken, err := claims.RevokedToken()
+		if err != nil {
+			return mfaAccount{}, util.NewError(common.ErrorCode.Unauthorized, err.Error())
+		}
+		account.mfaToken = &mfaToken
+		account.mfaTokenIssueAt = time.Unix(claims.IssuedAt, 0)
+	}
+
+	switch {
+	case mfa.AdminID != nil:
+		admin, err := adminRepository.Get(ctx, entity.Admin{ID: mfa.AdminID})
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return mfaAccount{}, util.NewError(common.ErrorCode.Unauthorized, err.Error())
+			} else {
+				return mfaAccount{}, util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+		}
+
+		account.adminID = admin.ID
+		account.username = *admin.Username
+		account.secret = admin.MFASecret
+		account.isEnabled = admin.IsMFAEnabled != nil && *admin.IsMFAEnabled
+		account.lastStep = admin.MFALastStep
+		account.failedLogin, account.lockUntil = admin.FailedLogin, admin.LockUntil
+
+		return account, nil
+	case mfa.UserID != nil:
+		user, err := userRepository.Get(ctx, entity.User{ID: mfa.UserID})
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return mfaAccount{}, util.NewError(common.ErrorCode.Unauthorized, err.Error())
+			} else {
+				return mfaAccount{}, util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+		}
+
+		account.userID = user.ID
+		account.username = *user.Username
+		account.secret = user.MFASecret
+		account.isEnabled = user.IsMFAEnabled != nil && *user.IsMFAEnabled
+		account.lastStep = user.MFALastStep
+		account.failedLogin, account.lockUntil = user.FailedLogin, user.LockUntil
+
+		return account, nil
+	default:
+		return mfaAccount{}, util.NewError(common.ErrorCode.Unauthorized, "mfa account not found")
+	}
+}
+
+func updateMFAAccount(ctx context.Context, adminRepository repository.Admin, userRepository repository.User, account mfaAccount, secret *string, isEnabled bool, auditLog entity.AuditLog) error {
+	err := error(nil)
+	if account.adminID != nil {
+		err = adminRepository.UpdateMFA(ctx, entity.Admin{ID: account.adminID, MFASecret: secret, IsMFAEnabled: &isEnabled}, auditLog)
+	} else {
+		err = userRepository.UpdateMFA(ctx, entity.User{ID: account.userID, MFASecret: secret, IsMFAEnabled: &isEnabled}, auditLog)
+	}
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return nil
+}
+
+// description: entity is the admin or user of account, secret itself is never written to audit log
+func newMFAAuditLog(audit entity.Audit, action entity.AuditAction, account mfaAccount, isEnabled bool) entity.AuditLog {
+	auditLog := entity.NewAuditLog(audit, action, entity.UserAuditEntityType, account.userID)
+	if account.adminID != nil {
+		auditLog = entity.NewAuditLog(audit, action, entity.AdminAuditEntityType, account.adminID)
+	}
+	auditLog.Before = entity.AuditChange{"is_mfa_enabled": account.isEnabled}
+	auditLog.After = entity.AuditChange{"is_mfa_enabled": isEnabled}
+
+	return auditLog
+}
+
+// description: validate first code of pending secret, enable mfa and replace recovery codes
+func confirmMFAAccount(ctx context.Context,
+	adminRepository repository.Admin,
+	recoveryCodeRepository repository.RecoveryCode,
+	transactionRepository repository.Transaction,
+	userRepository repository.User,
+	account mfaAccount,
+	mfa entity.MFA,
+	audit entity.Audit,
+) ([]string, error) {
+	if account.secret == nil {
+		return []string{}, util.NewError(common.ErrorCode.MFANotEnrolled, "mfa not enrolled")
+	}
+	if mfa.Code == nil {
+		return []string{}, util.NewError(common.ErrorCode.InvalidMFACode, "invalid mfa code")
+	}
+	err := useTOTPCode(ctx, adminRepository, userRepository, account, *mfa.Code)
+	if err != nil {
+		return []string{}, err
+	}
+
+	recoveryCodes := []string{}
+	err = transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := updateMFAAccount(ctx, adminRepository, userRepository, account, account.secret, true, newMFAAuditLog(audit, entity.EnableMFAAuditAction, account, true))
+		if err != nil {
+			return err
+		}
+
+		recoveryCode := entity.RecoveryCode{AdminID: account.adminID, UserID: account.userID}
+		err = recoveryCodeRepository.DeleteAll(ctx, recoveryCode)
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+
+		for i := 0; i < recoveryCodeAmount; i++ {
+			codeBytes := make([]byte, recoveryCodeSize)
+			_, err = rand.Read(codeBytes)
+			if err != nil {
+				return util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+			code := hex.EncodeToString(codeBytes)
+			codeHash := hashToken(code)
+
+			recoveryCode.CodeHash = &codeHash
+			err = recoveryCodeRepository.Create(ctx, recoveryCode)
+			if err != nil {
+				return util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+			recoveryCodes = append(recoveryCodes, formatRecoveryCode(code))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []string{}, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// description: wrong code or recovery code counts as failed login, so mfa is locked out like password login
+func verifyMFAAccount(ctx context.Context,
+	adminRepository repository.Admin,
+	recoveryCodeRepository repository.RecoveryCode,
+	transactionRepository repository.Transaction,
+	userRepository repository.User,
+	account mfaAccount,
+	mfa entity.MFA,
+) error {
+	if account.lockUntil != nil && time.Now().Before(*account.lockUntil) {
+		return util.NewError(common.ErrorCode.AccountLocked, "account is locked")
+	}
+
+	err := checkMFACode(ctx, adminRepository, recoveryCodeRepository, userRepository, account, mfa)
+	if err != nil {
+		e := util.Error{}
+		if errors.As(err, &e) && (e.Code == common.ErrorCode.InvalidMFACode || e.Code == common.ErrorCode.InvalidRecoveryCode) {
+			lockErr := increaseFailedLogin(ctx, adminRepository, transactionRepository, userRepository, account.adminID, account.userID)
+			if lockErr != nil {
+				return lockErr
+			}
+		}
+
+		return err
+	}
+	if isFailedLogin(account.failedLogin, account.lockUntil) {
+		return updateMFAAccountLock(ctx, adminRepository, userRepository, account, new(int), nil)
+	}
+
+	return nil
+}
+
+// description: accept totp code or consume one unused recovery code
+func checkMFACode(ctx context.Context,
+	adminRepository repository.Admin,
+	recoveryCodeRepository repository.RecoveryCode,
+	userRepository repository.User,
+	account mfaAccount,
+	mfa entity.MFA,
+) error {
+	if mfa.Code != nil {
+		if account.secret == nil {
+			return util.NewError(common.ErrorCode.InvalidMFACode, "invalid mfa code")
+		}
+
+		return useTOTPCode(ctx, adminRepository, userRepository, account, *mfa.Code)
+	}
+
+	if mfa.RecoveryCode != nil {
+		codeHash := hashToken(strings.ToLower(strings.ReplaceAll(*mfa.RecoveryCode, "-", "")))
+		recoveryCode := entity.RecoveryCode{
+			AdminID:  account.adminID,
+			UserID:   account.userID,
+			CodeHash: &codeHash,
+		}
+		err := recoveryCodeRepository.Use(ctx, recoveryCode)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return util.NewError(common.ErrorCode.InvalidRecoveryCode, "invalid recovery code")
+			} else {
+				return util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+		}
+
+		return nil
+	}
+
+	return util.NewError(common.ErrorCode.MFACodeRequired, "code or recovery code is required")
+}
+
+// description: code is accepted once, its time step must be after the last accepted one
+func useTOTPCode(ctx context.Context, adminRepository repository.Admin, userRepository repository.User, account mfaAccount, code string) error {
+	step, ok := matchTOTPStep(code, *account.secret)
+	if !ok || (account.lastStep != nil && step <= *account.lastStep) {
+		return util.NewError(common.ErrorCode.InvalidMFACode, "invalid mfa code")
+	}
+
+	err := error(nil)
+	if account.adminID != nil {
+		err = adminRepository.UpdateMFAStep(ctx, entity.Admin{ID: account.adminID, MFALastStep: &step})
+	} else {
+		err = userRepository.UpdateMFAStep(ctx, entity.User{ID: account.userID, MFALastStep: &step})
+	}
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return util.NewError(common.ErrorCode.InvalidMFACode, "invalid mfa code")
+		} else {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// description: time step the code belongs to, one step of skew either way like totp.Validate
+func matchTOTPStep(code string, secret string) (int64, bool) {
+	currentStep := time.Now().Unix() / totpPeriod
+	for _, step := range []int64{currentStep, currentStep - 1, currentStep + 1} {
+		expectedCode, err := totp.GenerateCode(secret, time.Unix(step*totpPeriod, 0))
+		if err == nil && subtle.ConstantTimeCompare([]byte(expectedCode), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+func updateMFAAccountLock(ctx context.Context, adminRepository repository.Admin, userRepository repository.User, account mfaAccount, failedLogin *int, lockUntil *time.Time) error {
+	err := error(nil)
+	if account.adminID != nil {
+		err = adminRepository.UpdateLock(ctx, entity.Admin{ID: account.adminID, FailedLogin: failedLogin, LockUntil: lockUntil})
+	} else {
+		err = userRepository.UpdateLock(ctx, entity.User{ID: account.userID, FailedLogin: failedLogin, LockUntil: lockUntil})
+	}
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return nil
+}
+
+// description: split hex code into dash separated groups for reading, dashes are dropped again on verify
+func formatRecoveryCode(code string) string {
+	groups := []string{}
+	for start := 0; start < len(code); start += recoveryCodeGroupSize {
+		end := start + recoveryCodeGroupSize
+		if end > len(code) {
+			end = len(code)
+		}
+		groups = append(groups, code[start:end])
+	}
+
+	return strings.Join(groups, "-")
+}
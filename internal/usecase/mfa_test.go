@@ -0,0 +1,228 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pquerna/otp/totp"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+	repositorymock "github.com/sndzhng/gin-template/mock/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func beforeTestMFA(test *testing.T) (
+	*repositorymock.MockAdmin,
+	*repositorymock.MockPolicy,
+	*repositorymock.MockRecoveryCode,
+	*repositorymock.MockUser,
+	usecase.MFA,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	config.MFA.Issuer = "issuer"
+
+	mockAdminRepository := repositorymock.NewMockAdmin(controller)
+	mockPolicyRepository := repositorymock.NewMockPolicy(controller)
+	mockRecoveryCodeRepository := repositorymock.NewMockRecoveryCode(controller)
+	mockUserRepository := repositorymock.NewMockUser(controller)
+	mfaUsecase := usecase.NewMFAUsecase(mockAdminRepository, mockPolicyRepository, mockRecoveryCodeRepository, newMockTransaction(controller), mockUserRepository)
+
+	return mockAdminRepository, mockPolicyRepository, mockRecoveryCodeRepository, mockUserRepository, mfaUsecase
+}
+
+func TestMFAConfirm(test *testing.T) {
+	_, _, mockRecoveryCodeRepository, mockUserRepository, mfaUsecase := beforeTestMFA(test)
+
+	id := uint64(1)
+	username := "username"
+	key, err := totp.Generate(totp.GenerateOpts{Issuer: "issuer", AccountName: username})
+	assert.NoError(test, err)
+	secret := key.Secret()
+	user := entity.User{
+		ID:        &id,
+		Username:  &username,
+		MFASecret: &secret,
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		assert.NoError(test, err)
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(user, nil)
+		mockUserRepository.EXPECT().UpdateMFAStep(gomock.Any(), gomock.Any()).Return(nil)
+		mockUserRepository.EXPECT().UpdateMFA(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, secret, *user.MFASecret)
+				assert.True(test, *user.IsMFAEnabled)
+				assert.Equal(test, entity.EnableMFAAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, entity.UserAuditEntityType, *auditLogs[0].EntityType)
+				return nil
+			},
+		)
+		mockRecoveryCodeRepository.EXPECT().DeleteAll(gomock.Any(), entity.RecoveryCode{UserID: &id}).Return(nil)
+		mockRecoveryCodeRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(10)
+
+		result, err := mfaUsecase.Confirm(context.Background(), entity.MFA{UserID: &id, Code: &code}, entity.Audit{})
+		assert.NoError(test, err)
+		assert.Len(test, result.RecoveryCodes, 10)
+		assert.Regexp(test, "^[0-9a-f]{5}-[0-9a-f]{5}-[0-9a-f]{5}-[0-9a-f]{5}$", result.RecoveryCodes[0])
+	})
+
+	test.Run("BadRequest/NotEnrolled", func(test *testing.T) {
+		code := "123456"
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username}, nil)
+
+		result, err := mfaUsecase.Confirm(context.Background(), entity.MFA{UserID: &id, Code: &code}, entity.Audit{})
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+		assert.Equal(test, entity.MFARecovery{}, result)
+	})
+
+	test.Run("Conflict", func(test *testing.T) {
+		isMFAEnabled := true
+		user := user
+		user.IsMFAEnabled = &isMFAEnabled
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(user, nil)
+
+		result, err := mfaUsecase.Confirm(context.Background(), entity.MFA{UserID: &id}, entity.Audit{})
+		assert.Equal(test, http.StatusConflict, err.(util.Error).Status)
+		assert.Equal(test, entity.MFARecovery{}, result)
+	})
+}
+
+func TestMFADisable(test *testing.T) {
+	mockAdminRepository, mockPolicyRepository, mockRecoveryCodeRepository, _, mfaUsecase := beforeTestMFA(test)
+
+	id := uint64(1)
+	username := "username"
+	isMFAEnabled := true
+	key, err := totp.Generate(totp.GenerateOpts{Issuer: "issuer", AccountName: username})
+	assert.NoError(test, err)
+	secret := key.Secret()
+	admin := entity.Admin{
+		ID:           &id,
+		Username:     &username,
+		MFASecret:    &secret,
+		IsMFAEnabled: &isMFAEnabled,
+	}
+
+	test.Run("Success", func(test *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		assert.NoError(test, err)
+
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(admin, nil)
+		mockPolicyRepository.EXPECT().Get(gomock.Any()).Return(entity.Policy{IsAdminMFARequired: new(bool)}, nil)
+		mockAdminRepository.EXPECT().UpdateMFAStep(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, admin entity.Admin) error {
+				assert.Equal(test, time.Now().Unix()/30, *admin.MFALastStep)
+				return nil
+			},
+		)
+		mockAdminRepository.EXPECT().UpdateMFA(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, admin entity.Admin, auditLogs ...entity.AuditLog) error {
+				assert.Nil(test, admin.MFASecret)
+				assert.False(test, *admin.IsMFAEnabled)
+				assert.Equal(test, entity.DisableMFAAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, entity.AdminAuditEntityType, *auditLogs[0].EntityType)
+				assert.Equal(test, id, *auditLogs[0].EntityID)
+				assert.Equal(test, entity.AuditChange{"is_mfa_enabled": true}, auditLogs[0].Before)
+				assert.Equal(test, entity.AuditChange{"is_mfa_enabled": false}, auditLogs[0].After)
+				return nil
+			},
+		)
+		mockRecoveryCodeRepository.EXPECT().DeleteAll(gomock.Any(), entity.RecoveryCode{AdminID: &id}).Return(nil)
+
+		err = mfaUsecase.Disable(context.Background(), entity.MFA{AdminID: &id, Code: &code}, entity.Audit{})
+		assert.NoError(test, err)
+	})
+
+	test.Run("Unauthorized/Replay", func(test *testing.T) {
+		currentTime := time.Now()
+		code, err := totp.GenerateCode(secret, currentTime)
+		assert.NoError(test, err)
+		lastStep := currentTime.Unix() / 30
+		admin := admin
+		admin.MFALastStep = &lastStep
+
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(admin, nil)
+		mockPolicyRepository.EXPECT().Get(gomock.Any()).Return(entity.Policy{IsAdminMFARequired: new(bool)}, nil)
+		mockAdminRepository.EXPECT().IncreaseFailedLogin(gomock.Any(), entity.Admin{ID: &id}).Return(1, nil)
+
+		err = mfaUsecase.Disable(context.Background(), entity.MFA{AdminID: &id, Code: &code}, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+
+	test.Run("Unauthorized/Locked", func(test *testing.T) {
+		code, err := totp.GenerateCode(secret, time.Now())
+		assert.NoError(test, err)
+		lockUntil := time.Now().Add(time.Minute)
+		admin := admin
+		admin.LockUntil = &lockUntil
+
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(admin, nil)
+		mockPolicyRepository.EXPECT().Get(gomock.Any()).Return(entity.Policy{IsAdminMFARequired: new(bool)}, nil)
+
+		err = mfaUsecase.Disable(context.Background(), entity.MFA{AdminID: &id, Code: &code}, entity.Audit{})
+		assert.Equal(test, http.StatusUnauthorized, err.(util.Error).Status)
+	})
+
+	test.Run("Forbidden", func(test *testing.T) {
+		isAdminMFARequired := true
+
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(admin, nil)
+		mockPolicyRepository.EXPECT().Get(gomock.Any()).Return(entity.Policy{IsAdminMFARequired: &isAdminMFARequired}, nil)
+
+		err := mfaUsecase.Disable(context.Background(), entity.MFA{AdminID: &id}, entity.Audit{})
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &id}).Return(entity.Admin{}, errors.New("internal error"))
+
+		err := mfaUsecase.Disable(context.Background(), entity.MFA{AdminID: &id}, entity.Audit{})
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+}
+
+func TestMFAEnroll(test *testing.T) {
+	_, _, _, mockUserRepository, mfaUsecase := beforeTestMFA(test)
+
+	id := uint64(1)
+	username := "username"
+
+	test.Run("Success", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username}, nil)
+		mockUserRepository.EXPECT().UpdateMFA(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+				assert.NotEmpty(test, *user.MFASecret)
+				assert.False(test, *user.IsMFAEnabled)
+				assert.Equal(test, entity.EnrollMFAAuditAction, *auditLogs[0].Action)
+				return nil
+			},
+		)
+
+		result, err := mfaUsecase.Enroll(context.Background(), entity.MFA{UserID: &id}, entity.Audit{})
+		assert.NoError(test, err)
+		assert.NotEmpty(test, *result.Secret)
+		assert.Contains(test, *result.URI, "otpauth://totp/")
+		assert.Contains(test, *result.QRCode, "data:image/png;base64,")
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username}, nil)
+		mockUserRepository.EXPECT().UpdateMFA(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		result, err := mfaUsecase.Enroll(context.Background(), entity.MFA{UserID: &id}, entity.Audit{})
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+		assert.Equal(test, entity.MFAEnrollment{}, result)
+	})
+}
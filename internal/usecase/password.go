@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/util"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	lowerCharacterClass  = "lower"
+	upperCharacterClass  = "upper"
+	digitCharacterClass  = "digit"
+	symbolCharacterClass = "symbol"
+)
+
+type (
+	passwordPolicy struct {
+		characterClasses []string
+		commonPasswords  map[string]bool
+		historyAmount    int
+		minLength        int
+		isRegexp         bool
+	}
+	// description: password hash is the current hash, nil when account is being created
+	passwordAccount struct {
+		adminID      *uint64
+		userID       *uint64
+		username     *string
+		passwordHash *[]byte
+	}
+)
+
+var currentPasswordPolicy = passwordPolicy{}
+
+func InitialPasswordPolicy() {
+	policy, err := newPasswordPolicy(config.Password)
+	if err != nil {
+		log.Fatalf("Error initial password policy: %s", err)
+	}
+
+	currentPasswordPolicy = policy
+}
+
+// description: empty value disables the rule, empty common list file falls back to embedded list
+func newPasswordPolicy(passwordConfig config.PasswordConfig) (passwordPolicy, error) {
+	policy := passwordPolicy{commonPasswords: map[string]bool{}}
+
+	for _, characterClass := range strings.Split(passwordConfig.CharacterClass, ",") {
+		characterClass = strings.TrimSpace(characterClass)
+		switch characterClass {
+		case "":
+			continue
+		case lowerCharacterClass, upperCharacterClass, digitCharacterClass, symbolCharacterClass:
+			policy.characterClasses = append(policy.characterClasses, characterClass)
+		default:
+			return passwordPolicy{}, fmt.Errorf("unknown character class %s", characterClass)
+		}
+	}
+
+	var err error
+	if passwordConfig.HistoryAmount != "" {
+		policy.historyAmount, err = strconv.Atoi(passwordConfig.HistoryAmount)
+		if err != nil {
+			return passwordPolicy{}, err
+		}
+	}
+	if passwordConfig.MinLength != "" {
+		policy.minLength, err = strconv.Atoi(passwordConfig.MinLength)
+		if err != nil {
+			return passwordPolicy{}, err
+		}
+	}
+	if passwordConfig.Regexp != "" {
+		policy.isRegexp, err = strconv.ParseBool(passwordConfig.Regexp)
+		if err != nil {
+			return passwordPolicy{}, err
+		}
+	}
+
+	commonPasswordList := common.CommonPasswordList
+	if passwordConfig.CommonListFile != "" {
+		commonPasswordBytes, err := os.ReadFile(passwordConfig.CommonListFile)
+		if err != nil {
+			return passwordPolicy{}, err
+		}
+		commonPasswordList = string(commonPasswordBytes)
+	}
+	for _, commonPassword := range strings.Split(commonPasswordList, "\n") {
+		commonPassword = strings.ToLower(strings.TrimSpace(commonPassword))
+		if commonPassword != "" {
+			policy.commonPasswords[commonPassword] = true
+		}
+	}
+
+	return policy, nil
+}
+
+func checkUsername(username *string) []util.ErrorDetail {
+	if username == nil {
+		return []util.ErrorDetail{}
+	}
+
+	isMatch, _ := regexp.MatchString(common.Regexp.Username, *username)
+	if !isMatch {
+		return []util.ErrorDetail{{Field: "username", Rule: "pattern", Message: "username must be at least 8 letters or digits, optionally separated by . or _"}}
+	}
+
+	return []util.ErrorDetail{}
+}
+
+// description: collect every violated rule, reuse is checked against current hash and stored history
+func checkPassword(ctx context.Context, passwordHistoryRepository repository.PasswordHistory, account passwordAccount, password string) ([]util.ErrorDetail, error) {
+	policy := currentPasswordPolicy
+	details := []util.ErrorDetail{}
+
+	if utf8.RuneCountInString(password) < policy.minLength {
+		details = append(details, util.ErrorDetail{Field: "password", Rule: "min_length", Param: strconv.Itoa(policy.minLength), Message: fmt.Sprintf("password must be at least %d characters", policy.minLength)})
+	}
+
+	for _, characterClass := range policy.characterClasses {
+		if !containsCharacterClass(password, characterClass) {
+			details = append(details, util.ErrorDetail{Field: "password", Rule: characterClass, Message: fmt.Sprintf("password must contain %s character", characterClass)})
+		}
+	}
+
+	if policy.isRegexp {
+		isMatch, _ := regexp.MatchString(common.Regexp.Password, password)
+		if !isMatch {
+			details = append(details, util.ErrorDetail{Field: "password", Rule: "pattern", Message: "password must be letters or digits, optionally separated by . or _"})
+		}
+	}
+
+	if account.username != nil && *account.username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(*account.username)) {
+		details = append(details, util.ErrorDetail{Field: "password", Rule: "username", Message: "password must not contain username"})
+	}
+
+	if policy.commonPasswords[strings.ToLower(password)] {
+		details = append(details, util.ErrorDetail{Field: "password", Rule: "common", Message: "password is too common"})
+	}
+
+	if policy.historyAmount > 0 && account.passwordHash != nil {
+		passwordHashes := []*[]byte{account.passwordHash}
+		if policy.historyAmount > 1 {
+			passwordHistories, err := passwordHistoryRepository.GetAll(ctx,
+				entity.PasswordHistory{AdminID: account.adminID, UserID: account.userID},
+				policy.historyAmount-1,
+			)
+			if err != nil {
+				return []util.ErrorDetail{}, err
+			}
+			for _, passwordHistory := range passwordHistories {
+				passwordHashes = append(passwordHashes, passwordHistory.PasswordHash)
+			}
+		}
+
+		for _, passwordHash := range passwordHashes {
+			if passwordHash != nil && bcrypt.CompareHashAndPassword(*passwordHash, []byte(password)) == nil {
+				details = append(details, util.ErrorDetail{Field: "password", Rule: "reused", Param: strconv.Itoa(policy.historyAmount), Message: fmt.Sprintf("password must differ from last %d passwords", policy.historyAmount)})
+				break
+			}
+		}
+	}
+
+	return details, nil
+}
+
+func newValidationError(details []util.ErrorDetail) error {
+	if len(details) == 0 {
+		return nil
+	}
+
+	return util.NewValidationError(details)
+}
+
+// description: keep replaced hash so later changes can detect reuse
+func savePasswordHistory(ctx context.Context, passwordHistoryRepository repository.PasswordHistory, account passwordAccount) error {
+	if currentPasswordPolicy.historyAmount <= 1 || account.passwordHash == nil {
+		return nil
+	}
+
+	err := passwordHistoryRepository.Create(ctx, entity.PasswordHistory{
+		AdminID:      account.adminID,
+		UserID:       account.userID,
+		PasswordHash: account.passwordHash,
+	})
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func containsCharacterClass(password string, characterClass string) bool {
+	for _, character := range password {
+		switch {
+		case characterClass == lowerCharacterClass && unicode.IsLower(character),
+			characterClass == upperCharacterClass && unicode.IsUpper(character),
+			characterClass == digitCharacterClass && unicode.IsDigit(character),
+			characterClass == symbolCharacterClass && (unicode.IsPunct(character) || unicode.IsSymbol(character)):
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+//go:generate mockgen -package=usecasemock -destination=../../mock/usecase/policy.go . Policy
+
+type (
+	Policy interface {
+		Get(ctx context.Context) (entity.Policy, error)
+		Update(ctx context.Context, policy entity.Policy) error
+	}
+	policyUsecase struct {
+		policyRepository repository.Policy
+	}
+)
+
+func NewPolicyUsecase(policyRepository repository.Policy) Policy {
+	return &policyUsecase{policyRepository: policyRepository}
+}
+
+func (usecase *policyUsecase) Get(ctx context.Context) (entity.Policy, error) {
+	policy, err := usecase.policyRepository.Get(ctx)
+	if err != nil {
+		return entity.Policy{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return policy, nil
+}
+
+func (usecase *policyUsecase) Update(ctx context.Context, policy entity.Policy) error {
+	err := usecase.policyRepository.Update(ctx, policy)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return nil
+}
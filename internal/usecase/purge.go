@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+//go:generate mockgen -package=usecasemock -destination=../../mock/usecase/purge.go . Purge
+
+type (
+	Purge interface {
+		PurgeDeleted(ctx context.Context) (entity.PurgeResult, error)
+	}
+	purgeUsecase struct {
+		adminRepository repository.Admin
+		userRepository  repository.User
+	}
+)
+
+func NewPurgeUsecase(adminRepository repository.Admin, userRepository repository.User) Purge {
+	return &purgeUsecase{
+		adminRepository: adminRepository,
+		userRepository:  userRepository,
+	}
+}
+
+// description: users are purged before admins so an admin whose users are all expired is purged in the same run, admin still owning user is kept
+func (usecase *purgeUsecase) PurgeDeleted(ctx context.Context) (entity.PurgeResult, error) {
+	result := entity.PurgeResult{}
+	deletedRetention, err := time.ParseDuration(config.Purge.DeletedRetention)
+	if err != nil {
+		return result, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	deleted := entity.DeletedOnly
+	deletedFilter := entity.DeletedFilter{Deleted: &deleted, DeleteAtBefore: new(time.Time)}
+	*deletedFilter.DeleteAtBefore = time.Now().Add(-deletedRetention)
+
+	users, err := usecase.userRepository.GetAll(ctx, &entity.UserFilter{DeletedFilter: deletedFilter}, nil, nil)
+	if err != nil {
+		return result, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	for _, user := range users {
+		auditLog := entity.NewAuditLog(entity.Audit{}, entity.PurgeAuditAction, entity.UserAuditEntityType, user.ID).WithChange(user, nil)
+		err = usecase.userRepository.Purge(ctx, entity.User{ID: user.ID}, auditLog)
+		if err != nil {
+			return result, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+		result.UserAmount++
+	}
+
+	admins, err := usecase.adminRepository.GetAll(ctx, &entity.AdminFilter{DeletedFilter: deletedFilter}, nil, nil)
+	if err != nil {
+		return result, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	for _, admin := range admins {
+		isOwner, err := isUserOwner(ctx, usecase.userRepository, admin.ID)
+		if err != nil {
+			return result, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+		if isOwner {
+			continue
+		}
+
+		auditLog := entity.NewAuditLog(entity.Audit{}, entity.PurgeAuditAction, entity.AdminAuditEntityType, admin.ID).WithChange(admin, nil)
+		err = usecase.adminRepository.Purge(ctx, entity.Admin{ID: admin.ID}, auditLog)
+		if err != nil {
+			return result, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+		result.AdminAmount++
+	}
+
+	return result, nil
+}
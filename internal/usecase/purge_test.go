@@ -0,0 +1,92 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+	repositorymock "github.com/sndzhng/gin-template/mock/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func beforeTestPurge(test *testing.T) (
+	*repositorymock.MockAdmin,
+	*repositorymock.MockUser,
+	usecase.Purge,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	config.Purge.DeletedRetention = "720h"
+
+	mockAdminRepository := repositorymock.NewMockAdmin(controller)
+	mockUserRepository := repositorymock.NewMockUser(controller)
+	purgeUsecase := usecase.NewPurgeUsecase(mockAdminRepository, mockUserRepository)
+
+	return mockAdminRepository, mockUserRepository, purgeUsecase
+}
+
+func TestPurgeDeleted(test *testing.T) {
+	mockAdminRepository, mockUserRepository, purgeUsecase := beforeTestPurge(test)
+
+	adminID := uint64(1)
+	ownerID := uint64(2)
+	userID := uint64(3)
+
+	test.Run("Success", func(test *testing.T) {
+		recordCount := int64(1)
+
+		mockUserRepository.EXPECT().GetAll(gomock.Any(), gomock.Any(), nil, nil).DoAndReturn(
+			func(_ context.Context, userFilter *entity.UserFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.User, error) {
+				assert.Equal(test, entity.DeletedOnly, *userFilter.Deleted)
+				assert.WithinDuration(test, time.Now().Add(-720*time.Hour), *userFilter.DeleteAtBefore, time.Minute)
+				return []entity.User{{ID: &userID, AdminID: &adminID}}, nil
+			},
+		)
+		mockUserRepository.EXPECT().Purge(gomock.Any(), entity.User{ID: &userID}, gomock.Any()).DoAndReturn(
+			func(_ context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, entity.PurgeAuditAction, *auditLogs[0].Action)
+				assert.Nil(test, auditLogs[0].ActorID)
+				return nil
+			},
+		)
+		mockAdminRepository.EXPECT().GetAll(gomock.Any(), gomock.Any(), nil, nil).Return([]entity.Admin{{ID: &adminID}, {ID: &ownerID}}, nil)
+		mockUserRepository.EXPECT().GetAll(gomock.Any(), gomock.Any(), nil, gomock.Any()).DoAndReturn(
+			func(_ context.Context, userFilter *entity.UserFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.User, error) {
+				pagination.RecordCount = new(int64)
+				if *userFilter.AdminID == ownerID {
+					pagination.RecordCount = &recordCount
+				}
+				return []entity.User{}, nil
+			},
+		).Times(2)
+		mockAdminRepository.EXPECT().Purge(gomock.Any(), entity.Admin{ID: &adminID}, gomock.Any()).Return(nil)
+
+		result, err := purgeUsecase.PurgeDeleted(context.Background())
+		assert.NoError(test, err)
+		assert.Equal(test, entity.PurgeResult{AdminAmount: 1, UserAmount: 1}, result)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserRepository.EXPECT().GetAll(gomock.Any(), gomock.Any(), nil, nil).Return([]entity.User{{ID: &userID}}, nil)
+		mockUserRepository.EXPECT().Purge(gomock.Any(), entity.User{ID: &userID}, gomock.Any()).Return(errors.New("internal error"))
+
+		_, err := purgeUsecase.PurgeDeleted(context.Background())
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("InternalError/Retention", func(test *testing.T) {
+		config.Purge.DeletedRetention = "invalid"
+		defer func() { config.Purge.DeletedRetention = "720h" }()
+
+		_, err := purgeUsecase.PurgeDeleted(context.Background())
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+}
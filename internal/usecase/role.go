@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/util"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=usecasemock -destination=../../mock/usecase/role.go . Role
+
+var (
+	errorOwnRolePermission = util.NewError(common.ErrorCode.Forbidden, "permissions of own role cannot be changed")
+	errorSuperAdminRole    = util.NewError(common.ErrorCode.SuperAdminRoleProtected, "super admin role cannot be changed")
+)
+
+type (
+	Role interface {
+		Create(ctx context.Context, role entity.Role, audit entity.Audit) (entity.Role, error)
+		Delete(ctx context.Context, role entity.Role, audit entity.Audit) error
+		Get(ctx context.Context, role entity.Role) (entity.Role, error)
+		GetAll(ctx context.Context) ([]entity.Role, error)
+		GetAllPermissions(ctx context.Context) ([]entity.Permission, error)
+		Update(ctx context.Context, role entity.Role, audit entity.Audit) error
+		UpdatePermissions(ctx context.Context, role entity.Role, rolePermission entity.RolePermission, audit entity.Audit) error
+	}
+	roleUsecase struct {
+		adminRepository       repository.Admin
+		permissionRepository  repository.Permission
+		roleRepository        repository.Role
+		transactionRepository repository.Transaction
+	}
+)
+
+func NewRoleUsecase(
+	adminRepository repository.Admin,
+	permissionRepository repository.Permission,
+	roleRepository repository.Role,
+	transactionRepository repository.Transaction,
+) Role {
+	return &roleUsecase{
+		adminRepository:       adminRepository,
+		permissionRepository:  permissionRepository,
+		roleRepository:        roleRepository,
+		transactionRepository: transactionRepository,
+	}
+}
+
+func (usecase *roleUsecase) Create(ctx context.Context, role entity.Role, audit entity.Audit) (entity.Role, error) {
+	err := newValidationError(checkRoleName(role.Name))
+	if err != nil {
+		return entity.Role{}, err
+	}
+
+	auditLog := entity.NewAuditLog(audit, entity.CreateAuditAction, entity.RoleAuditEntityType, nil).WithChange(nil, role)
+	err = usecase.roleRepository.Create(ctx, role, auditLog)
+	if err != nil {
+		return entity.Role{}, newRepositoryError(err)
+	}
+
+	role, err = usecase.roleRepository.Get(ctx, entity.Role{Name: role.Name})
+	if err != nil {
+		return entity.Role{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return role, nil
+}
+
+// description: role assigned to an admin, including soft deleted one, cannot be deleted
+func (usecase *roleUsecase) Delete(ctx context.Context, role entity.Role, audit entity.Audit) error {
+	role, err := usecase.getChangeableRole(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	deleted := entity.DeletedInclude
+	pagination := entity.Pagination{Limit: 1}
+	_, err = usecase.adminRepository.GetAll(ctx,
+		&entity.AdminFilter{Admin: entity.Admin{RoleID: role.ID}, DeletedFilter: entity.DeletedFilter{Deleted: &deleted}},
+		nil,
+		&pagination,
+	)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	if pagination.RecordCount != nil && *pagination.RecordCount > 0 {
+		return util.NewError(common.ErrorCode.RoleInUse, "role is assigned to admin")
+	}
+
+	auditLog := entity.NewAuditLog(audit, entity.DeleteAuditAction, entity.RoleAuditEntityType, role.ID).WithChange(role, nil)
+	err = usecase.roleRepository.Delete(ctx, entity.Role{ID: role.ID}, auditLog)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (usecase *roleUsecase) Get(ctx context.Context, role entity.Role) (entity.Role, error) {
+	role, err := usecase.roleRepository.Get(ctx, role)
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return entity.Role{}, util.NewError(common.ErrorCode.NotFound, err.Error())
+		default:
+			return entity.Role{}, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	return role, nil
+}
+
+func (usecase *roleUsecase) GetAll(ctx context.Context) ([]entity.Role, error) {
+	roles, err := usecase.roleRepository.GetAll(ctx)
+	if err != nil {
+		return []entity.Role{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return roles, nil
+}
+
+func (usecase *roleUsecase) GetAllPermissions(ctx context.Context) ([]entity.Permission, error) {
+	permissions, err := usecase.permissionRepository.GetAll(ctx)
+	if err != nil {
+		return []entity.Permission{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return permissions, nil
+}
+
+func (usecase *roleUsecase) Update(ctx context.Context, role entity.Role, audit entity.Audit) error {
+	err := newValidationError(checkRoleName(role.Name))
+	if err != nil {
+		return err
+	}
+
+	currentRole, err := usecase.getChangeableRole(ctx, entity.Role{ID: role.ID})
+	if err != nil {
+		return err
+	}
+
+	auditLog := entity.NewAuditLog(audit, entity.UpdateAuditAction, entity.RoleAuditEntityType, role.ID).WithChange(currentRole, role)
+	err = usecase.roleRepository.Update(ctx, role, auditLog)
+	if err != nil {
+		return newRepositoryError(err)
+	}
+
+	return nil
+}
+
+// description: replace all permissions, admins get the new permissions on next login or refresh, caller can only grant permissions it holds and cannot change its own role
+func (usecase *roleUsecase) UpdatePermissions(ctx context.Context, role entity.Role, rolePermission entity.RolePermission, audit entity.Audit) error {
+	permissionNames := []entity.PermissionName{}
+	isAdded := map[entity.PermissionName]bool{}
+	details := []util.ErrorDetail{}
+	for _, permissionName := range rolePermission.Permissions {
+		if _, ok := entity.PermissionDescriptions[permissionName]; !ok {
+			details = append(details, util.ErrorDetail{Field: "permissions", Rule: "oneof", Param: string(permissionName), Message: "unknown permission " + string(permissionName)})
+			continue
+		}
+		if !isAdded[permissionName] {
+			isAdded[permissionName] = true
+			permissionNames = append(permissionNames, permissionName)
+		}
+	}
+	err := newValidationError(details)
+	if err != nil {
+		return err
+	}
+
+	return usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		role, err := usecase.getChangeableRole(ctx, role)
+		if err != nil {
+			return err
+		}
+		if role.Name != nil && audit.HasActorRole(entity.RoleName(*role.Name)) {
+			return errorOwnRolePermission
+		}
+
+		currentPermissionNames := role.PermissionNames()
+		isCurrent := map[entity.PermissionName]bool{}
+		for _, permissionName := range currentPermissionNames {
+			isCurrent[permissionName] = true
+		}
+		for _, permissionName := range permissionNames {
+			if !isCurrent[permissionName] && !audit.HasActorPermission(permissionName) {
+				return util.NewError(common.ErrorCode.Forbidden, fmt.Sprintf("%s permission is not held by caller", permissionName))
+			}
+		}
+
+		auditLog := entity.NewAuditLog(audit, entity.UpdateAuditAction, entity.RoleAuditEntityType, role.ID)
+		auditLog.Before = entity.AuditChange{"permissions": currentPermissionNames}
+		auditLog.After = entity.AuditChange{"permissions": permissionNames}
+		err = usecase.roleRepository.UpdatePermissions(ctx, entity.Role{ID: role.ID}, permissionNames, auditLog)
+		if err != nil {
+			return newRepositoryError(err)
+		}
+
+		return nil
+	})
+}
+
+// description: super admin role is kept intact so initial admin and identity provider mapping always have full access
+func (usecase *roleUsecase) getChangeableRole(ctx context.Context, role entity.Role) (entity.Role, error) {
+	role, err := usecase.Get(ctx, role)
+	if err != nil {
+		return entity.Role{}, err
+	}
+	if role.Name != nil && entity.RoleName(*role.Name) == entity.SuperAdminRoleName {
+		return entity.Role{}, errorSuperAdminRole
+	}
+
+	return role, nil
+}
+
+func checkRoleName(name *string) []util.ErrorDetail {
+	if name != nil && (entity.RoleName(*name) == entity.UserRoleName || entity.RoleName(*name) == entity.SuperAdminRoleName) {
+		return []util.ErrorDetail{{Field: "name", Rule: "reserved", Message: "role name is reserved"}}
+	}
+
+	return []util.ErrorDetail{}
+}
@@ -0,0 +1,287 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+	repositorymock "github.com/sndzhng/gin-template/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func beforeTestRole(test *testing.T) (
+	*repositorymock.MockAdmin,
+	*repositorymock.MockPermission,
+	*repositorymock.MockRole,
+	usecase.Role,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	mockAdminRepository := repositorymock.NewMockAdmin(controller)
+	mockPermissionRepository := repositorymock.NewMockPermission(controller)
+	mockRoleRepository := repositorymock.NewMockRole(controller)
+	roleUsecase := usecase.NewRoleUsecase(mockAdminRepository, mockPermissionRepository, mockRoleRepository, newMockTransaction(controller))
+
+	return mockAdminRepository, mockPermissionRepository, mockRoleRepository, roleUsecase
+}
+
+func TestRoleCreate(test *testing.T) {
+	_, _, mockRoleRepository, roleUsecase := beforeTestRole(test)
+
+	audit := testAudit()
+	id := uint64(2)
+	name := "SUPPORT"
+	role := entity.Role{Name: &name}
+
+	test.Run("Success", func(test *testing.T) {
+		mockRoleRepository.EXPECT().Create(gomock.Any(), role, gomock.Any()).DoAndReturn(
+			func(_ context.Context, role entity.Role, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, entity.CreateAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, entity.RoleAuditEntityType, *auditLogs[0].EntityType)
+				assert.Equal(test, *audit.ActorID, *auditLogs[0].ActorID)
+				assert.Equal(test, name, auditLogs[0].After["name"])
+				return nil
+			},
+		)
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{Name: &name}).Return(entity.Role{ID: &id, Name: &name}, nil)
+
+		result, err := roleUsecase.Create(context.Background(), role, audit)
+		assert.NoError(test, err)
+		assert.Equal(test, id, *result.ID)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockRoleRepository.EXPECT().Create(gomock.Any(), role, gomock.Any()).Return(errors.New("internal error"))
+
+		_, err := roleUsecase.Create(context.Background(), role, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("BadRequest/Reserved", func(test *testing.T) {
+		reservedName := string(entity.UserRoleName)
+
+		_, err := roleUsecase.Create(context.Background(), entity.Role{Name: &reservedName}, audit)
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+		assert.Equal(test, "reserved", err.(util.Error).Details[0].Rule)
+	})
+}
+
+func TestRoleDelete(test *testing.T) {
+	mockAdminRepository, _, mockRoleRepository, roleUsecase := beforeTestRole(test)
+
+	audit := testAudit()
+	id := uint64(2)
+	name := "SUPPORT"
+	role := entity.Role{ID: &id}
+	deleted := entity.DeletedInclude
+
+	test.Run("Success", func(test *testing.T) {
+		mockRoleRepository.EXPECT().Get(gomock.Any(), role).Return(entity.Role{ID: &id, Name: &name}, nil)
+		mockAdminRepository.EXPECT().GetAll(gomock.Any(), &entity.AdminFilter{Admin: entity.Admin{RoleID: &id}, DeletedFilter: entity.DeletedFilter{Deleted: &deleted}}, nil, gomock.Any()).DoAndReturn(
+			func(_ context.Context, adminFilter *entity.AdminFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.Admin, error) {
+				pagination.RecordCount = new(int64)
+				return []entity.Admin{}, nil
+			},
+		)
+		mockRoleRepository.EXPECT().Delete(gomock.Any(), role, gomock.Any()).DoAndReturn(
+			func(_ context.Context, role entity.Role, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, entity.DeleteAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, id, *auditLogs[0].EntityID)
+				assert.Equal(test, name, auditLogs[0].Before["name"])
+				return nil
+			},
+		)
+
+		err := roleUsecase.Delete(context.Background(), role, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Conflict", func(test *testing.T) {
+		recordCount := int64(1)
+
+		mockRoleRepository.EXPECT().Get(gomock.Any(), role).Return(entity.Role{ID: &id, Name: &name}, nil)
+		mockAdminRepository.EXPECT().GetAll(gomock.Any(), gomock.Any(), nil, gomock.Any()).DoAndReturn(
+			func(_ context.Context, adminFilter *entity.AdminFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.Admin, error) {
+				pagination.RecordCount = &recordCount
+				return []entity.Admin{{RoleID: &id}}, nil
+			},
+		)
+
+		err := roleUsecase.Delete(context.Background(), role, audit)
+		assert.Equal(test, http.StatusConflict, err.(util.Error).Status)
+	})
+
+	test.Run("Forbidden/SuperAdmin", func(test *testing.T) {
+		superAdminName := string(entity.SuperAdminRoleName)
+
+		mockRoleRepository.EXPECT().Get(gomock.Any(), role).Return(entity.Role{ID: &id, Name: &superAdminName}, nil)
+
+		err := roleUsecase.Delete(context.Background(), role, audit)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockRoleRepository.EXPECT().Get(gomock.Any(), role).Return(entity.Role{}, gorm.ErrRecordNotFound)
+
+		err := roleUsecase.Delete(context.Background(), role, audit)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+}
+
+func TestRoleGetAll(test *testing.T) {
+	_, mockPermissionRepository, mockRoleRepository, roleUsecase := beforeTestRole(test)
+
+	id := uint64(1)
+	name := string(entity.SuperAdminRoleName)
+	permissionName := entity.UserReadPermissionName
+
+	test.Run("Success", func(test *testing.T) {
+		mockRoleRepository.EXPECT().GetAll(gomock.Any()).Return([]entity.Role{{ID: &id, Name: &name}}, nil)
+
+		result, err := roleUsecase.GetAll(context.Background())
+		assert.NoError(test, err)
+		assert.Len(test, result, 1)
+	})
+
+	test.Run("Success/Permissions", func(test *testing.T) {
+		mockPermissionRepository.EXPECT().GetAll(gomock.Any()).Return([]entity.Permission{{ID: &id, Name: &permissionName}}, nil)
+
+		result, err := roleUsecase.GetAllPermissions(context.Background())
+		assert.NoError(test, err)
+		assert.Len(test, result, 1)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockRoleRepository.EXPECT().GetAll(gomock.Any()).Return([]entity.Role{}, errors.New("internal error"))
+
+		result, err := roleUsecase.GetAll(context.Background())
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+		assert.Len(test, result, 0)
+	})
+}
+
+func TestRoleUpdate(test *testing.T) {
+	_, _, mockRoleRepository, roleUsecase := beforeTestRole(test)
+
+	audit := testAudit()
+	id := uint64(2)
+	name := "SUPPORT"
+	newName := "HELPDESK"
+	role := entity.Role{ID: &id, Name: &newName}
+
+	test.Run("Success", func(test *testing.T) {
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &id}).Return(entity.Role{ID: &id, Name: &name}, nil)
+		mockRoleRepository.EXPECT().Update(gomock.Any(), role, gomock.Any()).DoAndReturn(
+			func(_ context.Context, role entity.Role, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, entity.UpdateAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, entity.AuditChange{"name": name}, auditLogs[0].Before)
+				assert.Equal(test, entity.AuditChange{"name": newName}, auditLogs[0].After)
+				return nil
+			},
+		)
+
+		err := roleUsecase.Update(context.Background(), role, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Forbidden/SuperAdmin", func(test *testing.T) {
+		superAdminName := string(entity.SuperAdminRoleName)
+
+		mockRoleRepository.EXPECT().Get(gomock.Any(), entity.Role{ID: &id}).Return(entity.Role{ID: &id, Name: &superAdminName}, nil)
+
+		err := roleUsecase.Update(context.Background(), role, audit)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
+	})
+
+	test.Run("BadRequest/Reserved", func(test *testing.T) {
+		reservedName := string(entity.SuperAdminRoleName)
+
+		err := roleUsecase.Update(context.Background(), entity.Role{ID: &id, Name: &reservedName}, audit)
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+	})
+}
+
+func TestRoleUpdatePermissions(test *testing.T) {
+	_, _, mockRoleRepository, roleUsecase := beforeTestRole(test)
+
+	audit := testAudit()
+	id := uint64(2)
+	name := "SUPPORT"
+	role := entity.Role{ID: &id}
+	readPermissionName := entity.UserReadPermissionName
+	limitedAudit := audit
+	limitedAudit.ActorRoles = []entity.RoleName{"AUDITOR"}
+	limitedAudit.ActorPermissions = []entity.PermissionName{entity.RoleWritePermissionName}
+
+	test.Run("Success", func(test *testing.T) {
+		rolePermission := entity.RolePermission{Permissions: []entity.PermissionName{entity.UserReadPermissionName, entity.UserReadPermissionName, entity.UserWritePermissionName}}
+
+		mockRoleRepository.EXPECT().Get(gomock.Any(), role).Return(entity.Role{ID: &id, Name: &name, Permissions: []entity.Permission{{Name: &readPermissionName}}}, nil)
+		mockRoleRepository.EXPECT().UpdatePermissions(gomock.Any(), role, []entity.PermissionName{entity.UserReadPermissionName, entity.UserWritePermissionName}, gomock.Any()).DoAndReturn(
+			func(_ context.Context, role entity.Role, permissionNames []entity.PermissionName, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, entity.RoleAuditEntityType, *auditLogs[0].EntityType)
+				assert.Equal(test, entity.AuditChange{"permissions": []entity.PermissionName{entity.UserReadPermissionName}}, auditLogs[0].Before)
+				assert.Equal(test, entity.AuditChange{"permissions": permissionNames}, auditLogs[0].After)
+				return nil
+			},
+		)
+
+		err := roleUsecase.UpdatePermissions(context.Background(), role, rolePermission, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Success/KeepNotHeld", func(test *testing.T) {
+		rolePermission := entity.RolePermission{Permissions: []entity.PermissionName{entity.UserReadPermissionName}}
+
+		mockRoleRepository.EXPECT().Get(gomock.Any(), role).Return(entity.Role{ID: &id, Name: &name, Permissions: []entity.Permission{{Name: &readPermissionName}}}, nil)
+		mockRoleRepository.EXPECT().UpdatePermissions(gomock.Any(), role, rolePermission.Permissions, gomock.Any()).Return(nil)
+
+		err := roleUsecase.UpdatePermissions(context.Background(), role, rolePermission, limitedAudit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("BadRequest/Unknown", func(test *testing.T) {
+		rolePermission := entity.RolePermission{Permissions: []entity.PermissionName{"user:delete"}}
+
+		err := roleUsecase.UpdatePermissions(context.Background(), role, rolePermission, audit)
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+		assert.Equal(test, "oneof", err.(util.Error).Details[0].Rule)
+	})
+
+	test.Run("Forbidden/PermissionNotHeld", func(test *testing.T) {
+		rolePermission := entity.RolePermission{Permissions: []entity.PermissionName{entity.UserReadPermissionName, entity.UserWritePermissionName}}
+
+		mockRoleRepository.EXPECT().Get(gomock.Any(), role).Return(entity.Role{ID: &id, Name: &name, Permissions: []entity.Permission{{Name: &readPermissionName}}}, nil)
+
+		err := roleUsecase.UpdatePermissions(context.Background(), role, rolePermission, limitedAudit)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
+	})
+
+	test.Run("Forbidden/OwnRole", func(test *testing.T) {
+		rolePermission := entity.RolePermission{Permissions: []entity.PermissionName{entity.UserReadPermissionName}}
+		ownAudit := audit
+		ownAudit.ActorRoles = []entity.RoleName{entity.RoleName(name)}
+
+		mockRoleRepository.EXPECT().Get(gomock.Any(), role).Return(entity.Role{ID: &id, Name: &name}, nil)
+
+		err := roleUsecase.UpdatePermissions(context.Background(), role, rolePermission, ownAudit)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		rolePermission := entity.RolePermission{Permissions: []entity.PermissionName{entity.UserReadPermissionName}}
+
+		mockRoleRepository.EXPECT().Get(gomock.Any(), role).Return(entity.Role{ID: &id, Name: &name}, nil)
+		mockRoleRepository.EXPECT().UpdatePermissions(gomock.Any(), role, gomock.Any(), gomock.Any()).Return(gorm.ErrRecordNotFound)
+
+		err := roleUsecase.UpdatePermissions(context.Background(), role, rolePermission, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+}
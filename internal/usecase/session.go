@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/repository"
+	"github.com/sndzhng/gin-template/internal/util"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -package=usecasemock -destination=../../mock/usecase/session.go . Session
+
+type (
+	Session interface {
+		GetAll(ctx context.Context, session entity.Session) ([]entity.Session, error)
+		Revoke(ctx context.Context, session entity.Session) error
+		RevokeAll(ctx context.Context, session entity.Session) error
+	}
+	sessionUsecase struct {
+		refreshTokenRepository repository.RefreshToken
+		revokedTokenRepository repository.RevokedToken
+		sessionRepository      repository.Session
+		transactionRepository  repository.Transaction
+		userRepository         repository.User
+	}
+)
+
+func NewSessionUsecase(
+	refreshTokenRepository repository.RefreshToken,
+	revokedTokenRepository repository.RevokedToken,
+	sessionRepository repository.Session,
+	transactionRepository repository.Transaction,
+	userRepository repository.User,
+) Session {
+	return &sessionUsecase{
+		refreshTokenRepository: refreshTokenRepository,
+		revokedTokenRepository: revokedTokenRepository,
+		sessionRepository:      sessionRepository,
+		transactionRepository:  transactionRepository,
+		userRepository:         userRepository,
+	}
+}
+
+func (usecase *sessionUsecase) GetAll(ctx context.Context, session entity.Session) ([]entity.Session, error) {
+	err := usecase.checkUserOwner(ctx, session)
+	if err != nil {
+		return []entity.Session{}, err
+	}
+
+	sessions, err := usecase.sessionRepository.GetAll(ctx, session)
+	if err != nil {
+		return []entity.Session{}, util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return sessions, nil
+}
+
+// description: session is matched with admin id or user id so an account can only revoke its own sessions
+func (usecase *sessionUsecase) Revoke(ctx context.Context, session entity.Session) error {
+	err := usecase.checkUserOwner(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	session, err = usecase.sessionRepository.Get(ctx, session)
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return util.NewError(common.ErrorCode.NotFound, err.Error())
+		default:
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	expireMinute, err := time.ParseDuration(config.JWT.ExpireMinute)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	expireAt := time.Now().Add(expireMinute)
+
+	return usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := usecase.refreshTokenRepository.RevokeFamily(ctx, *session.FamilyID)
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+
+		revokedToken := entity.RevokedToken{
+			SessionID: session.ID,
+			ExpireAt:  &expireAt,
+		}
+		err = usecase.revokedTokenRepository.Create(ctx, revokedToken)
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+
+		return nil
+	})
+}
+
+func (usecase *sessionUsecase) RevokeAll(ctx context.Context, session entity.Session) error {
+	if session.AdminID == nil && session.UserID == nil {
+		return util.NewError(common.ErrorCode.Internal, "admin id and user id are nil")
+	}
+	err := usecase.checkUserOwner(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	return usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		return revokeAllTokens(ctx, usecase.refreshTokenRepository, usecase.revokedTokenRepository, session.AdminID, session.UserID)
+	})
+}
+
+// description: session of user is only reached inside owner scope of request, session of admin is always its own from claims
+func (usecase *sessionUsecase) checkUserOwner(ctx context.Context, session entity.Session) error {
+	if session.UserID == nil {
+		return nil
+	}
+
+	user := entity.User{ID: session.UserID}
+	err := applyOwnerScope(ctx, &user)
+	if err != nil {
+		return err
+	}
+
+	_, err = usecase.userRepository.Get(ctx, user)
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return util.NewError(common.ErrorCode.NotFound, err.Error())
+		default:
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	return nil
+}
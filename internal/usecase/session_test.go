@@ -0,0 +1,173 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/usecase"
+	"github.com/sndzhng/gin-template/internal/util"
+	repositorymock "github.com/sndzhng/gin-template/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func beforeTestSession(test *testing.T) (
+	*repositorymock.MockRefreshToken,
+	*repositorymock.MockRevokedToken,
+	*repositorymock.MockSession,
+	*repositorymock.MockUser,
+	usecase.Session,
+) {
+	controller := gomock.NewController(test)
+	defer controller.Finish()
+
+	config.JWT.ExpireMinute = "15m"
+
+	mockRefreshTokenRepository := repositorymock.NewMockRefreshToken(controller)
+	mockRevokedTokenRepository := repositorymock.NewMockRevokedToken(controller)
+	mockSessionRepository := repositorymock.NewMockSession(controller)
+	mockUserRepository := repositorymock.NewMockUser(controller)
+	sessionUsecase := usecase.NewSessionUsecase(mockRefreshTokenRepository, mockRevokedTokenRepository, mockSessionRepository, newMockTransaction(controller), mockUserRepository)
+
+	return mockRefreshTokenRepository, mockRevokedTokenRepository, mockSessionRepository, mockUserRepository, sessionUsecase
+}
+
+func TestSessionGetAll(test *testing.T) {
+	_, _, mockSessionRepository, mockUserRepository, sessionUsecase := beforeTestSession(test)
+
+	id := uint64(1)
+	ownerID := uint64(2)
+	session := entity.Session{UserID: &id}
+	ctx := ownerScopeContext(test, entity.SuperAdminRoleName, ownerID)
+
+	test.Run("Success", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id}, nil)
+		mockSessionRepository.EXPECT().GetAll(gomock.Any(), session).Return([]entity.Session{{ID: &id, UserID: &id}}, nil)
+
+		result, err := sessionUsecase.GetAll(ctx, session)
+		assert.NoError(test, err)
+		assert.Len(test, result, 1)
+	})
+
+	test.Run("Success/Admin", func(test *testing.T) {
+		mockSessionRepository.EXPECT().GetAll(gomock.Any(), entity.Session{AdminID: &id}).Return([]entity.Session{{ID: &id, AdminID: &id}}, nil)
+
+		result, err := sessionUsecase.GetAll(context.Background(), entity.Session{AdminID: &id})
+		assert.NoError(test, err)
+		assert.Len(test, result, 1)
+	})
+
+	test.Run("Success/User", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id}, nil)
+		mockSessionRepository.EXPECT().GetAll(gomock.Any(), session).Return([]entity.Session{{ID: &id, UserID: &id}}, nil)
+
+		result, err := sessionUsecase.GetAll(ownerScopeContext(test, entity.UserRoleName, id), session)
+		assert.NoError(test, err)
+		assert.Len(test, result, 1)
+	})
+
+	test.Run("NotFound/OwnerScope", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id, AdminID: &ownerID}).Return(entity.User{}, gorm.ErrRecordNotFound)
+
+		result, err := sessionUsecase.GetAll(ownerScopeContext(test, entity.AdminRoleName, ownerID), session)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+		assert.Len(test, result, 0)
+	})
+
+	test.Run("Forbidden/OwnerScope", func(test *testing.T) {
+		result, err := sessionUsecase.GetAll(context.Background(), session)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
+		assert.Len(test, result, 0)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id}, nil)
+		mockSessionRepository.EXPECT().GetAll(gomock.Any(), session).Return([]entity.Session{}, errors.New("internal error"))
+
+		result, err := sessionUsecase.GetAll(ctx, session)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+		assert.Len(test, result, 0)
+	})
+}
+
+func TestSessionRevoke(test *testing.T) {
+	mockRefreshTokenRepository, mockRevokedTokenRepository, mockSessionRepository, mockUserRepository, sessionUsecase := beforeTestSession(test)
+
+	id := uint64(1)
+	ownerID := uint64(2)
+	familyID := "familyID"
+	session := entity.Session{ID: &id, UserID: &id}
+	ctx := ownerScopeContext(test, entity.SuperAdminRoleName, ownerID)
+
+	test.Run("Success", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id}, nil)
+		mockSessionRepository.EXPECT().Get(gomock.Any(), session).Return(entity.Session{ID: &id, UserID: &id, FamilyID: &familyID}, nil)
+		mockRefreshTokenRepository.EXPECT().RevokeFamily(gomock.Any(), familyID).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, revokedToken entity.RevokedToken) error {
+				assert.Equal(test, id, *revokedToken.SessionID)
+				assert.Nil(test, revokedToken.UserID)
+				assert.NotNil(test, revokedToken.ExpireAt)
+				return nil
+			},
+		)
+
+		err := sessionUsecase.Revoke(ctx, session)
+		assert.NoError(test, err)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id}, nil)
+		mockSessionRepository.EXPECT().Get(gomock.Any(), session).Return(entity.Session{ID: &id, UserID: &id, FamilyID: &familyID}, nil)
+		mockRefreshTokenRepository.EXPECT().RevokeFamily(gomock.Any(), familyID).Return(errors.New("internal error"))
+
+		err := sessionUsecase.Revoke(ctx, session)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id}, nil)
+		mockSessionRepository.EXPECT().Get(gomock.Any(), session).Return(entity.Session{}, gorm.ErrRecordNotFound)
+
+		err := sessionUsecase.Revoke(ctx, session)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+
+	test.Run("Forbidden/OwnerScope", func(test *testing.T) {
+		err := sessionUsecase.Revoke(context.Background(), session)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
+	})
+}
+
+func TestSessionRevokeAll(test *testing.T) {
+	mockRefreshTokenRepository, mockRevokedTokenRepository, _, mockUserRepository, sessionUsecase := beforeTestSession(test)
+
+	id := uint64(1)
+	ownerID := uint64(2)
+
+	test.Run("Success", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id}, nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockRefreshTokenRepository.EXPECT().RevokeAll(gomock.Any(), entity.RefreshToken{UserID: &id}).Return(nil)
+
+		err := sessionUsecase.RevokeAll(ownerScopeContext(test, entity.SuperAdminRoleName, ownerID), entity.Session{UserID: &id})
+		assert.NoError(test, err)
+	})
+
+	test.Run("NotFound/OwnerScope", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id, AdminID: &ownerID}).Return(entity.User{}, gorm.ErrRecordNotFound)
+
+		err := sessionUsecase.RevokeAll(ownerScopeContext(test, entity.AdminRoleName, ownerID), entity.Session{UserID: &id})
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		err := sessionUsecase.RevokeAll(context.Background(), entity.Session{})
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+}
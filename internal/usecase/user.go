@@ -1,8 +1,10 @@
 package usecase
 
 import (
-	"net/http"
+	"context"
+	"fmt"
 
+	"github.com/sndzhng/gin-template/internal/common"
 	"github.com/sndzhng/gin-template/internal/entity"
 	"github.com/sndzhng/gin-template/internal/repository"
 	"github.com/sndzhng/gin-template/internal/util"
@@ -12,69 +14,137 @@ import (
 
 //go:generate mockgen -package=usecasemock -destination=../../mock/usecase/user.go . User
 
+var (
+	errorOwnerScopeMissing = util.NewError(common.ErrorCode.Forbidden, "owner scope is missing")
+)
+
 type (
 	User interface {
-		Create(user entity.User) error
-		Delete(user entity.User) error
-		Get(user entity.User) (entity.User, error)
-		GetAll(userFilter *entity.UserFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.User, error)
-		Update(user entity.User) error
+		Create(ctx context.Context, user entity.User, audit entity.Audit) error
+		Delete(ctx context.Context, user entity.User, audit entity.Audit) error
+		Get(ctx context.Context, user entity.User) (entity.User, error)
+		GetAll(ctx context.Context, userFilter *entity.UserFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.User, error)
+		Purge(ctx context.Context, user entity.User, audit entity.Audit) error
+		Reassign(ctx context.Context, user entity.User, userOwner entity.UserOwner, audit entity.Audit) error
+		Restore(ctx context.Context, user entity.User, audit entity.Audit) error
+		Unlock(ctx context.Context, user entity.User, audit entity.Audit) error
+		Update(ctx context.Context, user entity.User, audit entity.Audit) error
 	}
 	userUsecase struct {
-		userRepository repository.User
+		adminRepository           repository.Admin
+		passwordHistoryRepository repository.PasswordHistory
+		refreshTokenRepository    repository.RefreshToken
+		revokedTokenRepository    repository.RevokedToken
+		transactionRepository     repository.Transaction
+		userRepository            repository.User
 	}
 )
 
-func NewUserUsecase(userRepository repository.User) User {
-	return &userUsecase{userRepository: userRepository}
+func NewUserUsecase(
+	adminRepository repository.Admin,
+	passwordHistoryRepository repository.PasswordHistory,
+	refreshTokenRepository repository.RefreshToken,
+	revokedTokenRepository repository.RevokedToken,
+	transactionRepository repository.Transaction,
+	userRepository repository.User,
+) User {
+	return &userUsecase{
+		adminRepository:           adminRepository,
+		passwordHistoryRepository: passwordHistoryRepository,
+		refreshTokenRepository:    refreshTokenRepository,
+		revokedTokenRepository:    revokedTokenRepository,
+		transactionRepository:     transactionRepository,
+		userRepository:            userRepository,
+	}
 }
 
-func (usecase *userUsecase) Create(user entity.User) error {
+func (usecase *userUsecase) Create(ctx context.Context, user entity.User, audit entity.Audit) error {
 	if user.Password == nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: "password is nil"}
+		return util.NewError(common.ErrorCode.Internal, "password is nil")
+	}
+
+	details, err := checkPassword(ctx, usecase.passwordHistoryRepository, passwordAccount{username: user.Username}, *user.Password)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+	err = newValidationError(append(checkUsername(user.Username), details...))
+	if err != nil {
+		return err
 	}
 
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(*user.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		return util.NewError(common.ErrorCode.Internal, err.Error())
 	}
 
 	user.PasswordHash = &passwordHash
-	err = usecase.userRepository.Create(user)
+	auditLog := entity.NewAuditLog(audit, entity.CreateAuditAction, entity.UserAuditEntityType, nil).WithChange(nil, user)
+	err = usecase.userRepository.Create(ctx, user, auditLog)
 	if err != nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		return newRepositoryError(err)
 	}
 
 	return nil
 }
 
-func (usecase *userUsecase) Delete(user entity.User) error {
-	err := usecase.userRepository.Delete(user)
+func (usecase *userUsecase) Delete(ctx context.Context, user entity.User, audit entity.Audit) error {
+	currentUser, err := usecase.Get(ctx, entity.User{ID: user.ID, AdminID: user.AdminID})
 	if err != nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		return err
+	}
+	err = checkVersion(user.Version, currentUser.Version)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	auditLog := entity.NewAuditLog(audit, entity.DeleteAuditAction, entity.UserAuditEntityType, user.ID).WithChange(currentUser, nil)
+	return usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := usecase.userRepository.Delete(ctx, entity.User{ID: user.ID, Version: user.Version}, auditLog)
+		if err != nil {
+			switch err {
+			case gorm.ErrRecordNotFound:
+				return util.NewError(common.ErrorCode.VersionMismatch, "version does not match")
+			default:
+				return util.NewError(common.ErrorCode.Internal, err.Error())
+			}
+		}
+
+		return revokeAllTokens(ctx, usecase.refreshTokenRepository, usecase.revokedTokenRepository, nil, user.ID)
+	})
 }
 
-func (usecase *userUsecase) Get(user entity.User) (entity.User, error) {
-	user, err := usecase.userRepository.Get(user)
+// description: owner scope of request context narrows the lookup, so admin outside super admin role only finds users it owns
+func (usecase *userUsecase) Get(ctx context.Context, user entity.User) (entity.User, error) {
+	err := applyOwnerScope(ctx, &user)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	user, err = usecase.userRepository.Get(ctx, user)
 	if err != nil {
 		switch err {
 		case gorm.ErrRecordNotFound:
-			return entity.User{}, util.Error{Code: http.StatusNotFound, Message: err.Error()}
+			return entity.User{}, util.NewError(common.ErrorCode.NotFound, err.Error())
 		default:
-			return entity.User{}, util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+			return entity.User{}, util.NewError(common.ErrorCode.Internal, err.Error())
 		}
 	}
 
 	return user, nil
 }
 
-func (usecase *userUsecase) GetAll(userFilter *entity.UserFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.User, error) {
-	users, err := usecase.userRepository.GetAll(userFilter, sortOrder, pagination)
+func (usecase *userUsecase) GetAll(ctx context.Context, userFilter *entity.UserFilter, sortOrder *entity.SortOrder, pagination *entity.Pagination) ([]entity.User, error) {
+	if userFilter == nil {
+		userFilter = &entity.UserFilter{}
+	}
+	err := applyOwnerScope(ctx, &userFilter.User)
+	if err != nil {
+		return []entity.User{}, err
+	}
+
+	users, err := usecase.userRepository.GetAll(ctx, userFilter, sortOrder, pagination)
 	if err != nil {
-		return []entity.User{}, util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		return []entity.User{}, util.NewError(common.ErrorCode.Internal, err.Error())
 	}
 
 	if pagination != nil {
@@ -84,22 +154,225 @@ func (usecase *userUsecase) GetAll(userFilter *entity.UserFilter, sortOrder *ent
 	return users, nil
 }
 
-func (usecase *userUsecase) Update(user entity.User) error {
+// description: user must be soft deleted first and found within owner scope of the caller
+func (usecase *userUsecase) Purge(ctx context.Context, user entity.User, audit entity.Audit) error {
+	deletedUser, err := usecase.getDeleted(ctx, entity.User{ID: user.ID, AdminID: user.AdminID})
+	if err != nil {
+		return err
+	}
+
+	auditLog := entity.NewAuditLog(audit, entity.PurgeAuditAction, entity.UserAuditEntityType, deletedUser.ID).WithChange(deletedUser, nil)
+	err = usecase.userRepository.Purge(ctx, entity.User{ID: deletedUser.ID}, auditLog)
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return util.NewError(common.ErrorCode.NotFound, err.Error())
+		default:
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// description: user is found within owner scope of the caller at the expected version, new owner must be an existing admin
+func (usecase *userUsecase) Reassign(ctx context.Context, user entity.User, userOwner entity.UserOwner, audit entity.Audit) error {
+	currentUser, err := usecase.Get(ctx, entity.User{ID: user.ID, AdminID: user.AdminID})
+	if err != nil {
+		return err
+	}
+	err = checkVersion(user.Version, currentUser.Version)
+	if err != nil {
+		return err
+	}
+
+	_, err = usecase.adminRepository.Get(ctx, entity.Admin{ID: userOwner.AdminID})
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return newValidationError([]util.ErrorDetail{{Field: "admin_id", Rule: "exists", Message: "admin does not exist"}})
+		default:
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	user = entity.User{ID: currentUser.ID, AdminID: userOwner.AdminID, Version: user.Version}
+	auditLog := entity.NewAuditLog(audit, entity.ReassignAuditAction, entity.UserAuditEntityType, user.ID).WithChange(currentUser, user)
+	err = usecase.userRepository.Update(ctx, user, auditLog)
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return util.NewError(common.ErrorCode.VersionMismatch, "version does not match")
+		default:
+			return newRepositoryError(err)
+		}
+	}
+
+	return nil
+}
+
+// description: username and phone of deleted user may have been reused by an active user meanwhile
+func (usecase *userUsecase) Restore(ctx context.Context, user entity.User, audit entity.Audit) error {
+	deletedUser, err := usecase.getDeleted(ctx, entity.User{ID: user.ID, AdminID: user.AdminID})
+	if err != nil {
+		return err
+	}
+
+	err = usecase.checkRestoreConflict(ctx, entity.User{Username: deletedUser.Username}, fmt.Sprintf("username %s is already used by another user", *deletedUser.Username))
+	if err != nil {
+		return err
+	}
+	err = usecase.checkRestoreConflict(ctx, entity.User{Phone: deletedUser.Phone}, fmt.Sprintf("phone %s is already used by another user", *deletedUser.Phone))
+	if err != nil {
+		return err
+	}
+
+	auditLog := entity.NewAuditLog(audit, entity.RestoreAuditAction, entity.UserAuditEntityType, deletedUser.ID)
+	err = usecase.userRepository.Restore(ctx, entity.User{ID: deletedUser.ID}, auditLog)
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return util.NewError(common.ErrorCode.NotFound, err.Error())
+		default:
+			return newRepositoryError(err)
+		}
+	}
+
+	return nil
+}
+
+func (usecase *userUsecase) Unlock(ctx context.Context, user entity.User, audit entity.Audit) error {
+	currentUser, err := usecase.Get(ctx, entity.User{ID: user.ID, AdminID: user.AdminID})
+	if err != nil {
+		return err
+	}
+
+	user.AdminID = nil
+	user.FailedLogin = new(int)
+	user.LockUntil = nil
+	auditLog := entity.NewAuditLog(audit, entity.UnlockAuditAction, entity.UserAuditEntityType, user.ID).WithChange(currentUser, user)
+	err = usecase.userRepository.UpdateLock(ctx, user, auditLog)
+	if err != nil {
+		return util.NewError(common.ErrorCode.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (usecase *userUsecase) Update(ctx context.Context, user entity.User, audit entity.Audit) error {
+	currentUser, err := usecase.Get(ctx, entity.User{ID: user.ID, AdminID: user.AdminID})
+	if err != nil {
+		return err
+	}
+	err = checkVersion(user.Version, currentUser.Version)
+	if err != nil {
+		return err
+	}
+	user.AdminID = nil
+
+	account := passwordAccount{}
 	if user.Password != nil {
+		account = passwordAccount{userID: currentUser.ID, username: currentUser.Username, passwordHash: currentUser.PasswordHash}
+		if user.Username != nil {
+			account.username = user.Username
+		}
+
+		details, err := checkPassword(ctx, usecase.passwordHistoryRepository, account, *user.Password)
+		if err != nil {
+			return util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+		err = newValidationError(append(checkUsername(user.Username), details...))
+		if err != nil {
+			return err
+		}
+
 		passwordHash, err := bcrypt.GenerateFromPassword([]byte(*user.Password), bcrypt.DefaultCost)
 		if err != nil {
-			return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+			return util.NewError(common.ErrorCode.Internal, err.Error())
 		}
 		isResetPassword := true
 
 		user.PasswordHash = &passwordHash
 		user.IsResetPassword = &isResetPassword
+	} else {
+		err := newValidationError(checkUsername(user.Username))
+		if err != nil {
+			return err
+		}
+	}
+
+	auditLog := entity.NewAuditLog(audit, entity.UpdateAuditAction, entity.UserAuditEntityType, user.ID).WithChange(currentUser, user)
+	return usecase.transactionRepository.Do(ctx, func(ctx context.Context) error {
+		err := usecase.userRepository.Update(ctx, user, auditLog)
+		if err != nil {
+			switch err {
+			case gorm.ErrRecordNotFound:
+				return util.NewError(common.ErrorCode.VersionMismatch, "version does not match")
+			default:
+				return newRepositoryError(err)
+			}
+		}
+		if user.Password == nil {
+			return nil
+		}
+
+		err = savePasswordHistory(ctx, usecase.passwordHistoryRepository, account)
+		if err != nil {
+			return err
+		}
+
+		// description: password set by admin signs out every session held with the previous one
+		return revokeAllTokens(ctx, usecase.refreshTokenRepository, usecase.revokedTokenRepository, nil, user.ID)
+	})
+}
+
+// description: conflict is an active user holding a unique value of the deleted one
+func (usecase *userUsecase) checkRestoreConflict(ctx context.Context, conflict entity.User, message string) error {
+	_, err := usecase.userRepository.Get(ctx, conflict)
+	switch err {
+	case nil:
+		return util.NewError(common.ErrorCode.RestoreConflict, message)
+	case gorm.ErrRecordNotFound:
+		return nil
+	default:
+		return util.NewError(common.ErrorCode.Internal, err.Error())
 	}
+}
 
-	err := usecase.userRepository.Update(user)
+func (usecase *userUsecase) getDeleted(ctx context.Context, user entity.User) (entity.User, error) {
+	err := applyOwnerScope(ctx, &user)
 	if err != nil {
-		return util.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		return entity.User{}, err
 	}
 
-	return nil
+	user, err = usecase.userRepository.GetDeleted(ctx, user)
+	if err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return entity.User{}, util.NewError(common.ErrorCode.NotFound, err.Error())
+		default:
+			return entity.User{}, util.NewError(common.ErrorCode.Internal, err.Error())
+		}
+	}
+
+	return user, nil
+}
+
+// description: narrow user lookup to owner scope of request, missing or empty scope is refused so a route without owner scope middleware reaches no user
+func applyOwnerScope(ctx context.Context, user *entity.User) error {
+	ownerScope, ok := util.GetOwnerScope(ctx)
+	switch {
+	case !ok:
+		return errorOwnerScopeMissing
+	case ownerScope.IsAll:
+		return nil
+	case ownerScope.AdminID != nil:
+		user.AdminID = ownerScope.AdminID
+		return nil
+	case ownerScope.UserID != nil:
+		user.ID = ownerScope.UserID
+		return nil
+	default:
+		return errorOwnerScopeMissing
+	}
 }
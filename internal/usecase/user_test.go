@@ -1,39 +1,79 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
 	"github.com/golang/mock/gomock"
+	"github.com/sndzhng/gin-template/internal/config"
 	"github.com/sndzhng/gin-template/internal/entity"
 	"github.com/sndzhng/gin-template/internal/usecase"
 	"github.com/sndzhng/gin-template/internal/util"
 	repositorymock "github.com/sndzhng/gin-template/mock/repository"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 func beforeTestUser(test *testing.T) (
+	*repositorymock.MockAdmin,
+	*repositorymock.MockPasswordHistory,
+	*repositorymock.MockRefreshToken,
+	*repositorymock.MockRevokedToken,
 	*repositorymock.MockUser,
 	usecase.User,
 ) {
 	controller := gomock.NewController(test)
 	defer controller.Finish()
 
+	config.JWT.ExpireMinute = "15m"
+
+	mockAdminRepository := repositorymock.NewMockAdmin(controller)
+	mockPasswordHistoryRepository := repositorymock.NewMockPasswordHistory(controller)
+	mockRefreshTokenRepository := repositorymock.NewMockRefreshToken(controller)
+	mockRevokedTokenRepository := repositorymock.NewMockRevokedToken(controller)
 	mockUserRepository := repositorymock.NewMockUser(controller)
-	userUsecase := usecase.NewUserUsecase(mockUserRepository)
+	userUsecase := usecase.NewUserUsecase(mockAdminRepository, mockPasswordHistoryRepository, mockRefreshTokenRepository, mockRevokedTokenRepository, newMockTransaction(controller), mockUserRepository)
+
+	return mockAdminRepository, mockPasswordHistoryRepository, mockRefreshTokenRepository, mockRevokedTokenRepository, mockUserRepository, userUsecase
+}
+
+// description: request context as owner scope middleware leaves it for an account of role
+func ownerScopeContext(test *testing.T, roleName entity.RoleName, subject uint64) context.Context {
+	ginContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ginContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	ginContext.Set("claims", &entity.Claims{
+		StandardClaims: jwt.StandardClaims{Subject: fmt.Sprint(subject)},
+		Roles:          []entity.RoleName{roleName},
+	})
+
+	err := util.SetOwnerScope(ginContext)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	return ginContext.Request.Context()
+}
 
-	return mockUserRepository, userUsecase
+// description: request context of super admin in test audit, owner scope reaches every user
+func superAdminContext(test *testing.T) context.Context {
+	return ownerScopeContext(test, entity.SuperAdminRoleName, 99)
 }
 
 func TestUserCreate(test *testing.T) {
-	mockUserRepository, userUsecase := beforeTestUser(test)
+	_, _, _, _, mockUserRepository, userUsecase := beforeTestUser(test)
 
+	audit := testAudit()
 	id := uint64(1)
 	username := "username"
-	password := "password"
+	password := "Correct.Horse42"
 	name := "name"
 	user := entity.User{
 		AdminID:  &id,
@@ -43,52 +83,136 @@ func TestUserCreate(test *testing.T) {
 	}
 
 	test.Run("Success", func(test *testing.T) {
-		mockUserRepository.EXPECT().Create(gomock.Any()).Return(nil)
+		mockUserRepository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
-		err := userUsecase.Create(user)
+		err := userUsecase.Create(superAdminContext(test), user, audit)
 		assert.NoError(test, err)
 	})
 
 	test.Run("InternalError", func(test *testing.T) {
-		mockUserRepository.EXPECT().Create(gomock.Any()).Return(errors.New("internal error"))
+		mockUserRepository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := userUsecase.Create(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("Conflict", func(test *testing.T) {
+		constraintError := entity.ConstraintError{Type: entity.UniqueConstraintType, Constraint: "idx_users_phone_active", Field: "phone"}
+		mockUserRepository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(constraintError)
 
-		err := userUsecase.Create(user)
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		err := userUsecase.Create(superAdminContext(test), user, audit)
+		assert.Equal(test, constraintError, err)
+	})
+
+	test.Run("BadRequest/PasswordPolicy", func(test *testing.T) {
+		config.Password = config.PasswordConfig{CharacterClass: "lower,upper,digit,symbol", MinLength: "12"}
+		usecase.InitialPasswordPolicy()
+		defer func() {
+			config.Password = config.PasswordConfig{}
+			usecase.InitialPasswordPolicy()
+		}()
+
+		invalidUsername := "pass"
+		password := "password"
+		user := user
+		user.Username = &invalidUsername
+		user.Password = &password
+
+		err := userUsecase.Create(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+
+		rules := []string{}
+		for _, detail := range err.(util.Error).Details {
+			rules = append(rules, fmt.Sprintf("%s.%s", detail.Field, detail.Rule))
+		}
+		assert.ElementsMatch(
+			test,
+			[]string{"username.pattern", "password.min_length", "password.upper", "password.digit", "password.symbol", "password.username", "password.common"},
+			rules,
+		)
 	})
 
 	test.Run("PasswordIsNilError", func(test *testing.T) {
 		user.Password = nil
 
-		err := userUsecase.Create(entity.User{})
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		err := userUsecase.Create(superAdminContext(test), entity.User{}, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
 	})
 }
 
 func TestUserDelete(test *testing.T) {
-	mockUserRepository, userUsecase := beforeTestUser(test)
+	_, _, mockRefreshTokenRepository, mockRevokedTokenRepository, mockUserRepository, userUsecase := beforeTestUser(test)
 
+	audit := testAudit()
 	id := uint64(1)
 	user := entity.User{
 		ID: &id,
 	}
 
 	test.Run("Success", func(test *testing.T) {
-		mockUserRepository.EXPECT().Delete(user).Return(nil)
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id}, nil)
+		mockUserRepository.EXPECT().Delete(gomock.Any(), user, gomock.Any()).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, revokedToken entity.RevokedToken) error {
+				assert.Nil(test, revokedToken.JTI)
+				assert.Equal(test, id, *revokedToken.UserID)
+				return nil
+			},
+		)
+		mockRefreshTokenRepository.EXPECT().RevokeAll(gomock.Any(), entity.RefreshToken{UserID: &id}).Return(nil)
 
-		err := userUsecase.Delete(user)
+		err := userUsecase.Delete(superAdminContext(test), user, audit)
 		assert.NoError(test, err)
 	})
 
+	test.Run("PreconditionFailed", func(test *testing.T) {
+		version, currentVersion := uint64(1), uint64(2)
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id, Version: &currentVersion}, nil)
+
+		err := userUsecase.Delete(superAdminContext(test), entity.User{ID: &id, Version: &version}, audit)
+		assert.Equal(test, http.StatusPreconditionFailed, err.(util.Error).Status)
+	})
+
+	test.Run("Success/OwnerScope", func(test *testing.T) {
+		ownerID := uint64(2)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id, AdminID: &ownerID}).Return(entity.User{ID: &id, AdminID: &ownerID}, nil)
+		mockUserRepository.EXPECT().Delete(gomock.Any(), user, gomock.Any()).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockRefreshTokenRepository.EXPECT().RevokeAll(gomock.Any(), entity.RefreshToken{UserID: &id}).Return(nil)
+
+		err := userUsecase.Delete(superAdminContext(test), entity.User{ID: &id, AdminID: &ownerID}, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("RecordNotFound/OwnerScope", func(test *testing.T) {
+		ownerID := uint64(2)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id, AdminID: &ownerID}).Return(entity.User{}, gorm.ErrRecordNotFound)
+
+		err := userUsecase.Delete(superAdminContext(test), entity.User{ID: &id, AdminID: &ownerID}, audit)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+
+	test.Run("InternalError/RevokedToken", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id}, nil)
+		mockUserRepository.EXPECT().Delete(gomock.Any(), user, gomock.Any()).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := userUsecase.Delete(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
 	test.Run("InternalError", func(test *testing.T) {
-		mockUserRepository.EXPECT().Delete(user).Return(errors.New("internal error"))
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id}, nil)
+		mockUserRepository.EXPECT().Delete(gomock.Any(), user, gomock.Any()).Return(errors.New("internal error"))
 
-		err := userUsecase.Delete(user)
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		err := userUsecase.Delete(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
 	})
 }
 
 func TestUserGet(test *testing.T) {
-	mockUserRepository, userUsecase := beforeTestUser(test)
+	_, _, _, _, mockUserRepository, userUsecase := beforeTestUser(test)
 
 	id := uint64(1)
 	username := "username"
@@ -97,7 +221,7 @@ func TestUserGet(test *testing.T) {
 	}
 
 	test.Run("Success", func(test *testing.T) {
-		mockUserRepository.EXPECT().Get(user).Return(
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(
 			entity.User{
 				ID:       &id,
 				Username: &username,
@@ -105,30 +229,51 @@ func TestUserGet(test *testing.T) {
 			nil,
 		)
 
-		result, err := userUsecase.Get(user)
+		result, err := userUsecase.Get(superAdminContext(test), user)
 		assert.NoError(test, err)
 		assert.Equal(test, *result.ID, *user.ID)
 	})
 
 	test.Run("InternalError", func(test *testing.T) {
-		mockUserRepository.EXPECT().Get(user).Return(entity.User{}, errors.New("internal error"))
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{}, errors.New("internal error"))
 
-		result, err := userUsecase.Get(user)
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		result, err := userUsecase.Get(superAdminContext(test), user)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
 		assert.Equal(test, result, entity.User{})
 	})
 
 	test.Run("RecordNotFound", func(test *testing.T) {
-		mockUserRepository.EXPECT().Get(user).Return(entity.User{}, gorm.ErrRecordNotFound)
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{}, gorm.ErrRecordNotFound)
 
-		result, err := userUsecase.Get(user)
-		assert.Equal(test, http.StatusNotFound, err.(util.Error).Code)
+		result, err := userUsecase.Get(superAdminContext(test), user)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
 		assert.Equal(test, result, entity.User{})
 	})
+
+	test.Run("RecordNotFound/OwnerScope", func(test *testing.T) {
+		ownerID := uint64(2)
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id, AdminID: &ownerID}).Return(entity.User{}, gorm.ErrRecordNotFound)
+
+		_, err := userUsecase.Get(ownerScopeContext(test, entity.AdminRoleName, ownerID), user)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+
+	test.Run("Success/OwnerScopeUser", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id}, nil)
+
+		_, err := userUsecase.Get(ownerScopeContext(test, entity.UserRoleName, id), entity.User{})
+		assert.NoError(test, err)
+	})
+
+	test.Run("Forbidden/OwnerScope", func(test *testing.T) {
+		_, err := userUsecase.Get(context.Background(), user)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
+	})
 }
 
 func TestUserGetAll(test *testing.T) {
-	mockUserRepository, userUsecase := beforeTestUser(test)
+	_, _, _, _, mockUserRepository, userUsecase := beforeTestUser(test)
 
 	id := uint64(1)
 	username := "username"
@@ -151,27 +296,243 @@ func TestUserGetAll(test *testing.T) {
 			Offset: 0,
 		}
 
-		mockUserRepository.EXPECT().GetAll(&userFilter, &sortOrder, &pagination).Return(users, nil)
+		mockUserRepository.EXPECT().GetAll(gomock.Any(), &userFilter, &sortOrder, &pagination).Return(users, nil)
 
-		result, err := userUsecase.GetAll(&userFilter, &sortOrder, &pagination)
+		result, err := userUsecase.GetAll(superAdminContext(test), &userFilter, &sortOrder, &pagination)
 		assert.NoError(test, err)
 		assert.Len(test, result, len(users))
 	})
 
+	test.Run("Success/OwnerScope", func(test *testing.T) {
+		ownerID := uint64(2)
+		userFilter := entity.UserFilter{User: entity.User{AdminID: &id}}
+
+		mockUserRepository.EXPECT().GetAll(gomock.Any(), &entity.UserFilter{User: entity.User{AdminID: &ownerID}}, nil, nil).Return(users, nil)
+
+		_, err := userUsecase.GetAll(ownerScopeContext(test, entity.AdminRoleName, ownerID), &userFilter, nil, nil)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Success/OwnerScopeWithoutFilter", func(test *testing.T) {
+		ownerID := uint64(2)
+
+		mockUserRepository.EXPECT().GetAll(gomock.Any(), &entity.UserFilter{User: entity.User{AdminID: &ownerID}}, nil, nil).Return(users, nil)
+
+		_, err := userUsecase.GetAll(ownerScopeContext(test, entity.AdminRoleName, ownerID), nil, nil, nil)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Forbidden/OwnerScope", func(test *testing.T) {
+		result, err := userUsecase.GetAll(context.Background(), nil, nil, nil)
+		assert.Equal(test, http.StatusForbidden, err.(util.Error).Status)
+		assert.Len(test, result, 0)
+	})
+
 	test.Run("InternalError", func(test *testing.T) {
-		mockUserRepository.EXPECT().GetAll(nil, nil, nil).Return([]entity.User{}, errors.New("internal error"))
+		mockUserRepository.EXPECT().GetAll(gomock.Any(), &entity.UserFilter{}, nil, nil).Return([]entity.User{}, errors.New("internal error"))
 
-		result, err := userUsecase.GetAll(nil, nil, nil)
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		result, err := userUsecase.GetAll(superAdminContext(test), nil, nil, nil)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
 		assert.Len(test, result, 0)
 	})
 }
 
+func TestUserPurge(test *testing.T) {
+	_, _, _, _, mockUserRepository, userUsecase := beforeTestUser(test)
+
+	audit := testAudit()
+	id := uint64(1)
+	adminID := uint64(2)
+	username := "username"
+	user := entity.User{ID: &id, AdminID: &adminID}
+
+	test.Run("Success", func(test *testing.T) {
+		mockUserRepository.EXPECT().GetDeleted(gomock.Any(), user).Return(entity.User{ID: &id, AdminID: &adminID, Username: &username}, nil)
+		mockUserRepository.EXPECT().Purge(gomock.Any(), entity.User{ID: &id}, gomock.Any()).DoAndReturn(
+			func(_ context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, entity.PurgeAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, username, auditLogs[0].Before["username"])
+				return nil
+			},
+		)
+
+		err := userUsecase.Purge(superAdminContext(test), user, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockUserRepository.EXPECT().GetDeleted(gomock.Any(), user).Return(entity.User{}, gorm.ErrRecordNotFound)
+
+		err := userUsecase.Purge(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserRepository.EXPECT().GetDeleted(gomock.Any(), user).Return(entity.User{ID: &id, AdminID: &adminID, Username: &username}, nil)
+		mockUserRepository.EXPECT().Purge(gomock.Any(), entity.User{ID: &id}, gomock.Any()).Return(errors.New("internal error"))
+
+		err := userUsecase.Purge(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+}
+
+func TestUserReassign(test *testing.T) {
+	mockAdminRepository, _, _, _, mockUserRepository, userUsecase := beforeTestUser(test)
+
+	audit := testAudit()
+	id := uint64(1)
+	ownerID := uint64(2)
+	newOwnerID := uint64(3)
+	user := entity.User{ID: &id, AdminID: &ownerID}
+	userOwner := entity.UserOwner{AdminID: &newOwnerID}
+
+	test.Run("Success", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id, AdminID: &ownerID}, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &newOwnerID}).Return(entity.Admin{ID: &newOwnerID}, nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), entity.User{ID: &id, AdminID: &newOwnerID}, gomock.Any()).Return(nil)
+
+		err := userUsecase.Reassign(superAdminContext(test), user, userOwner, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("PreconditionFailed", func(test *testing.T) {
+		version := uint64(1)
+		currentVersion := uint64(2)
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id, AdminID: &ownerID, Version: &currentVersion}, nil)
+
+		err := userUsecase.Reassign(superAdminContext(test), entity.User{ID: &id, AdminID: &ownerID, Version: &version}, userOwner, audit)
+		assert.Equal(test, http.StatusPreconditionFailed, err.(util.Error).Status)
+	})
+
+	test.Run("PreconditionFailed/Concurrent", func(test *testing.T) {
+		version := uint64(1)
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id, AdminID: &ownerID, Version: &version}, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &newOwnerID}).Return(entity.Admin{ID: &newOwnerID}, nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), entity.User{ID: &id, AdminID: &newOwnerID, Version: &version}, gomock.Any()).Return(gorm.ErrRecordNotFound)
+
+		err := userUsecase.Reassign(superAdminContext(test), entity.User{ID: &id, AdminID: &ownerID, Version: &version}, userOwner, audit)
+		assert.Equal(test, http.StatusPreconditionFailed, err.(util.Error).Status)
+	})
+
+	test.Run("BadRequest/AdminNotFound", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id, AdminID: &ownerID}, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &newOwnerID}).Return(entity.Admin{}, gorm.ErrRecordNotFound)
+
+		err := userUsecase.Reassign(superAdminContext(test), user, userOwner, audit)
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+		assert.Equal(test, "admin_id", err.(util.Error).Details[0].Field)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id, AdminID: &ownerID}, nil)
+		mockAdminRepository.EXPECT().Get(gomock.Any(), entity.Admin{ID: &newOwnerID}).Return(entity.Admin{ID: &newOwnerID}, nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := userUsecase.Reassign(superAdminContext(test), user, userOwner, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("RecordNotFound", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{}, gorm.ErrRecordNotFound)
+
+		err := userUsecase.Reassign(superAdminContext(test), user, userOwner, audit)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+}
+
+func TestUserRestore(test *testing.T) {
+	_, _, _, _, mockUserRepository, userUsecase := beforeTestUser(test)
+
+	audit := testAudit()
+	id := uint64(1)
+	otherID := uint64(3)
+	adminID := uint64(2)
+	username := "username"
+	phone := "0812345678"
+	user := entity.User{ID: &id, AdminID: &adminID}
+	deletedUser := entity.User{ID: &id, AdminID: &adminID, Username: &username, Phone: &phone}
+
+	test.Run("Success", func(test *testing.T) {
+		mockUserRepository.EXPECT().GetDeleted(gomock.Any(), user).Return(deletedUser, nil)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(entity.User{}, gorm.ErrRecordNotFound)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Phone: &phone}).Return(entity.User{}, gorm.ErrRecordNotFound)
+		mockUserRepository.EXPECT().Restore(gomock.Any(), entity.User{ID: &id}, gomock.Any()).DoAndReturn(
+			func(_ context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, entity.RestoreAuditAction, *auditLogs[0].Action)
+				assert.Equal(test, entity.UserAuditEntityType, *auditLogs[0].EntityType)
+				return nil
+			},
+		)
+
+		err := userUsecase.Restore(superAdminContext(test), user, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Conflict/Username", func(test *testing.T) {
+		mockUserRepository.EXPECT().GetDeleted(gomock.Any(), user).Return(deletedUser, nil)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(entity.User{ID: &otherID}, nil)
+
+		err := userUsecase.Restore(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusConflict, err.(util.Error).Status)
+	})
+
+	test.Run("Conflict/Phone", func(test *testing.T) {
+		mockUserRepository.EXPECT().GetDeleted(gomock.Any(), user).Return(deletedUser, nil)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(entity.User{}, gorm.ErrRecordNotFound)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Phone: &phone}).Return(entity.User{ID: &otherID}, nil)
+
+		err := userUsecase.Restore(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusConflict, err.(util.Error).Status)
+	})
+
+	test.Run("NotFound", func(test *testing.T) {
+		mockUserRepository.EXPECT().GetDeleted(gomock.Any(), user).Return(entity.User{}, gorm.ErrRecordNotFound)
+
+		err := userUsecase.Restore(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserRepository.EXPECT().GetDeleted(gomock.Any(), user).Return(deletedUser, nil)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{Username: &username}).Return(entity.User{}, errors.New("internal error"))
+
+		err := userUsecase.Restore(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+}
+
+func TestUserUnlock(test *testing.T) {
+	_, _, _, _, mockUserRepository, userUsecase := beforeTestUser(test)
+
+	audit := testAudit()
+	id := uint64(1)
+	user := entity.User{ID: &id}
+
+	test.Run("Success", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id}, nil)
+		mockUserRepository.EXPECT().UpdateLock(gomock.Any(), entity.User{ID: &id, FailedLogin: new(int)}, gomock.Any()).Return(nil)
+
+		err := userUsecase.Unlock(superAdminContext(test), user, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("InternalError", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), user).Return(entity.User{ID: &id}, nil)
+		mockUserRepository.EXPECT().UpdateLock(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := userUsecase.Unlock(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+}
+
 func TestUserUpdate(test *testing.T) {
-	mockUserRepository, userUsecase := beforeTestUser(test)
+	_, mockPasswordHistoryRepository, mockRefreshTokenRepository, mockRevokedTokenRepository, mockUserRepository, userUsecase := beforeTestUser(test)
 
+	audit := testAudit()
 	id := uint64(1)
-	password := "password"
+	password := "Correct.Horse42"
 	username := "username"
 	name := "name"
 	phone := "+66987654321"
@@ -185,16 +546,125 @@ func TestUserUpdate(test *testing.T) {
 	}
 
 	test.Run("Success", func(test *testing.T) {
-		mockUserRepository.EXPECT().Update(gomock.Any()).Return(nil)
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username}, nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, revokedToken entity.RevokedToken) error {
+				assert.Equal(test, id, *revokedToken.UserID)
+				return nil
+			},
+		)
+		mockRefreshTokenRepository.EXPECT().RevokeAll(gomock.Any(), entity.RefreshToken{UserID: &id}).Return(nil)
+
+		err := userUsecase.Update(superAdminContext(test), user, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("Success/WithoutPassword", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username}, nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), entity.User{ID: &id, Name: &name}, gomock.Any()).Return(nil)
+
+		err := userUsecase.Update(superAdminContext(test), entity.User{ID: &id, Name: &name}, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("InternalError/RevokedToken", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username}, nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := userUsecase.Update(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("PreconditionFailed", func(test *testing.T) {
+		version, currentVersion := uint64(1), uint64(2)
+		versionedUser := user
+		versionedUser.Version = &version
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username, Version: &currentVersion}, nil)
+
+		err := userUsecase.Update(superAdminContext(test), versionedUser, audit)
+		assert.Equal(test, http.StatusPreconditionFailed, err.(util.Error).Status)
+	})
 
-		err := userUsecase.Update(user)
+	test.Run("PreconditionFailed/Changed", func(test *testing.T) {
+		version := uint64(2)
+		versionedUser := user
+		versionedUser.Version = &version
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username, Version: &version}, nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, user entity.User, auditLogs ...entity.AuditLog) error {
+				assert.Equal(test, version, *user.Version)
+				assert.NotContains(test, auditLogs[0].After, "version")
+				return gorm.ErrRecordNotFound
+			},
+		)
+
+		err := userUsecase.Update(superAdminContext(test), versionedUser, audit)
+		assert.Equal(test, http.StatusPreconditionFailed, err.(util.Error).Status)
+	})
+
+	test.Run("Success/PasswordHistory", func(test *testing.T) {
+		config.Password = config.PasswordConfig{HistoryAmount: "3"}
+		usecase.InitialPasswordPolicy()
+		defer func() {
+			config.Password = config.PasswordConfig{}
+			usecase.InitialPasswordPolicy()
+		}()
+
+		oldPassword := "oldPassword"
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(oldPassword), bcrypt.MinCost)
+		assert.NoError(test, err)
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username, PasswordHash: &passwordHash}, nil)
+		mockPasswordHistoryRepository.EXPECT().GetAll(gomock.Any(), entity.PasswordHistory{UserID: &id}, 2).Return([]entity.PasswordHistory{}, nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockPasswordHistoryRepository.EXPECT().Create(gomock.Any(), entity.PasswordHistory{UserID: &id, PasswordHash: &passwordHash}).Return(nil)
+		mockRevokedTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockRefreshTokenRepository.EXPECT().RevokeAll(gomock.Any(), entity.RefreshToken{UserID: &id}).Return(nil)
+
+		err = userUsecase.Update(superAdminContext(test), user, audit)
+		assert.NoError(test, err)
+	})
+
+	test.Run("BadRequest/PasswordReused", func(test *testing.T) {
+		config.Password = config.PasswordConfig{HistoryAmount: "3"}
+		usecase.InitialPasswordPolicy()
+		defer func() {
+			config.Password = config.PasswordConfig{}
+			usecase.InitialPasswordPolicy()
+		}()
+
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+		assert.NoError(test, err)
+		otherPasswordHash, err := bcrypt.GenerateFromPassword([]byte("otherPassword"), bcrypt.MinCost)
 		assert.NoError(test, err)
+
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username, PasswordHash: &otherPasswordHash}, nil)
+		mockPasswordHistoryRepository.EXPECT().GetAll(gomock.Any(), entity.PasswordHistory{UserID: &id}, 2).Return(
+			[]entity.PasswordHistory{{UserID: &id, PasswordHash: &passwordHash}},
+			nil,
+		)
+
+		err = userUsecase.Update(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusBadRequest, err.(util.Error).Status)
+		assert.Equal(test, "reused", err.(util.Error).Details[0].Rule)
 	})
 
 	test.Run("InternalError", func(test *testing.T) {
-		mockUserRepository.EXPECT().Update(gomock.Any()).Return(errors.New("internal error"))
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{ID: &id, Username: &username}, nil)
+		mockUserRepository.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("internal error"))
+
+		err := userUsecase.Update(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Status)
+	})
+
+	test.Run("RecordNotFound", func(test *testing.T) {
+		mockUserRepository.EXPECT().Get(gomock.Any(), entity.User{ID: &id}).Return(entity.User{}, gorm.ErrRecordNotFound)
 
-		err := userUsecase.Update(user)
-		assert.Equal(test, http.StatusInternalServerError, err.(util.Error).Code)
+		err := userUsecase.Update(superAdminContext(test), user, audit)
+		assert.Equal(test, http.StatusNotFound, err.(util.Error).Status)
 	})
 }
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"github.com/sndzhng/gin-template/internal/common"
+	"github.com/sndzhng/gin-template/internal/util"
+)
+
+// description: expected version comes from If-Match, nil expected version skips the check
+func checkVersion(expected *uint64, current *uint64) error {
+	if expected == nil {
+		return nil
+	}
+	if current == nil || *expected != *current {
+		return util.NewError(common.ErrorCode.VersionMismatch, "version does not match")
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,15 +11,35 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sndzhng/gin-template/internal/entity"
-	"github.com/sndzhng/gin-template/internal/middleware"
+	"github.com/sndzhng/gin-template/internal/i18n"
 )
 
+type (
+	// description: users a request may reach, is all is the explicit value of super admin,
+	// admin id reaches users owned by the admin and user id reaches the user itself
+	OwnerScope struct {
+		IsAll   bool
+		AdminID *uint64
+		UserID  *uint64
+	}
+	// description: request context key of owner scope, unexported so only SetOwnerScope can set it
+	ownerScopeKey struct{}
+)
+
+func GetClaims(ginContext *gin.Context) (*entity.Claims, error) {
+	if ginContext.Keys["claims"] == nil {
+		return nil, errors.New("claims not found")
+	}
+
+	return ginContext.MustGet("claims").(*entity.Claims), nil
+}
+
 func GetClaimSubject(ginContext *gin.Context) (uint64, error) {
 	if ginContext.Keys["claims"] == nil {
 		return 0, errors.New("claims not found")
 	}
 
-	claims := ginContext.MustGet("claims").(*middleware.CustomClaims)
+	claims := ginContext.MustGet("claims").(*entity.Claims)
 	subject, err := strconv.ParseUint(claims.Subject, 10, 64)
 	if err != nil {
 		return 0, err
@@ -32,12 +53,97 @@ func GetClaimRoles(ginContext *gin.Context) ([]entity.RoleName, error) {
 		return []entity.RoleName{}, errors.New("claims not found")
 	}
 
-	claims := ginContext.MustGet("claims").(*middleware.CustomClaims)
+	claims := ginContext.MustGet("claims").(*entity.Claims)
 	roles := claims.Roles
 
 	return roles, nil
 }
 
+// description: admin id behind an impersonated token, nil when not impersonated
+func GetClaimActor(ginContext *gin.Context) (*uint64, error) {
+	if ginContext.Keys["claims"] == nil {
+		return nil, errors.New("claims not found")
+	}
+
+	claims := ginContext.MustGet("claims").(*entity.Claims)
+
+	return claims.ActorID()
+}
+
+// description: false when owner scope middleware did not run, caller must then refuse to reach any user
+func GetOwnerScope(ctx context.Context) (OwnerScope, bool) {
+	ownerScope, ok := ctx.Value(ownerScopeKey{}).(OwnerScope)
+
+	return ownerScope, ok
+}
+
+// description: resolve owner scope from claims once and carry it in request context, so usecase narrows every user lookup
+func SetOwnerScope(ginContext *gin.Context) error {
+	claims, err := GetClaims(ginContext)
+	if err != nil {
+		return err
+	}
+
+	ownerScope := OwnerScope{IsAll: isSuperAdmin(claims.Roles)}
+	if !ownerScope.IsAll {
+		subject, err := GetClaimSubject(ginContext)
+		if err != nil {
+			return err
+		}
+		if claims.IsUser() {
+			ownerScope.UserID = &subject
+		} else {
+			ownerScope.AdminID = &subject
+		}
+	}
+	ginContext.Request = ginContext.Request.WithContext(context.WithValue(ginContext.Request.Context(), ownerScopeKey{}, ownerScope))
+
+	return nil
+}
+
+func GetDevice(ginContext *gin.Context) entity.Device {
+	userAgent := ginContext.Request.UserAgent()
+	ipAddress := ginContext.ClientIP()
+
+	return entity.Device{UserAgent: &userAgent, IPAddress: &ipAddress}
+}
+
+// description: actor is the admin from claims when present, request id is set by request id middleware
+func GetAudit(ginContext *gin.Context) entity.Audit {
+	device := GetDevice(ginContext)
+	audit := entity.Audit{IPAddress: device.IPAddress, UserAgent: device.UserAgent}
+
+	if requestID := ginContext.GetString("request_id"); requestID != "" {
+		audit.RequestID = &requestID
+	}
+	if claims, err := GetClaims(ginContext); err == nil {
+		audit.ActorID, _, _ = claims.Account()
+		audit.ActorRoles = claims.Roles
+		audit.ActorPermissions = claims.Permissions
+	}
+
+	return audit
+}
+
+// description: locale of message bundle picked from accept language header
+func GetLocale(ginContext *gin.Context) string {
+	if ginContext.Request == nil {
+		return i18n.DefaultLocale
+	}
+
+	return i18n.MatchLocale(ginContext.GetHeader("Accept-Language"))
+}
+
+// description: message of key added by handler through i18n.AddMessages, key itself when no bundle has it
+func Translate(ginContext *gin.Context, key string, params map[string]string) string {
+	message, ok := i18n.Translate(GetLocale(ginContext), key, params)
+	if !ok {
+		return key
+	}
+
+	return message
+}
+
 func ModifyRequestBody(ginContext *gin.Context, modifyMap map[string]interface{}) error {
 	bodyBytes, err := io.ReadAll(ginContext.Request.Body)
 	if err != nil {
@@ -72,3 +178,13 @@ func ModifyRequestParams(ginContext *gin.Context, modifyMap map[string]interface
 
 	ginContext.Request.URL.RawQuery = queryParams.Encode()
 }
+
+func isSuperAdmin(roles []entity.RoleName) bool {
+	for _, role := range roles {
+		if role == entity.SuperAdminRoleName {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,44 +1,285 @@
 package util
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 	"github.com/sndzhng/gin-template/internal/common"
 	"github.com/sndzhng/gin-template/internal/config"
+	"github.com/sndzhng/gin-template/internal/entity"
+	"github.com/sndzhng/gin-template/internal/i18n"
+	"gorm.io/gorm/schema"
 )
 
-type Error struct {
-	Code    int
-	Message string
-}
+const (
+	problemContentType = "application/problem+json"
+	problemErrorFormat = "problem"
+)
+
+var (
+	// description: binding field is named like its json and form key, e.g. AdminID is admin_id
+	bindingNamingStrategy = schema.NamingStrategy{}
+
+	// description: status of every code in catalogue, code missing here answers 500
+	errorCodeStatuses = map[string]int{
+		common.ErrorCode.AccountLocked:             http.StatusUnauthorized,
+		common.ErrorCode.AdminOwnsUser:             http.StatusConflict,
+		common.ErrorCode.BadRequest:                http.StatusBadRequest,
+		common.ErrorCode.BootstrapDone:             http.StatusConflict,
+		common.ErrorCode.DuplicateValue:            http.StatusConflict,
+		common.ErrorCode.Forbidden:                 http.StatusForbidden,
+		common.ErrorCode.ImpersonationForbidden:    http.StatusForbidden,
+		common.ErrorCode.Internal:                  http.StatusInternalServerError,
+		common.ErrorCode.InvalidDeletedFilter:      http.StatusBadRequest,
+		common.ErrorCode.InvalidMFACode:            http.StatusUnauthorized,
+		common.ErrorCode.InvalidMFAToken:           http.StatusUnauthorized,
+		common.ErrorCode.InvalidOIDCState:          http.StatusUnauthorized,
+		common.ErrorCode.InvalidPasswordResetToken: http.StatusUnauthorized,
+		common.ErrorCode.InvalidRecoveryCode:       http.StatusUnauthorized,
+		common.ErrorCode.InvalidRefreshToken:       http.StatusUnauthorized,
+		common.ErrorCode.InvalidSortOrder:          http.StatusBadRequest,
+		common.ErrorCode.InvalidValue:              http.StatusUnprocessableEntity,
+		common.ErrorCode.LoginFailed:               http.StatusUnauthorized,
+		common.ErrorCode.MFAAlreadyEnabled:         http.StatusConflict,
+		common.ErrorCode.MFACodeRequired:           http.StatusBadRequest,
+		common.ErrorCode.MFANotEnabled:             http.StatusBadRequest,
+		common.ErrorCode.MFANotEnrolled:            http.StatusBadRequest,
+		common.ErrorCode.MFARequired:               http.StatusForbidden,
+		common.ErrorCode.MFATokenRequired:          http.StatusBadRequest,
+		common.ErrorCode.NotFound:                  http.StatusNotFound,
+		common.ErrorCode.OIDCCodeRequired:          http.StatusBadRequest,
+		common.ErrorCode.OIDCDisabled:              http.StatusNotFound,
+		common.ErrorCode.OIDCLoginFailed:           http.StatusUnauthorized,
+		common.ErrorCode.OIDCRoleNotMapped:         http.StatusForbidden,
+		common.ErrorCode.PasswordResetRequired:     http.StatusForbidden,
+		common.ErrorCode.PreconditionRequired:      http.StatusPreconditionRequired,
+		common.ErrorCode.RateLimited:               http.StatusTooManyRequests,
+		common.ErrorCode.ReferenceNotFound:         http.StatusUnprocessableEntity,
+		common.ErrorCode.RefreshTokenExpired:       http.StatusUnauthorized,
+		common.ErrorCode.RefreshTokenReused:        http.StatusUnauthorized,
+		common.ErrorCode.RequestTimeout:            http.StatusServiceUnavailable,
+		common.ErrorCode.RestoreConflict:           http.StatusConflict,
+		common.ErrorCode.RoleInUse:                 http.StatusConflict,
+		common.ErrorCode.SuperAdminRoleProtected:   http.StatusForbidden,
+		common.ErrorCode.Unauthorized:              http.StatusUnauthorized,
+		common.ErrorCode.UsernameTaken:             http.StatusConflict,
+		common.ErrorCode.Validation:                http.StatusBadRequest,
+		common.ErrorCode.VersionMismatch:           http.StatusPreconditionFailed,
+	}
+)
+
+type (
+	Error struct {
+		Status  int
+		Code    string
+		Message string
+		Details []ErrorDetail
+	}
+	// description: param is the rule argument e.g. minimum length, used to translate message
+	ErrorDetail struct {
+		Field   string `json:"field"`
+		Rule    string `json:"rule"`
+		Param   string `json:"-"`
+		Message string `json:"message"`
+	}
+	errorResponse struct {
+		Code      string        `json:"code"`
+		Message   string        `json:"message"`
+		Details   []ErrorDetail `json:"details,omitempty"`
+		RequestID string        `json:"request_id,omitempty"`
+	}
+	// description: rfc 7807 problem details, code, details and request id are extension members
+	problemResponse struct {
+		Type      string        `json:"type"`
+		Title     string        `json:"title"`
+		Status    int           `json:"status"`
+		Detail    string        `json:"detail,omitempty"`
+		Instance  string        `json:"instance,omitempty"`
+		Code      string        `json:"code"`
+		Details   []ErrorDetail `json:"details,omitempty"`
+		RequestID string        `json:"request_id,omitempty"`
+	}
+)
 
 func (e Error) Error() string {
 	return e.Message
 }
 
 func HandleError(ginContext *gin.Context, err error) {
-	switch e := err.(type) {
-	case Error:
-		if e.Message != "" {
-			log.Println(e.Message)
+	// description: failure caused by deadline of timeout middleware is reported as timeout whatever status usecase chose
+	if ginContext.Request != nil && errors.Is(ginContext.Request.Context().Err(), context.DeadlineExceeded) {
+		err = NewError(common.ErrorCode.RequestTimeout, "request timeout")
+	}
+
+	constraintError := entity.ConstraintError{}
+	if errors.As(err, &constraintError) {
+		err = newConstraintError(constraintError)
+	}
+
+	// description: usecase error may arrive wrapped e.g. by transaction or fmt.Errorf with %w
+	e := Error{}
+	if !errors.As(err, &e) {
+		e = NewError(common.ErrorCode.Internal, err.Error())
+	}
+	if e.Code == "" {
+		e.Code = common.ErrorCode.Internal
+	}
+	if e.Status == 0 {
+		e.Status = errorCodeStatus(e.Code)
+	}
+	if e.Message != "" {
+		log.Println(e.Message)
+	}
+
+	locale := GetLocale(ginContext)
+	details := []ErrorDetail{}
+	for _, detail := range e.Details {
+		details = append(details, translateErrorDetail(locale, detail))
+	}
+	params := map[string]string{}
+	if len(e.Details) > 0 {
+		params["field"] = translateField(locale, e.Details[0].Field)
+	}
+
+	// description: internal message may reveal query or infrastructure, so only non production answers it untranslated
+	message, ok := i18n.Translate(locale, e.Code, params)
+	if e.Status >= http.StatusInternalServerError && e.Message != "" && config.Environment != common.Environment.Production {
+		message = e.Message
+	} else if !ok {
+		message = e.Message
+	}
+	if message == "" {
+		message = http.StatusText(e.Status)
+	}
+	ginContext.Header("Content-Language", locale)
+
+	if isProblemRequested(ginContext) {
+		ginContext.Header("Content-Type", problemContentType)
+		ginContext.AbortWithStatusJSON(e.Status, problemResponse{
+			Type:      "about:blank",
+			Title:     http.StatusText(e.Status),
+			Status:    e.Status,
+			Detail:    message,
+			Instance:  requestPath(ginContext),
+			Code:      e.Code,
+			Details:   details,
+			RequestID: ginContext.GetString("request_id"),
+		})
+		return
+	}
+
+	ginContext.AbortWithStatusJSON(e.Status, errorResponse{
+		Code:      e.Code,
+		Message:   message,
+		Details:   details,
+		RequestID: ginContext.GetString("request_id"),
+	})
+}
+
+// description: status comes from catalogue so the same code always answers the same status
+func NewError(code string, message string) Error {
+	return Error{Status: errorCodeStatus(code), Code: code, Message: message}
+}
+
+// description: validator errors of ShouldBindJSON and ShouldBindQuery become field details, malformed body stays bad request
+func NewBindingError(err error) Error {
+	validationErrors := validator.ValidationErrors{}
+	if !errors.As(err, &validationErrors) {
+		return NewError(common.ErrorCode.BadRequest, err.Error())
+	}
+
+	details := []ErrorDetail{}
+	for _, fieldError := range validationErrors {
+		detail := ErrorDetail{
+			Field: bindingNamingStrategy.ColumnName("", fieldError.Field()),
+			Rule:  fieldError.Tag(),
+			Param: fieldError.Param(),
 		}
+		details = append(details, translateErrorDetail(i18n.DefaultLocale, detail))
+	}
+
+	return NewValidationError(details)
+}
+
+func NewValidationError(details []ErrorDetail) Error {
+	validationError := NewError(common.ErrorCode.Validation, "validation failed")
+	validationError.Details = details
+
+	return validationError
+}
+
+// description: duplicate value is a conflict, missing reference or failed check is unprocessable input
+func newConstraintError(constraintError entity.ConstraintError) Error {
+	code := common.ErrorCode.InvalidValue
+	switch constraintError.Type {
+	case entity.UniqueConstraintType:
+		code = common.ErrorCode.DuplicateValue
+	case entity.ForeignKeyConstraintType:
+		code = common.ErrorCode.ReferenceNotFound
+	}
+
+	detail := translateErrorDetail(i18n.DefaultLocale, ErrorDetail{Field: constraintError.Field, Rule: string(constraintError.Type)})
+	constraintDetailError := NewError(code, detail.Message)
+	constraintDetailError.Details = []ErrorDetail{detail}
+
+	return constraintDetailError
+}
 
-		if e.Message != "" && config.Environment != common.Environment.Production {
-			ginContext.AbortWithStatusJSON(e.Code, e.Message)
-		} else {
-			ginContext.AbortWithStatus(e.Code)
+// description: key of field and rule is tried first, e.g. validation.password.pattern, so one field can word a shared rule differently
+func translateErrorDetail(locale string, detail ErrorDetail) ErrorDetail {
+	params := map[string]string{"field": translateField(locale, detail.Field), "param": detail.Param, "rule": detail.Rule}
+	for _, key := range []string{
+		fmt.Sprintf("validation.%s.%s", detail.Field, detail.Rule),
+		fmt.Sprintf("validation.%s", detail.Rule),
+		"validation.default",
+	} {
+		message, ok := i18n.Translate(locale, key, params)
+		if ok {
+			detail.Message = message
+			return detail
 		}
+	}
 
-		return
-	default:
-		log.Println(err.Error())
-		ginContext.AbortWithError(500, err)
+	return detail
+}
 
-		return
+func translateField(locale string, field string) string {
+	label, ok := i18n.Translate(locale, fmt.Sprintf("field.%s", field), nil)
+	if !ok {
+		return field
 	}
+
+	return label
 }
 
-func NewError(code int, message string) Error {
-	return Error{Code: code, Message: message}
+func errorCodeStatus(code string) int {
+	status, ok := errorCodeStatuses[code]
+	if !ok {
+		return http.StatusInternalServerError
+	}
+
+	return status
+}
+
+// description: problem details when configured or when client asks for it in accept header
+func isProblemRequested(ginContext *gin.Context) bool {
+	if config.Server.ErrorFormat == problemErrorFormat {
+		return true
+	}
+
+	return ginContext.Request != nil && strings.Contains(ginContext.Request.Header.Get("Accept"), problemContentType)
+}
+
+func requestPath(ginContext *gin.Context) string {
+	if ginContext.Request == nil || ginContext.Request.URL == nil {
+		return ""
+	}
+
+	return ginContext.Request.URL.Path
 }
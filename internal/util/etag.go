@@ -0,0 +1,65 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sndzhng/gin-template/internal/common"
+)
+
+const (
+	ETagHeader        = "ETag"
+	IfMatchHeader     = "If-Match"
+	IfNoneMatchHeader = "If-None-Match"
+)
+
+// description: strong entity tag of a versioned resource, empty when version is unknown
+func ETag(version *uint64) string {
+	if version == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("\"%d\"", *version)
+}
+
+// description: version expected by If-Match, required so a change cannot silently overwrite another, nil for * which matches any existing version
+func GetIfMatchVersion(ginContext *gin.Context) (*uint64, error) {
+	ifMatch := strings.TrimSpace(ginContext.GetHeader(IfMatchHeader))
+	if ifMatch == "" {
+		return nil, NewError(common.ErrorCode.PreconditionRequired, "If-Match header is required")
+	}
+	if ifMatch == "*" {
+		return nil, nil
+	}
+
+	version, err := strconv.ParseUint(strings.Trim(ifMatch, "\""), 10, 64)
+	if err != nil {
+		return nil, NewError(common.ErrorCode.BadRequest, "invalid If-Match header")
+	}
+
+	return &version, nil
+}
+
+// description: set ETag header and report whether If-None-Match already has it, weak comparison as read is cacheable
+func IsNotModified(ginContext *gin.Context, version *uint64) bool {
+	etag := ETag(version)
+	if etag == "" {
+		return false
+	}
+	ginContext.Header(ETagHeader, etag)
+
+	ifNoneMatch := ginContext.GetHeader(IfNoneMatchHeader)
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -5,6 +5,7 @@
 package repositorymock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,73 +36,228 @@ func (m *MockAdmin) EXPECT() *MockAdminMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockAdmin) Create(arg0 entity.Admin) error {
+func (m *MockAdmin) Create(arg0 context.Context, arg1 entity.Admin, arg2 ...entity.AuditLog) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0)
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockAdminMockRecorder) Create(arg0 interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) Create(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAdmin)(nil).Create), arg0)
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAdmin)(nil).Create), varargs...)
+}
+
+// CreateFirst mocks base method.
+func (m *MockAdmin) CreateFirst(arg0 context.Context, arg1 entity.Admin, arg2 ...entity.AuditLog) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateFirst", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFirst indicates an expected call of CreateFirst.
+func (mr *MockAdminMockRecorder) CreateFirst(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFirst", reflect.TypeOf((*MockAdmin)(nil).CreateFirst), varargs...)
 }
 
 // Delete mocks base method.
-func (m *MockAdmin) Delete(arg0 entity.Admin) error {
+func (m *MockAdmin) Delete(arg0 context.Context, arg1 entity.Admin, arg2 ...entity.AuditLog) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0)
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockAdminMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) Delete(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAdmin)(nil).Delete), arg0)
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAdmin)(nil).Delete), varargs...)
 }
 
 // Get mocks base method.
-func (m *MockAdmin) Get(arg0 entity.Admin) (entity.Admin, error) {
+func (m *MockAdmin) Get(arg0 context.Context, arg1 entity.Admin) (entity.Admin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0)
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
 	ret0, _ := ret[0].(entity.Admin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockAdminMockRecorder) Get(arg0 interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAdmin)(nil).Get), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAdmin)(nil).Get), arg0, arg1)
 }
 
 // GetAll mocks base method.
-func (m *MockAdmin) GetAll(arg0 *entity.AdminFilter, arg1 *entity.SortOrder, arg2 *entity.Pagination) ([]entity.Admin, error) {
+func (m *MockAdmin) GetAll(arg0 context.Context, arg1 *entity.AdminFilter, arg2 *entity.SortOrder, arg3 *entity.Pagination) ([]entity.Admin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]entity.Admin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockAdminMockRecorder) GetAll(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) GetAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAdmin)(nil).GetAll), arg0, arg1, arg2, arg3)
+}
+
+// GetDeleted mocks base method.
+func (m *MockAdmin) GetDeleted(arg0 context.Context, arg1 entity.Admin) (entity.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", arg0, arg1)
+	ret0, _ := ret[0].(entity.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockAdminMockRecorder) GetDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockAdmin)(nil).GetDeleted), arg0, arg1)
+}
+
+// IncreaseFailedLogin mocks base method.
+func (m *MockAdmin) IncreaseFailedLogin(arg0 context.Context, arg1 entity.Admin) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseFailedLogin", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncreaseFailedLogin indicates an expected call of IncreaseFailedLogin.
+func (mr *MockAdminMockRecorder) IncreaseFailedLogin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseFailedLogin", reflect.TypeOf((*MockAdmin)(nil).IncreaseFailedLogin), arg0, arg1)
+}
+
+// Purge mocks base method.
+func (m *MockAdmin) Purge(arg0 context.Context, arg1 entity.Admin, arg2 ...entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Purge", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockAdminMockRecorder) Purge(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAdmin)(nil).GetAll), arg0, arg1, arg2)
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockAdmin)(nil).Purge), varargs...)
+}
+
+// Restore mocks base method.
+func (m *MockAdmin) Restore(arg0 context.Context, arg1 entity.Admin, arg2 ...entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Restore", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockAdminMockRecorder) Restore(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAdmin)(nil).Restore), varargs...)
 }
 
 // Update mocks base method.
-func (m *MockAdmin) Update(arg0 entity.Admin) error {
+func (m *MockAdmin) Update(arg0 context.Context, arg1 entity.Admin, arg2 ...entity.AuditLog) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0)
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockAdminMockRecorder) Update(arg0 interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) Update(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAdmin)(nil).Update), varargs...)
+}
+
+// UpdateLock mocks base method.
+func (m *MockAdmin) UpdateLock(arg0 context.Context, arg1 entity.Admin, arg2 ...entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateLock", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLock indicates an expected call of UpdateLock.
+func (mr *MockAdminMockRecorder) UpdateLock(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLock", reflect.TypeOf((*MockAdmin)(nil).UpdateLock), varargs...)
+}
+
+// UpdateMFA mocks base method.
+func (m *MockAdmin) UpdateMFA(arg0 context.Context, arg1 entity.Admin, arg2 ...entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateMFA", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMFA indicates an expected call of UpdateMFA.
+func (mr *MockAdminMockRecorder) UpdateMFA(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMFA", reflect.TypeOf((*MockAdmin)(nil).UpdateMFA), varargs...)
+}
+
+// UpdateMFAStep mocks base method.
+func (m *MockAdmin) UpdateMFAStep(arg0 context.Context, arg1 entity.Admin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMFAStep", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMFAStep indicates an expected call of UpdateMFAStep.
+func (mr *MockAdminMockRecorder) UpdateMFAStep(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAdmin)(nil).Update), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMFAStep", reflect.TypeOf((*MockAdmin)(nil).UpdateMFAStep), arg0, arg1)
 }
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sndzhng/gin-template/internal/repository (interfaces: OIDCState)
+
+// Package repositorymock is a generated GoMock package.
+package repositorymock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/sndzhng/gin-template/internal/entity"
+)
+
+// MockOIDCState is a mock of OIDCState interface.
+type MockOIDCState struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCStateMockRecorder
+}
+
+// MockOIDCStateMockRecorder is the mock recorder for MockOIDCState.
+type MockOIDCStateMockRecorder struct {
+	mock *MockOIDCState
+}
+
+// NewMockOIDCState creates a new mock instance.
+func NewMockOIDCState(ctrl *gomock.Controller) *MockOIDCState {
+	mock := &MockOIDCState{ctrl: ctrl}
+	mock.recorder = &MockOIDCStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCState) EXPECT() *MockOIDCStateMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOIDCState) Create(arg0 context.Context, arg1 entity.OIDCState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOIDCStateMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOIDCState)(nil).Create), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockOIDCState) Get(arg0 context.Context, arg1 entity.OIDCState) (entity.OIDCState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(entity.OIDCState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOIDCStateMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOIDCState)(nil).Get), arg0, arg1)
+}
+
+// Use mocks base method.
+func (m *MockOIDCState) Use(arg0 context.Context, arg1 entity.OIDCState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Use", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Use indicates an expected call of Use.
+func (mr *MockOIDCStateMockRecorder) Use(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Use", reflect.TypeOf((*MockOIDCState)(nil).Use), arg0, arg1)
+}
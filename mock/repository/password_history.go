@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sndzhng/gin-template/internal/repository (interfaces: PasswordHistory)
+
+// Package repositorymock is a generated GoMock package.
+package repositorymock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/sndzhng/gin-template/internal/entity"
+)
+
+// MockPasswordHistory is a mock of PasswordHistory interface.
+type MockPasswordHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordHistoryMockRecorder
+}
+
+// MockPasswordHistoryMockRecorder is the mock recorder for MockPasswordHistory.
+type MockPasswordHistoryMockRecorder struct {
+	mock *MockPasswordHistory
+}
+
+// NewMockPasswordHistory creates a new mock instance.
+func NewMockPasswordHistory(ctrl *gomock.Controller) *MockPasswordHistory {
+	mock := &MockPasswordHistory{ctrl: ctrl}
+	mock.recorder = &MockPasswordHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordHistory) EXPECT() *MockPasswordHistoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPasswordHistory) Create(arg0 context.Context, arg1 entity.PasswordHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPasswordHistoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPasswordHistory)(nil).Create), arg0, arg1)
+}
+
+// GetAll mocks base method.
+func (m *MockPasswordHistory) GetAll(arg0 context.Context, arg1 entity.PasswordHistory, arg2 int) ([]entity.PasswordHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]entity.PasswordHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPasswordHistoryMockRecorder) GetAll(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPasswordHistory)(nil).GetAll), arg0, arg1, arg2)
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sndzhng/gin-template/internal/repository (interfaces: PasswordResetToken)
+
+// Package repositorymock is a generated GoMock package.
+package repositorymock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/sndzhng/gin-template/internal/entity"
+)
+
+// MockPasswordResetToken is a mock of PasswordResetToken interface.
+type MockPasswordResetToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetTokenMockRecorder
+}
+
+// MockPasswordResetTokenMockRecorder is the mock recorder for MockPasswordResetToken.
+type MockPasswordResetTokenMockRecorder struct {
+	mock *MockPasswordResetToken
+}
+
+// NewMockPasswordResetToken creates a new mock instance.
+func NewMockPasswordResetToken(ctrl *gomock.Controller) *MockPasswordResetToken {
+	mock := &MockPasswordResetToken{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetToken) EXPECT() *MockPasswordResetTokenMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPasswordResetToken) Create(arg0 context.Context, arg1 entity.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPasswordResetTokenMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPasswordResetToken)(nil).Create), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockPasswordResetToken) Get(arg0 context.Context, arg1 entity.PasswordResetToken) (entity.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(entity.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPasswordResetTokenMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPasswordResetToken)(nil).Get), arg0, arg1)
+}
+
+// Use mocks base method.
+func (m *MockPasswordResetToken) Use(arg0 context.Context, arg1 entity.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Use", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Use indicates an expected call of Use.
+func (mr *MockPasswordResetTokenMockRecorder) Use(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Use", reflect.TypeOf((*MockPasswordResetToken)(nil).Use), arg0, arg1)
+}
+
+// UseAll mocks base method.
+func (m *MockPasswordResetToken) UseAll(arg0 context.Context, arg1 entity.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseAll", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseAll indicates an expected call of UseAll.
+func (mr *MockPasswordResetTokenMockRecorder) UseAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseAll", reflect.TypeOf((*MockPasswordResetToken)(nil).UseAll), arg0, arg1)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sndzhng/gin-template/internal/repository (interfaces: Permission)
+
+// Package repositorymock is a generated GoMock package.
+package repositorymock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/sndzhng/gin-template/internal/entity"
+)
+
+// MockPermission is a mock of Permission interface.
+type MockPermission struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionMockRecorder
+}
+
+// MockPermissionMockRecorder is the mock recorder for MockPermission.
+type MockPermissionMockRecorder struct {
+	mock *MockPermission
+}
+
+// NewMockPermission creates a new mock instance.
+func NewMockPermission(ctrl *gomock.Controller) *MockPermission {
+	mock := &MockPermission{ctrl: ctrl}
+	mock.recorder = &MockPermissionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermission) EXPECT() *MockPermissionMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockPermission) GetAll(arg0 context.Context) ([]entity.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]entity.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPermissionMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPermission)(nil).GetAll), arg0)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sndzhng/gin-template/internal/repository (interfaces: Policy)
+
+// Package repositorymock is a generated GoMock package.
+package repositorymock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/sndzhng/gin-template/internal/entity"
+)
+
+// MockPolicy is a mock of Policy interface.
+type MockPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyMockRecorder
+}
+
+// MockPolicyMockRecorder is the mock recorder for MockPolicy.
+type MockPolicyMockRecorder struct {
+	mock *MockPolicy
+}
+
+// NewMockPolicy creates a new mock instance.
+func NewMockPolicy(ctrl *gomock.Controller) *MockPolicy {
+	mock := &MockPolicy{ctrl: ctrl}
+	mock.recorder = &MockPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicy) EXPECT() *MockPolicyMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockPolicy) Get(arg0 context.Context) (entity.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(entity.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPolicyMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPolicy)(nil).Get), arg0)
+}
+
+// Update mocks base method.
+func (m *MockPolicy) Update(arg0 context.Context, arg1 entity.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPolicyMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPolicy)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sndzhng/gin-template/internal/repository (interfaces: RecoveryCode)
+
+// Package repositorymock is a generated GoMock package.
+package repositorymock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/sndzhng/gin-template/internal/entity"
+)
+
+// MockRecoveryCode is a mock of RecoveryCode interface.
+type MockRecoveryCode struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecoveryCodeMockRecorder
+}
+
+// MockRecoveryCodeMockRecorder is the mock recorder for MockRecoveryCode.
+type MockRecoveryCodeMockRecorder struct {
+	mock *MockRecoveryCode
+}
+
+// NewMockRecoveryCode creates a new mock instance.
+func NewMockRecoveryCode(ctrl *gomock.Controller) *MockRecoveryCode {
+	mock := &MockRecoveryCode{ctrl: ctrl}
+	mock.recorder = &MockRecoveryCodeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecoveryCode) EXPECT() *MockRecoveryCodeMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRecoveryCode) Create(arg0 context.Context, arg1 entity.RecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRecoveryCodeMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRecoveryCode)(nil).Create), arg0, arg1)
+}
+
+// DeleteAll mocks base method.
+func (m *MockRecoveryCode) DeleteAll(arg0 context.Context, arg1 entity.RecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAll indicates an expected call of DeleteAll.
+func (mr *MockRecoveryCodeMockRecorder) DeleteAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockRecoveryCode)(nil).DeleteAll), arg0, arg1)
+}
+
+// Use mocks base method.
+func (m *MockRecoveryCode) Use(arg0 context.Context, arg1 entity.RecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Use", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Use indicates an expected call of Use.
+func (mr *MockRecoveryCodeMockRecorder) Use(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Use", reflect.TypeOf((*MockRecoveryCode)(nil).Use), arg0, arg1)
+}
@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sndzhng/gin-template/internal/repository (interfaces: RefreshToken)
+
+// Package repositorymock is a generated GoMock package.
+package repositorymock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/sndzhng/gin-template/internal/entity"
+)
+
+// MockRefreshToken is a mock of RefreshToken interface.
+type MockRefreshToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenMockRecorder
+}
+
+// MockRefreshTokenMockRecorder is the mock recorder for MockRefreshToken.
+type MockRefreshTokenMockRecorder struct {
+	mock *MockRefreshToken
+}
+
+// NewMockRefreshToken creates a new mock instance.
+func NewMockRefreshToken(ctrl *gomock.Controller) *MockRefreshToken {
+	mock := &MockRefreshToken{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshToken) EXPECT() *MockRefreshTokenMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRefreshToken) Create(arg0 context.Context, arg1 entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefreshTokenMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefreshToken)(nil).Create), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockRefreshToken) Get(arg0 context.Context, arg1 entity.RefreshToken) (entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRefreshTokenMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRefreshToken)(nil).Get), arg0, arg1)
+}
+
+// Revoke mocks base method.
+func (m *MockRefreshToken) Revoke(arg0 context.Context, arg1 entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockRefreshTokenMockRecorder) Revoke(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockRefreshToken)(nil).Revoke), arg0, arg1)
+}
+
+// RevokeAll mocks base method.
+func (m *MockRefreshToken) RevokeAll(arg0 context.Context, arg1 entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockRefreshTokenMockRecorder) RevokeAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockRefreshToken)(nil).RevokeAll), arg0, arg1)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockRefreshToken) RevokeFamily(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockRefreshTokenMockRecorder) RevokeFamily(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockRefreshToken)(nil).RevokeFamily), arg0, arg1)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sndzhng/gin-template/internal/repository (interfaces: RevokedToken)
+
+// Package repositorymock is a generated GoMock package.
+package repositorymock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/sndzhng/gin-template/internal/entity"
+)
+
+// MockRevokedToken is a mock of RevokedToken interface.
+type MockRevokedToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevokedTokenMockRecorder
+}
+
+// MockRevokedTokenMockRecorder is the mock recorder for MockRevokedToken.
+type MockRevokedTokenMockRecorder struct {
+	mock *MockRevokedToken
+}
+
+// NewMockRevokedToken creates a new mock instance.
+func NewMockRevokedToken(ctrl *gomock.Controller) *MockRevokedToken {
+	mock := &MockRevokedToken{ctrl: ctrl}
+	mock.recorder = &MockRevokedTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevokedToken) EXPECT() *MockRevokedTokenMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRevokedToken) Create(arg0 context.Context, arg1 entity.RevokedToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRevokedTokenMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRevokedToken)(nil).Create), arg0, arg1)
+}
+
+// IsRevoked mocks base method.
+func (m *MockRevokedToken) IsRevoked(arg0 context.Context, arg1 entity.RevokedToken, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockRevokedTokenMockRecorder) IsRevoked(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockRevokedToken)(nil).IsRevoked), arg0, arg1, arg2)
+}
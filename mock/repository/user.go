@@ -5,6 +5,7 @@
 package repositorymock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,73 +36,208 @@ func (m *MockUser) EXPECT() *MockUserMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockUser) Create(arg0 entity.User) error {
+func (m *MockUser) Create(arg0 context.Context, arg1 entity.User, arg2 ...entity.AuditLog) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0)
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockUserMockRecorder) Create(arg0 interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) Create(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), arg0)
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), varargs...)
 }
 
 // Delete mocks base method.
-func (m *MockUser) Delete(arg0 entity.User) error {
+func (m *MockUser) Delete(arg0 context.Context, arg1 entity.User, arg2 ...entity.AuditLog) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0)
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUserMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) Delete(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUser)(nil).Delete), arg0)
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUser)(nil).Delete), varargs...)
 }
 
 // Get mocks base method.
-func (m *MockUser) Get(arg0 entity.User) (entity.User, error) {
+func (m *MockUser) Get(arg0 context.Context, arg1 entity.User) (entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0)
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
 	ret0, _ := ret[0].(entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockUserMockRecorder) Get(arg0 interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUser)(nil).Get), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUser)(nil).Get), arg0, arg1)
 }
 
 // GetAll mocks base method.
-func (m *MockUser) GetAll(arg0 *entity.UserFilter, arg1 *entity.SortOrder, arg2 *entity.Pagination) ([]entity.User, error) {
+func (m *MockUser) GetAll(arg0 context.Context, arg1 *entity.UserFilter, arg2 *entity.SortOrder, arg3 *entity.Pagination) ([]entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockUserMockRecorder) GetAll(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) GetAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUser)(nil).GetAll), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUser)(nil).GetAll), arg0, arg1, arg2, arg3)
+}
+
+// GetDeleted mocks base method.
+func (m *MockUser) GetDeleted(arg0 context.Context, arg1 entity.User) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", arg0, arg1)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockUserMockRecorder) GetDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockUser)(nil).GetDeleted), arg0, arg1)
+}
+
+// IncreaseFailedLogin mocks base method.
+func (m *MockUser) IncreaseFailedLogin(arg0 context.Context, arg1 entity.User) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseFailedLogin", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncreaseFailedLogin indicates an expected call of IncreaseFailedLogin.
+func (mr *MockUserMockRecorder) IncreaseFailedLogin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseFailedLogin", reflect.TypeOf((*MockUser)(nil).IncreaseFailedLogin), arg0, arg1)
+}
+
+// Purge mocks base method.
+func (m *MockUser) Purge(arg0 context.Context, arg1 entity.User, arg2 ...entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Purge", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockUserMockRecorder) Purge(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockUser)(nil).Purge), varargs...)
+}
+
+// Restore mocks base method.
+func (m *MockUser) Restore(arg0 context.Context, arg1 entity.User, arg2 ...entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Restore", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockUserMockRecorder) Restore(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUser)(nil).Restore), varargs...)
 }
 
 // Update mocks base method.
-func (m *MockUser) Update(arg0 entity.User) error {
+func (m *MockUser) Update(arg0 context.Context, arg1 entity.User, arg2 ...entity.AuditLog) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0)
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockUserMockRecorder) Update(arg0 interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) Update(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), varargs...)
+}
+
+// UpdateLock mocks base method.
+func (m *MockUser) UpdateLock(arg0 context.Context, arg1 entity.User, arg2 ...entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateLock", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLock indicates an expected call of UpdateLock.
+func (mr *MockUserMockRecorder) UpdateLock(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLock", reflect.TypeOf((*MockUser)(nil).UpdateLock), varargs...)
+}
+
+// UpdateMFA mocks base method.
+func (m *MockUser) UpdateMFA(arg0 context.Context, arg1 entity.User, arg2 ...entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateMFA", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMFA indicates an expected call of UpdateMFA.
+func (mr *MockUserMockRecorder) UpdateMFA(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMFA", reflect.TypeOf((*MockUser)(nil).UpdateMFA), varargs...)
+}
+
+// UpdateMFAStep mocks base method.
+func (m *MockUser) UpdateMFAStep(arg0 context.Context, arg1 entity.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMFAStep", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMFAStep indicates an expected call of UpdateMFAStep.
+func (mr *MockUserMockRecorder) UpdateMFAStep(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMFAStep", reflect.TypeOf((*MockUser)(nil).UpdateMFAStep), arg0, arg1)
 }
@@ -5,6 +5,7 @@
 package usecasemock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,88 +35,130 @@ func (m *MockAdmin) EXPECT() *MockAdminMockRecorder {
 	return m.recorder
 }
 
+// Bootstrap mocks base method.
+func (m *MockAdmin) Bootstrap(arg0 context.Context, arg1 entity.Admin, arg2 entity.Audit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bootstrap", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Bootstrap indicates an expected call of Bootstrap.
+func (mr *MockAdminMockRecorder) Bootstrap(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bootstrap", reflect.TypeOf((*MockAdmin)(nil).Bootstrap), arg0, arg1, arg2)
+}
+
 // Create mocks base method.
-func (m *MockAdmin) Create(arg0 entity.Admin) error {
+func (m *MockAdmin) Create(arg0 context.Context, arg1 entity.Admin, arg2 entity.Audit) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockAdminMockRecorder) Create(arg0 interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAdmin)(nil).Create), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAdmin)(nil).Create), arg0, arg1, arg2)
 }
 
 // Delete mocks base method.
-func (m *MockAdmin) Delete(arg0 entity.Admin) error {
+func (m *MockAdmin) Delete(arg0 context.Context, arg1 entity.Admin, arg2 entity.Audit) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockAdminMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAdmin)(nil).Delete), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAdmin)(nil).Delete), arg0, arg1, arg2)
 }
 
 // Get mocks base method.
-func (m *MockAdmin) Get(arg0 entity.Admin) (entity.Admin, error) {
+func (m *MockAdmin) Get(arg0 context.Context, arg1 entity.Admin) (entity.Admin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0)
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
 	ret0, _ := ret[0].(entity.Admin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockAdminMockRecorder) Get(arg0 interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAdmin)(nil).Get), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAdmin)(nil).Get), arg0, arg1)
 }
 
 // GetAll mocks base method.
-func (m *MockAdmin) GetAll(arg0 *entity.AdminFilter, arg1 *entity.SortOrder, arg2 *entity.Pagination) ([]entity.Admin, error) {
+func (m *MockAdmin) GetAll(arg0 context.Context, arg1 *entity.AdminFilter, arg2 *entity.SortOrder, arg3 *entity.Pagination) ([]entity.Admin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]entity.Admin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockAdminMockRecorder) GetAll(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) GetAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAdmin)(nil).GetAll), arg0, arg1, arg2, arg3)
+}
+
+// Purge mocks base method.
+func (m *MockAdmin) Purge(arg0 context.Context, arg1 entity.Admin, arg2 entity.Audit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockAdminMockRecorder) Purge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockAdmin)(nil).Purge), arg0, arg1, arg2)
+}
+
+// Restore mocks base method.
+func (m *MockAdmin) Restore(arg0 context.Context, arg1 entity.Admin, arg2 entity.Audit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockAdminMockRecorder) Restore(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAdmin)(nil).GetAll), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAdmin)(nil).Restore), arg0, arg1, arg2)
 }
 
-// Initial mocks base method.
-func (m *MockAdmin) Initial() error {
+// Unlock mocks base method.
+func (m *MockAdmin) Unlock(arg0 context.Context, arg1 entity.Admin, arg2 entity.Audit) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Initial")
+	ret := m.ctrl.Call(m, "Unlock", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Initial indicates an expected call of Initial.
-func (mr *MockAdminMockRecorder) Initial() *gomock.Call {
+// Unlock indicates an expected call of Unlock.
+func (mr *MockAdminMockRecorder) Unlock(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Initial", reflect.TypeOf((*MockAdmin)(nil).Initial))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockAdmin)(nil).Unlock), arg0, arg1, arg2)
 }
 
 // Update mocks base method.
-func (m *MockAdmin) Update(arg0 entity.Admin) error {
+func (m *MockAdmin) Update(arg0 context.Context, arg1 entity.Admin, arg2 entity.Audit) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockAdminMockRecorder) Update(arg0 interface{}) *gomock.Call {
+func (mr *MockAdminMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAdmin)(nil).Update), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAdmin)(nil).Update), arg0, arg1, arg2)
 }
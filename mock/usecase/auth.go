@@ -5,6 +5,7 @@
 package usecasemock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,45 +36,205 @@ func (m *MockAuth) EXPECT() *MockAuthMockRecorder {
 }
 
 // AdminLogin mocks base method.
-func (m *MockAuth) AdminLogin(arg0 entity.Login) (entity.AccessToken, error) {
+func (m *MockAuth) AdminLogin(arg0 context.Context, arg1 entity.Login) (entity.AccessToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminLogin", arg0)
+	ret := m.ctrl.Call(m, "AdminLogin", arg0, arg1)
 	ret0, _ := ret[0].(entity.AccessToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AdminLogin indicates an expected call of AdminLogin.
-func (mr *MockAuthMockRecorder) AdminLogin(arg0 interface{}) *gomock.Call {
+func (mr *MockAuthMockRecorder) AdminLogin(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminLogin", reflect.TypeOf((*MockAuth)(nil).AdminLogin), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminLogin", reflect.TypeOf((*MockAuth)(nil).AdminLogin), arg0, arg1)
+}
+
+// AdminOIDCCallback mocks base method.
+func (m *MockAuth) AdminOIDCCallback(arg0 context.Context, arg1 entity.OIDCCallback) (entity.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminOIDCCallback", arg0, arg1)
+	ret0, _ := ret[0].(entity.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminOIDCCallback indicates an expected call of AdminOIDCCallback.
+func (mr *MockAuthMockRecorder) AdminOIDCCallback(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminOIDCCallback", reflect.TypeOf((*MockAuth)(nil).AdminOIDCCallback), arg0, arg1)
+}
+
+// AdminOIDCLogin mocks base method.
+func (m *MockAuth) AdminOIDCLogin(arg0 context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminOIDCLogin", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminOIDCLogin indicates an expected call of AdminOIDCLogin.
+func (mr *MockAuthMockRecorder) AdminOIDCLogin(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminOIDCLogin", reflect.TypeOf((*MockAuth)(nil).AdminOIDCLogin), arg0)
+}
+
+// AdminRefresh mocks base method.
+func (m *MockAuth) AdminRefresh(arg0 context.Context, arg1 entity.Refresh) (entity.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminRefresh", arg0, arg1)
+	ret0, _ := ret[0].(entity.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminRefresh indicates an expected call of AdminRefresh.
+func (mr *MockAuthMockRecorder) AdminRefresh(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminRefresh", reflect.TypeOf((*MockAuth)(nil).AdminRefresh), arg0, arg1)
+}
+
+// AdminReset mocks base method.
+func (m *MockAuth) AdminReset(arg0 context.Context, arg1 entity.Reset, arg2 entity.Audit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminReset", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminReset indicates an expected call of AdminReset.
+func (mr *MockAuthMockRecorder) AdminReset(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminReset", reflect.TypeOf((*MockAuth)(nil).AdminReset), arg0, arg1, arg2)
+}
+
+// AdminVerifyMFA mocks base method.
+func (m *MockAuth) AdminVerifyMFA(arg0 context.Context, arg1 entity.MFA, arg2 entity.Audit) (entity.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminVerifyMFA", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminVerifyMFA indicates an expected call of AdminVerifyMFA.
+func (mr *MockAuthMockRecorder) AdminVerifyMFA(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminVerifyMFA", reflect.TypeOf((*MockAuth)(nil).AdminVerifyMFA), arg0, arg1, arg2)
+}
+
+// Logout mocks base method.
+func (m *MockAuth) Logout(arg0 context.Context, arg1 entity.Logout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthMockRecorder) Logout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuth)(nil).Logout), arg0, arg1)
+}
+
+// LogoutAll mocks base method.
+func (m *MockAuth) LogoutAll(arg0 context.Context, arg1 entity.Logout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockAuthMockRecorder) LogoutAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockAuth)(nil).LogoutAll), arg0, arg1)
+}
+
+// UserForgot mocks base method.
+func (m *MockAuth) UserForgot(arg0 context.Context, arg1 entity.Forgot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserForgot", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserForgot indicates an expected call of UserForgot.
+func (mr *MockAuthMockRecorder) UserForgot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserForgot", reflect.TypeOf((*MockAuth)(nil).UserForgot), arg0, arg1)
+}
+
+// UserForgotConfirm mocks base method.
+func (m *MockAuth) UserForgotConfirm(arg0 context.Context, arg1 entity.ForgotConfirm, arg2 entity.Audit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserForgotConfirm", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserForgotConfirm indicates an expected call of UserForgotConfirm.
+func (mr *MockAuthMockRecorder) UserForgotConfirm(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserForgotConfirm", reflect.TypeOf((*MockAuth)(nil).UserForgotConfirm), arg0, arg1, arg2)
 }
 
 // UserLogin mocks base method.
-func (m *MockAuth) UserLogin(arg0 entity.Login) (entity.AccessToken, error) {
+func (m *MockAuth) UserLogin(arg0 context.Context, arg1 entity.Login) (entity.AccessToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserLogin", arg0)
+	ret := m.ctrl.Call(m, "UserLogin", arg0, arg1)
 	ret0, _ := ret[0].(entity.AccessToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UserLogin indicates an expected call of UserLogin.
-func (mr *MockAuthMockRecorder) UserLogin(arg0 interface{}) *gomock.Call {
+func (mr *MockAuthMockRecorder) UserLogin(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserLogin", reflect.TypeOf((*MockAuth)(nil).UserLogin), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserLogin", reflect.TypeOf((*MockAuth)(nil).UserLogin), arg0, arg1)
+}
+
+// UserRefresh mocks base method.
+func (m *MockAuth) UserRefresh(arg0 context.Context, arg1 entity.Refresh) (entity.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserRefresh", arg0, arg1)
+	ret0, _ := ret[0].(entity.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserRefresh indicates an expected call of UserRefresh.
+func (mr *MockAuthMockRecorder) UserRefresh(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserRefresh", reflect.TypeOf((*MockAuth)(nil).UserRefresh), arg0, arg1)
 }
 
 // UserReset mocks base method.
-func (m *MockAuth) UserReset(arg0 entity.Reset) error {
+func (m *MockAuth) UserReset(arg0 context.Context, arg1 entity.Reset, arg2 entity.Audit) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserReset", arg0)
+	ret := m.ctrl.Call(m, "UserReset", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UserReset indicates an expected call of UserReset.
-func (mr *MockAuthMockRecorder) UserReset(arg0 interface{}) *gomock.Call {
+func (mr *MockAuthMockRecorder) UserReset(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserReset", reflect.TypeOf((*MockAuth)(nil).UserReset), arg0, arg1, arg2)
+}
+
+// UserVerifyMFA mocks base method.
+func (m *MockAuth) UserVerifyMFA(arg0 context.Context, arg1 entity.MFA, arg2 entity.Audit) (entity.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserVerifyMFA", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserVerifyMFA indicates an expected call of UserVerifyMFA.
+func (mr *MockAuthMockRecorder) UserVerifyMFA(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserReset", reflect.TypeOf((*MockAuth)(nil).UserReset), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserVerifyMFA", reflect.TypeOf((*MockAuth)(nil).UserVerifyMFA), arg0, arg1, arg2)
 }
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sndzhng/gin-template/internal/usecase (interfaces: Impersonation)
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/sndzhng/gin-template/internal/entity"
+)
+
+// MockImpersonation is a mock of Impersonation interface.
+type MockImpersonation struct {
+	ctrl     *gomock.Controller
+	recorder *MockImpersonationMockRecorder
+}
+
+// MockImpersonationMockRecorder is the mock recorder for MockImpersonation.
+type MockImpersonationMockRecorder struct {
+	mock *MockImpersonation
+}
+
+// NewMockImpersonation creates a new mock instance.
+func NewMockImpersonation(ctrl *gomock.Controller) *MockImpersonation {
+	mock := &MockImpersonation{ctrl: ctrl}
+	mock.recorder = &MockImpersonationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImpersonation) EXPECT() *MockImpersonationMockRecorder {
+	return m.recorder
+}
+
+// Impersonate mocks base method.
+func (m *MockImpersonation) Impersonate(arg0 context.Context, arg1 entity.Impersonation) (entity.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Impersonate", arg0, arg1)
+	ret0, _ := ret[0].(entity.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Impersonate indicates an expected call of Impersonate.
+func (mr *MockImpersonationMockRecorder) Impersonate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Impersonate", reflect.TypeOf((*MockImpersonation)(nil).Impersonate), arg0, arg1)
+}
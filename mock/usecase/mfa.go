@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sndzhng/gin-template/internal/usecase (interfaces: MFA)
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/sndzhng/gin-template/internal/entity"
+)
+
+// MockMFA is a mock of MFA interface.
+type MockMFA struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAMockRecorder
+}
+
+// MockMFAMockRecorder is the mock recorder for MockMFA.
+type MockMFAMockRecorder struct {
+	mock *MockMFA
+}
+
+// NewMockMFA creates a new mock instance.
+func NewMockMFA(ctrl *gomock.Controller) *MockMFA {
+	mock := &MockMFA{ctrl: ctrl}
+	mock.recorder = &MockMFAMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFA) EXPECT() *MockMFAMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockMFA) Confirm(arg0 context.Context, arg1 entity.MFA, arg2 entity.Audit) (entity.MFARecovery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.MFARecovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockMFAMockRecorder) Confirm(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockMFA)(nil).Confirm), arg0, arg1, arg2)
+}
+
+// Disable mocks base method.
+func (m *MockMFA) Disable(arg0 context.Context, arg1 entity.MFA, arg2 entity.Audit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockMFAMockRecorder) Disable(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockMFA)(nil).Disable), arg0, arg1, arg2)
+}
+
+// Enroll mocks base method.
+func (m *MockMFA) Enroll(arg0 context.Context, arg1 entity.MFA, arg2 entity.Audit) (entity.MFAEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.MFAEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockMFAMockRecorder) Enroll(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockMFA)(nil).Enroll), arg0, arg1, arg2)
+}
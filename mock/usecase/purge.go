@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sndzhng/gin-template/internal/usecase (interfaces: Purge)
+
+// Package usecasemock is a generated GoMock package.
+package usecasemock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/sndzhng/gin-template/internal/entity"
+)
+
+// MockPurge is a mock of Purge interface.
+type MockPurge struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurgeMockRecorder
+}
+
+// MockPurgeMockRecorder is the mock recorder for MockPurge.
+type MockPurgeMockRecorder struct {
+	mock *MockPurge
+}
+
+// NewMockPurge creates a new mock instance.
+func NewMockPurge(ctrl *gomock.Controller) *MockPurge {
+	mock := &MockPurge{ctrl: ctrl}
+	mock.recorder = &MockPurgeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurge) EXPECT() *MockPurgeMockRecorder {
+	return m.recorder
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockPurge) PurgeDeleted(arg0 context.Context) (entity.PurgeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", arg0)
+	ret0, _ := ret[0].(entity.PurgeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockPurgeMockRecorder) PurgeDeleted(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockPurge)(nil).PurgeDeleted), arg0)
+}